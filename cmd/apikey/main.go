@@ -0,0 +1,76 @@
+// Command apikey issues an API key, e.g. the first admin key used to manage the others.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+
+	"remora/internal/api"
+	"remora/internal/apikey"
+	apikeyrepo "remora/internal/apikey/repository"
+	apikeysvc "remora/internal/apikey/service"
+	"remora/internal/config"
+	apiCfg "remora/internal/config/api"
+	"remora/internal/db"
+)
+
+func main() {
+	name := flag.String("name", "", "key name")
+	scopes := flag.String("scopes", string(apikey.ScopeAdmin), "comma-separated scopes")
+	expires := flag.Duration("expires", 0, "key lifetime; 0 never expires")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		AddSource: true,
+	}))
+
+	env := os.Getenv("ENV")
+	if env == "" {
+		env = "local"
+	}
+
+	cfg, err := config.LoadFromDir[*apiCfg.Config](env, "./config/api")
+	if err != nil {
+		logger.Error("load config failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	parsed, err := apikey.ParseScopes(strings.Split(*scopes, ","))
+	if err != nil {
+		logger.Error("parse scopes failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+
+	params := apikey.CreateParams{Name: *name, Scopes: parsed}
+
+	if *expires > 0 {
+		expiresAt := time.Now().Add(*expires)
+		params.ExpiresAt = &expiresAt
+	}
+
+	ctx := context.Background()
+
+	pool, err := api.NewPgxPool(ctx, cfg.AppConfig.PostgreSQL)
+	if err != nil {
+		logger.Error("connect database failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	key, raw, err := apikeysvc.New(apikeyrepo.New(db.New(pool))).Create(ctx, params)
+	if err != nil {
+		logger.Error("create api key failed", slog.Any("error", err))
+		pool.Close()
+		os.Exit(1) //nolint:gocritic // pool closed above
+	}
+
+	logger.Info("api key created", slog.String("id", key.ID.String()), slog.String("prefix", key.Prefix))
+
+	// The raw key is shown only once; it is printed alone on stdout so it can be piped.
+	fmt.Println(raw) //nolint:forbidigo // CLI output
+}
@@ -0,0 +1,76 @@
+// Command vaultregistry adds, enables or disables vaults in the registry the agent's postgres
+// vault source reads. It connects to DATABASE_URL, like the agent.
+//
+//	vaultregistry -address 0x... [-label name] add
+//	vaultregistry -address 0x... enable
+//	vaultregistry -address 0x... disable
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"remora/internal/agent"
+	"remora/internal/db"
+)
+
+func main() {
+	address := flag.String("address", "", "vault address")
+	label := flag.String("label", "", "vault label (add only)")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		AddSource: true,
+	}))
+
+	action := flag.Arg(0)
+	if flag.NArg() != 1 || (action != "add" && action != "enable" && action != "disable") {
+		logger.Error("usage: vaultregistry -address 0x... [-label name] add|enable|disable")
+		os.Exit(2)
+	}
+
+	if !common.IsHexAddress(*address) {
+		logger.Error("invalid vault address", slog.String("address", *address))
+		os.Exit(2)
+	}
+
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		logger.Error("DATABASE_URL not set")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		logger.Error("connect database failed", slog.Any("error", err))
+		os.Exit(1)
+	}
+	defer pool.Close()
+
+	registry := agent.NewPostgresVaultSource(db.New(pool))
+	vault := common.HexToAddress(*address)
+
+	switch action {
+	case "add":
+		err = registry.Add(ctx, vault, *label)
+	case "enable":
+		err = registry.SetEnabled(ctx, vault, true)
+	case "disable":
+		err = registry.SetEnabled(ctx, vault, false)
+	}
+
+	if err != nil {
+		logger.Error("update vault registry failed", slog.String("action", action), slog.Any("error", err))
+		pool.Close()
+		os.Exit(1) //nolint:gocritic // pool closed above
+	}
+
+	logger.Info("vault registry updated", slog.String("action", action), slog.String("address", vault.Hex()))
+}
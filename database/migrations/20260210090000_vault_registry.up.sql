@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS vault_registry (
+    address VARCHAR(42) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_vault_registry_enabled ON vault_registry(enabled);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS factory_vault;
+DROP TABLE IF EXISTS vault_discovery_cursor;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS vault_discovery_cursor (
+    factory_address VARCHAR(42) PRIMARY KEY,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS factory_vault (
+    factory_address VARCHAR(42) NOT NULL,
+    vault_address VARCHAR(42) NOT NULL,
+    block_number BIGINT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    log_index INT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (factory_address, vault_address)
+);
+
+CREATE INDEX idx_factory_vault_block ON factory_vault(factory_address, block_number, log_index);
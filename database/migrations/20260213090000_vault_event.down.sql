@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS vault_position;
+DROP TABLE IF EXISTS vault_config;
+DROP TABLE IF EXISTS vault_event;
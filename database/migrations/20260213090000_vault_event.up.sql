@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS vault_event (
+    vault_address VARCHAR(42) NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index INT NOT NULL,
+    block_hash VARCHAR(66) NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    event_type VARCHAR(64) NOT NULL,
+    data JSONB NOT NULL,
+    block_time TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (vault_address, block_number, log_index)
+);
+
+CREATE INDEX idx_vault_event_type ON vault_event(vault_address, event_type, block_number, log_index);
+
+CREATE TABLE IF NOT EXISTS vault_config (
+    vault_address VARCHAR(42) PRIMARY KEY,
+    owner VARCHAR(42) NOT NULL,
+    agent VARCHAR(42) NOT NULL,
+    agent_paused BOOLEAN NOT NULL,
+    swap_allowed BOOLEAN NOT NULL,
+    allowed_tick_lower INT NOT NULL,
+    allowed_tick_upper INT NOT NULL,
+    max_positions_k NUMERIC(78, 0) NOT NULL,
+    indexed_block BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS vault_position (
+    vault_address VARCHAR(42) NOT NULL,
+    token_id NUMERIC(78, 0) NOT NULL,
+    tick_lower INT NOT NULL,
+    tick_upper INT NOT NULL,
+    block_number BIGINT NOT NULL,
+    PRIMARY KEY (vault_address, token_id)
+);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS auth_nonce (
+    nonce VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_auth_nonce_expires_at ON auth_nonce(expires_at);
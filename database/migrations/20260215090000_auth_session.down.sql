@@ -0,0 +1,7 @@
+-- Hashed tokens cannot be turned back into raw ones; all sessions are dropped.
+DELETE FROM auth_token;
+
+ALTER TABLE auth_token
+    DROP COLUMN IF EXISTS last_used_at,
+    DROP COLUMN IF EXISTS user_agent,
+    DROP COLUMN IF EXISTS device;
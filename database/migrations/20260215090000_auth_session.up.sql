@@ -0,0 +1,11 @@
+-- Tokens were stored raw in token_hash; hash them in place so existing sessions keep working.
+UPDATE auth_token SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex');
+
+ALTER TABLE auth_token
+    ADD COLUMN device VARCHAR(128) NOT NULL DEFAULT '',
+    ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '',
+    ADD COLUMN last_used_at TIMESTAMP;
+
+UPDATE auth_token SET last_used_at = updated_at;
+
+ALTER TABLE auth_token ALTER COLUMN last_used_at SET NOT NULL;
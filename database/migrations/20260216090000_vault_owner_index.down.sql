@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_vault_config_owner;
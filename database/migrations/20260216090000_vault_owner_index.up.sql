@@ -0,0 +1 @@
+CREATE INDEX IF NOT EXISTS idx_vault_config_owner ON vault_config(owner);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS token (
+    address VARCHAR(42) PRIMARY KEY,
+    symbol VARCHAR(64) NOT NULL,
+    decimals SMALLINT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
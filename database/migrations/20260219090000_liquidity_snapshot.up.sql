@@ -0,0 +1,14 @@
+-- Keyframes store every initialized tick in range; other rows store only the ticks that
+-- changed since the previous row, with removed ticks stored as zero liquidity.
+CREATE TABLE IF NOT EXISTS liquidity_snapshot (
+    pool_id VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_time TIMESTAMP NOT NULL,
+    sqrt_price_x96 NUMERIC(78, 0) NOT NULL,
+    tick INT NOT NULL,
+    liquidity NUMERIC(78, 0) NOT NULL,
+    keyframe BOOLEAN NOT NULL,
+    ticks BYTEA NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (pool_id, block_number)
+);
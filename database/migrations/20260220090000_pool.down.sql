@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS indexer_cursor;
+DROP TABLE IF EXISTS pool;
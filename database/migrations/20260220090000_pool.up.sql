@@ -0,0 +1,24 @@
+-- Pools discovered from PoolManager Initialize events. Addresses are stored checksummed.
+CREATE TABLE IF NOT EXISTS pool (
+    pool_id VARCHAR(66) PRIMARY KEY,
+    currency0 VARCHAR(42) NOT NULL,
+    currency1 VARCHAR(42) NOT NULL,
+    fee INT NOT NULL,
+    tick_spacing INT NOT NULL,
+    hooks VARCHAR(42) NOT NULL,
+    init_block BIGINT NOT NULL,
+    init_tx_hash VARCHAR(66) NOT NULL,
+    init_sqrt_price_x96 NUMERIC(78, 0) NOT NULL,
+    init_tick INT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS pool_currency0_idx ON pool (currency0, init_block DESC);
+CREATE INDEX IF NOT EXISTS pool_currency1_idx ON pool (currency1, init_block DESC);
+
+-- Last block processed by each chain-wide log indexer.
+CREATE TABLE IF NOT EXISTS indexer_cursor (
+    name VARCHAR(64) PRIMARY KEY,
+    block_number BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
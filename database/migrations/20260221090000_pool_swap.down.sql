@@ -0,0 +1,3 @@
+DELETE FROM indexer_cursor WHERE name LIKE 'pool_swap:%';
+ALTER TABLE indexer_cursor ALTER COLUMN name TYPE VARCHAR(64);
+DROP TABLE IF EXISTS pool_swap;
@@ -0,0 +1,23 @@
+-- Swaps of tracked pools from PoolManager Swap events. Amounts are the swapper's balance
+-- deltas: negative is paid into the pool. Fee is the total swap fee in hundredths of a bip.
+CREATE TABLE IF NOT EXISTS pool_swap (
+    pool_id VARCHAR(66) NOT NULL,
+    block_number BIGINT NOT NULL,
+    log_index INT NOT NULL,
+    tx_hash VARCHAR(66) NOT NULL,
+    block_time TIMESTAMP NOT NULL,
+    sender VARCHAR(42) NOT NULL,
+    amount0 NUMERIC(78, 0) NOT NULL,
+    amount1 NUMERIC(78, 0) NOT NULL,
+    sqrt_price_x96 NUMERIC(78, 0) NOT NULL,
+    liquidity NUMERIC(78, 0) NOT NULL,
+    tick INT NOT NULL,
+    fee INT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (block_number, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS pool_swap_pool_time_idx ON pool_swap (pool_id, block_time);
+
+-- Swap cursors are named per pool ("pool_swap:<pool id>").
+ALTER TABLE indexer_cursor ALTER COLUMN name TYPE VARCHAR(128);
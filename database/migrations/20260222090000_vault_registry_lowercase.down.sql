@@ -0,0 +1 @@
+ALTER TABLE vault_registry DROP CONSTRAINT IF EXISTS vault_registry_address_lowercase;
@@ -0,0 +1,10 @@
+-- Keep one row per vault before lowercasing, preferring a disabled one so quarantines survive.
+DELETE FROM vault_registry a USING vault_registry b
+WHERE LOWER(a.address) = LOWER(b.address)
+  AND a.address <> b.address
+  AND (a.enabled, a.address) > (b.enabled, b.address);
+
+UPDATE vault_registry SET address = LOWER(address);
+
+ALTER TABLE vault_registry
+    ADD CONSTRAINT vault_registry_address_lowercase CHECK (address ~ '^0x[0-9a-f]{40}$');
@@ -0,0 +1,20 @@
+-- name: CreateAPIKey :exec
+INSERT INTO api_key (id, name, prefix, key_hash, scopes, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: GetAPIKeyByPrefix :one
+SELECT id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+FROM api_key
+WHERE prefix = $1;
+
+-- name: ListAPIKeys :many
+SELECT id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+FROM api_key
+ORDER BY created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+UPDATE api_key SET revoked_at = $2
+WHERE id = $1 AND revoked_at IS NULL;
+
+-- name: TouchAPIKey :exec
+UPDATE api_key SET last_used_at = $2 WHERE id = $1;
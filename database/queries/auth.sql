@@ -1,14 +1,42 @@
--- name: DeleteAuthTokensByUserID :exec
-DELETE FROM auth_token WHERE user_id = $1;
-
 -- name: CreateAuthToken :exec
-INSERT INTO auth_token (id, user_id, token_hash, expires_at, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6);
+INSERT INTO auth_token (id, user_id, token_hash, device, user_agent, expires_at, last_used_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
 
 -- name: GetAuthTokenByHash :one
-SELECT id, user_id, token_hash, expires_at, created_at, updated_at
+SELECT id, user_id, token_hash, expires_at, created_at, updated_at, device, user_agent, last_used_at
 FROM auth_token
 WHERE token_hash = $1 AND expires_at > $2;
 
+-- name: ListAuthTokensByUserID :many
+SELECT id, user_id, token_hash, expires_at, created_at, updated_at, device, user_agent, last_used_at
+FROM auth_token
+WHERE user_id = $1 AND expires_at > $2
+ORDER BY last_used_at DESC;
+
+-- name: TouchAuthToken :exec
+UPDATE auth_token
+SET expires_at = $2, last_used_at = $3, updated_at = $3
+WHERE id = $1;
+
+-- name: RotateAuthToken :execrows
+UPDATE auth_token
+SET token_hash = @new_token_hash, expires_at = @expires_at, last_used_at = @updated_at, updated_at = @updated_at
+WHERE id = @id AND token_hash = @token_hash;
+
+-- name: DeleteAuthTokenByID :execrows
+DELETE FROM auth_token WHERE id = $1 AND user_id = $2;
+
 -- name: DeleteAuthTokenByHash :exec
 DELETE FROM auth_token WHERE token_hash = $1;
+
+-- name: CreateAuthNonce :exec
+INSERT INTO auth_nonce (nonce, expires_at, created_at)
+VALUES ($1, $2, $3);
+
+-- name: ConsumeAuthNonce :one
+DELETE FROM auth_nonce
+WHERE nonce = $1 AND expires_at > $2
+RETURNING nonce;
+
+-- name: DeleteExpiredAuthNonces :exec
+DELETE FROM auth_nonce WHERE expires_at <= $1;
@@ -0,0 +1,22 @@
+-- name: InsertLiquiditySnapshot :exec
+INSERT INTO liquidity_snapshot (pool_id, block_number, block_time, sqrt_price_x96, tick, liquidity, keyframe, ticks, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (pool_id, block_number) DO NOTHING;
+
+-- name: GetLatestLiquiditySnapshotBlock :one
+SELECT COALESCE(MAX(block_number), 0)::BIGINT
+FROM liquidity_snapshot
+WHERE pool_id = $1;
+
+-- name: ListLiquiditySnapshotsForReplay :many
+-- The latest keyframe at or before the block, then every row after it up to the block.
+SELECT s.pool_id, s.block_number, s.block_time, s.sqrt_price_x96, s.tick, s.liquidity, s.keyframe, s.ticks, s.created_at
+FROM liquidity_snapshot s
+WHERE s.pool_id = @pool_id
+  AND s.block_number <= @block_number
+  AND s.block_number >= (
+    SELECT COALESCE(MAX(k.block_number), 0)
+    FROM liquidity_snapshot k
+    WHERE k.pool_id = @pool_id AND k.block_number <= @block_number AND k.keyframe
+  )
+ORDER BY s.block_number;
@@ -0,0 +1,29 @@
+-- name: InsertPool :exec
+INSERT INTO pool (pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (pool_id) DO NOTHING;
+
+-- name: GetPool :one
+SELECT pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at
+FROM pool
+WHERE pool_id = $1;
+
+-- name: ListPools :many
+-- Newest first; an empty token lists every pool.
+SELECT pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at
+FROM pool
+WHERE @token::VARCHAR = ''
+   OR currency0 = @token::VARCHAR
+   OR currency1 = @token::VARCHAR
+ORDER BY init_block DESC, pool_id
+LIMIT @row_limit;
+
+-- name: GetIndexerCursor :one
+SELECT block_number
+FROM indexer_cursor
+WHERE name = $1;
+
+-- name: UpsertIndexerCursor :exec
+INSERT INTO indexer_cursor (name, block_number, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (name) DO UPDATE SET block_number = EXCLUDED.block_number, updated_at = EXCLUDED.updated_at;
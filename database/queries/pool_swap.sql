@@ -0,0 +1,46 @@
+-- name: InsertPoolSwap :exec
+INSERT INTO pool_swap (pool_id, block_number, log_index, tx_hash, block_time, sender, amount0, amount1, sqrt_price_x96, liquidity, tick, fee, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (block_number, log_index) DO NOTHING;
+
+-- name: GetLatestPoolSwap :one
+SELECT pool_id, block_number, log_index, tx_hash, block_time, sender, amount0, amount1, sqrt_price_x96, liquidity, tick, fee, created_at
+FROM pool_swap
+WHERE pool_id = @pool_id AND block_time < @until
+ORDER BY block_number DESC, log_index DESC
+LIMIT 1;
+
+-- name: ListPoolSwapVolumeByTick :many
+-- Swaps are binned by the tick they ended at. Fees are charged on the amount paid in.
+SELECT (FLOOR(tick::NUMERIC / @bin_size::INT) * @bin_size::INT)::INT AS tick_lower,
+       COUNT(*) AS swaps,
+       SUM(ABS(amount0))::NUMERIC AS volume0,
+       SUM(ABS(amount1))::NUMERIC AS volume1,
+       SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees0,
+       SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees1
+FROM pool_swap
+WHERE pool_id = @pool_id AND block_time >= @since AND block_time < @until
+GROUP BY 1
+ORDER BY 1;
+
+-- name: ListPoolSwapVolumeByTime :many
+SELECT date_bin(make_interval(secs => @bucket_seconds::INT), block_time, TIMESTAMP '1970-01-01')::TIMESTAMP AS bucket_start,
+       COUNT(*) AS swaps,
+       SUM(ABS(amount0))::NUMERIC AS volume0,
+       SUM(ABS(amount1))::NUMERIC AS volume1,
+       SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees0,
+       SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees1
+FROM pool_swap
+WHERE pool_id = @pool_id AND block_time >= @since AND block_time < @until
+GROUP BY 1
+ORDER BY 1;
+
+-- name: GetPoolSwapRangeFees :one
+-- Fees per unit of active liquidity for swaps that ended in [tick_lower, tick_upper): what one
+-- unit of liquidity over that range would have earned.
+SELECT COUNT(*) AS swaps,
+       COALESCE(SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 / liquidity ELSE 0 END), 0)::NUMERIC AS fees0_per_liquidity,
+       COALESCE(SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 / liquidity ELSE 0 END), 0)::NUMERIC AS fees1_per_liquidity
+FROM pool_swap
+WHERE pool_id = @pool_id AND block_time >= @since AND block_time < @until
+  AND tick >= @tick_lower AND tick < @tick_upper AND liquidity > 0;
@@ -0,0 +1,14 @@
+-- name: GetToken :one
+SELECT address, symbol, decimals, created_at
+FROM token
+WHERE address = $1;
+
+-- name: UpsertToken :exec
+INSERT INTO token (address, symbol, decimals, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (address) DO UPDATE SET symbol = EXCLUDED.symbol, decimals = EXCLUDED.decimals;
+
+-- name: ListTokens :many
+SELECT address, symbol, decimals, created_at
+FROM token
+WHERE address = ANY(@addresses::VARCHAR[]);
@@ -10,3 +10,9 @@ VALUES ($1, $2, $3, $4);
 
 -- name: ListUsers :many
 SELECT "id" FROM "user";
+
+-- name: UpsertUserByAddress :one
+INSERT INTO "user" ("id", "address", "created_at", "updated_at")
+VALUES ($1, $2, $3, $4)
+ON CONFLICT ("address") DO UPDATE SET "updated_at" = EXCLUDED."updated_at"
+RETURNING *;
@@ -0,0 +1,15 @@
+-- name: GetVaultDiscoveryCursor :one
+SELECT last_block FROM vault_discovery_cursor WHERE factory_address = $1;
+
+-- name: UpsertVaultDiscoveryCursor :exec
+INSERT INTO vault_discovery_cursor (factory_address, last_block, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (factory_address) DO UPDATE SET last_block = EXCLUDED.last_block, updated_at = EXCLUDED.updated_at;
+
+-- name: InsertFactoryVault :exec
+INSERT INTO factory_vault (factory_address, vault_address, block_number, tx_hash, log_index, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (factory_address, vault_address) DO NOTHING;
+
+-- name: ListFactoryVaults :many
+SELECT vault_address FROM factory_vault WHERE factory_address = $1 ORDER BY block_number, log_index;
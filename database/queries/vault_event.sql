@@ -0,0 +1,65 @@
+-- name: InsertVaultEvent :exec
+INSERT INTO vault_event (vault_address, block_number, log_index, block_hash, tx_hash, event_type, data, block_time, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+ON CONFLICT (vault_address, block_number, log_index) DO NOTHING;
+
+-- name: GetVaultConfig :one
+SELECT vault_address, owner, agent, agent_paused, swap_allowed, allowed_tick_lower, allowed_tick_upper, max_positions_k, indexed_block, updated_at
+FROM vault_config
+WHERE vault_address = $1;
+
+-- name: ListVaultConfigsByOwner :many
+SELECT vault_address, owner, agent, agent_paused, swap_allowed, allowed_tick_lower, allowed_tick_upper, max_positions_k, indexed_block, updated_at
+FROM vault_config
+WHERE owner = $1
+ORDER BY vault_address;
+
+-- name: ListVaultIndexedBlocks :many
+SELECT vault_address, indexed_block FROM vault_config;
+
+-- name: UpsertVaultConfig :exec
+INSERT INTO vault_config (vault_address, owner, agent, agent_paused, swap_allowed, allowed_tick_lower, allowed_tick_upper, max_positions_k, indexed_block, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (vault_address) DO UPDATE SET
+    owner = EXCLUDED.owner,
+    agent = EXCLUDED.agent,
+    agent_paused = EXCLUDED.agent_paused,
+    swap_allowed = EXCLUDED.swap_allowed,
+    allowed_tick_lower = EXCLUDED.allowed_tick_lower,
+    allowed_tick_upper = EXCLUDED.allowed_tick_upper,
+    max_positions_k = EXCLUDED.max_positions_k,
+    indexed_block = EXCLUDED.indexed_block,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: UpsertVaultPosition :exec
+INSERT INTO vault_position (vault_address, token_id, tick_lower, tick_upper, block_number)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (vault_address, token_id) DO UPDATE SET
+    tick_lower = EXCLUDED.tick_lower,
+    tick_upper = EXCLUDED.tick_upper,
+    block_number = EXCLUDED.block_number;
+
+-- name: DeleteVaultPosition :exec
+DELETE FROM vault_position WHERE vault_address = $1 AND token_id = $2;
+
+-- name: ListVaultPositions :many
+SELECT vault_address, token_id, tick_lower, tick_upper, block_number
+FROM vault_position
+WHERE vault_address = $1
+ORDER BY block_number, token_id;
+
+-- name: AdvanceVaultIndexedBlock :exec
+UPDATE vault_config
+SET indexed_block = @indexed_block, updated_at = @updated_at
+WHERE vault_address = ANY(@vault_addresses::VARCHAR[]) AND indexed_block < @indexed_block;
+
+-- name: ListVaultEvents :many
+SELECT vault_address, block_number, log_index, block_hash, tx_hash, event_type, data, block_time, created_at
+FROM vault_event
+WHERE vault_address = @vault_address
+  AND (cardinality(@event_types::VARCHAR[]) = 0 OR event_type = ANY(@event_types::VARCHAR[]))
+  AND block_number >= @from_block
+  AND block_number <= @to_block
+  AND (block_number, log_index) < (@before_block::BIGINT, @before_log_index::INT)
+ORDER BY block_number DESC, log_index DESC
+LIMIT @row_limit;
@@ -0,0 +1,10 @@
+-- name: ListVaultRegistryAddresses :many
+SELECT address FROM vault_registry WHERE enabled = $1 ORDER BY created_at, address;
+
+-- name: UpsertVaultRegistry :exec
+INSERT INTO vault_registry (address, enabled, label, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (address) DO UPDATE SET label = EXCLUDED.label, updated_at = EXCLUDED.updated_at;
+
+-- name: SetVaultRegistryEnabled :execrows
+UPDATE vault_registry SET enabled = $2, updated_at = $3 WHERE address = $1;
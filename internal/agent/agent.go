@@ -8,7 +8,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"go.opentelemetry.io/otel/metric"
 
+	"remora/internal/alert"
 	"remora/internal/allocation"
 	"remora/internal/coverage"
 	"remora/internal/liquidity"
@@ -41,15 +43,21 @@ type RebalanceResult struct {
 type Service struct {
 	vaultSource VaultSource
 	strategySvc strategy.Service
-	signer      *signer.Signer
+	signers     *signer.Registry
+	nonces      *signer.NonceManager
 	ethClient   *ethclient.Client
+	gasBackend  GasBackend
 	logger      *slog.Logger
 
+	alerter      alert.Alerter
+	balanceGauge metric.Float64Gauge
+
 	deviationThreshold float64
 	swapSlippageBps    int64
 	mintSlippageBps    int64
 	maxGasPriceGwei    float64
 	tickRangeOverride  int32
+	targetSmoothing    strategy.Smoothing
 	slot0Fetcher       Slot0Fetcher
 }
 
@@ -57,7 +65,7 @@ type Service struct {
 func New(
 	vaultSource VaultSource,
 	strategySvc strategy.Service,
-	signer *signer.Signer,
+	signers *signer.Registry,
 	ethClient *ethclient.Client,
 	logger *slog.Logger,
 	slot0Fetcher Slot0Fetcher,
@@ -65,9 +73,13 @@ func New(
 	return &Service{
 		vaultSource:        vaultSource,
 		strategySvc:        strategySvc,
-		signer:             signer,
+		signers:            signers,
+		nonces:             signer.NewNonceManager(ethClient),
 		ethClient:          ethClient,
+		gasBackend:         ethClient,
 		logger:             logger,
+		alerter:            alert.NewLogAlerter(logger),
+		balanceGauge:       newBalanceGauge(),
 		slot0Fetcher:       slot0Fetcher,
 		deviationThreshold: 0.1,
 		swapSlippageBps:    50,  // default: 0.5%
@@ -83,6 +95,11 @@ func (s *Service) SetProtectionSettings(swapSlippageBps int64, mintSlippageBps i
 	s.maxGasPriceGwei = maxGasPriceGwei
 }
 
+// SetAlerter replaces the alerter used for low-balance alerts (default: log).
+func (s *Service) SetAlerter(alerter alert.Alerter) {
+	s.alerter = alerter
+}
+
 // SetDeviationThreshold updates the threshold for rebalance decision.
 func (s *Service) SetDeviationThreshold(threshold float64) {
 	s.deviationThreshold = threshold
@@ -94,6 +111,11 @@ func (s *Service) SetTickRangeAroundCurrent(tickRange int32) {
 	s.tickRangeOverride = tickRange
 }
 
+// SetTargetSmoothing builds targets from a weighted average of recent distributions.
+func (s *Service) SetTargetSmoothing(smoothing strategy.Smoothing) {
+	s.targetSmoothing = smoothing
+}
+
 // Run executes one round of rebalance check for all vaults.
 func (s *Service) Run(ctx context.Context) ([]RebalanceResult, error) {
 	addresses, err := s.vaultSource.GetVaultAddresses(ctx)
@@ -103,6 +125,12 @@ func (s *Service) Run(ctx context.Context) ([]RebalanceResult, error) {
 
 	s.logger.InfoContext(ctx, "starting rebalance run", slog.Int("vault_count", len(addresses)))
 
+	for _, agent := range s.signers.Addresses() {
+		if _, err := s.recordBalance(ctx, agent); err != nil {
+			s.logger.WarnContext(ctx, "failed to record agent balance", slog.String("agent", agent.Hex()), slog.Any("error", err))
+		}
+	}
+
 	var results []RebalanceResult
 
 	for _, addr := range addresses {
@@ -117,24 +145,20 @@ func (s *Service) Run(ctx context.Context) ([]RebalanceResult, error) {
 func (s *Service) processVault(ctx context.Context, vaultAddr common.Address) RebalanceResult {
 	s.logger.Info("processing vault", slog.String("address", vaultAddr.Hex()))
 
-	// Step 1: Create vault client
-	auth, err := s.signer.TransactOpts()
-	if err != nil {
-		return RebalanceResult{VaultAddress: vaultAddr, Reason: "signer_error"}
-	}
-
-	vaultClient, err := vault.NewClient(vaultAddr, s.ethClient, auth)
+	// Step 1: Get vault state and current positions (read at the same block)
+	reader, err := vault.NewClient(vaultAddr, s.ethClient, nil)
 	if err != nil {
 		return RebalanceResult{VaultAddress: vaultAddr, Reason: "vault_client_error"}
 	}
 
-	// Step 2: Get vault state and current positions
-	state, err := vaultClient.GetState(ctx)
+	snapshot, err := reader.GetSnapshot(ctx, nil)
 	if err != nil {
 		s.logger.Error("failed to get vault state", slog.Any("error", err))
 		return RebalanceResult{VaultAddress: vaultAddr, Reason: "get_state_error"}
 	}
 
+	state := &snapshot.State
+
 	// Check if agent is paused for this vault
 	if state.AgentPaused {
 		s.logger.Info("vault agent is paused, skipping", slog.String("address", vaultAddr.Hex()))
@@ -146,6 +170,21 @@ func (s *Service) processVault(ctx context.Context, vaultAddr common.Address) Re
 		}
 	}
 
+	// Step 2: Create a vault client signing as the vault's agent, with nonces tracked per key
+	sgn, err := s.signers.Get(state.Agent)
+	if err != nil {
+		s.logger.Warn("no signer for vault agent, skipping",
+			slog.String("address", vaultAddr.Hex()),
+			slog.String("agent", state.Agent.Hex()))
+
+		return RebalanceResult{VaultAddress: vaultAddr, Reason: "signer_not_found"}
+	}
+
+	vaultClient, err := vault.NewClient(vaultAddr, s.nonces.Backend(s.ethClient), signer.TransactOpts(ctx, sgn))
+	if err != nil {
+		return RebalanceResult{VaultAddress: vaultAddr, Reason: "vault_client_error"}
+	}
+
 	// Step 3: Compute target positions using strategy service
 	// Convert vault.PoolKey to poolid.PoolKey
 	liqPoolKey := poolid.PoolKey{
@@ -191,6 +230,7 @@ func (s *Service) processVault(ctx context.Context, vaultAddr common.Address) Re
 		AlgoConfig:       algoConfig,
 		AllowedTickLower: state.AllowedTickLower,
 		AllowedTickUpper: state.AllowedTickUpper,
+		Smoothing:        s.targetSmoothing,
 	}
 
 	s.logger.Info("computing target positions",
@@ -246,11 +286,7 @@ func (s *Service) processVault(ctx context.Context, vaultAddr common.Address) Re
 	}
 
 	// Get Invested Balances (from current positions)
-	positions, err := vaultClient.GetPositions(ctx)
-	if err != nil {
-		s.logger.Error("failed to get positions", slog.Any("error", err))
-		return RebalanceResult{VaultAddress: vaultAddr, Reason: "get_positions_error"}
-	}
+	positions := snapshot.Positions
 
 	s.logger.Info("fetched positions from vault", slog.Any("token_ids", func() []string {
 		ids := make([]string, len(positions))
@@ -366,10 +402,24 @@ func (s *Service) processVault(ctx context.Context, vaultAddr common.Address) Re
 		)
 	}
 
+	// Step 5.5: Make sure the agent can pay for every planned step before touching positions
+	plan := GasPlan{Burns: len(positions), Mints: len(allocationResult.Positions)}
+	if allocationResult.SwapAmount != nil && allocationResult.SwapAmount.Sign() > 0 {
+		plan.Swaps = 1
+	}
+
+	if err := s.checkGasBalance(ctx, vaultAddr, sgn.Address(), plan); err != nil {
+		s.logger.Error("gas balance check failed", slog.Any("error", err))
+		return RebalanceResult{VaultAddress: vaultAddr, Reason: "insufficient_gas_balance"}
+	}
+
 	// Step 6: Execute rebalance
 	err = s.executeRebalance(ctx, vaultClient, positions, allocationResult, targetResult.SqrtPriceX96, token0, token1, &liqPoolKey)
 	if err != nil {
+		// A failed sequence may leave reserved nonces unused; resync this key from the chain.
+		s.nonces.Reset(sgn.Address())
 		s.logger.Error("failed to execute rebalance", slog.Any("error", err))
+
 		return RebalanceResult{VaultAddress: vaultAddr, Reason: "execution_error"}
 	}
 
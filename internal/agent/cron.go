@@ -5,18 +5,27 @@ import (
 	"log/slog"
 	"os"
 	"strconv"
+	"time"
 
-	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/joho/godotenv"
 	"github.com/robfig/cron/v3"
 
+	"remora/internal/alert"
+	"remora/internal/db"
 	liquidityrepo "remora/internal/liquidity/repository"
 	liquidityservice "remora/internal/liquidity/service"
+	"remora/internal/rpc"
 	"remora/internal/signer"
+	"remora/internal/strategy"
 	strategyservice "remora/internal/strategy/service"
+	"remora/internal/token"
+	tokenrepo "remora/internal/token/repository"
+	tokenservice "remora/internal/token/service"
 )
 
+// defaultAlertInterval is how often a persisting condition is re-alerted.
+const defaultAlertInterval = time.Hour
+
 // StartCron starts the rebalance cron from environment variables.
 // If useDefaultSchedule is false and REBALANCE_SCHEDULE is not set, returns (nil, nil) and no cron is run.
 // If useDefaultSchedule is true (e.g. when running the rebalance binary), default schedule "*/5 * * * *" is used when unset.
@@ -33,59 +42,79 @@ func StartCron(ctx context.Context, logger *slog.Logger, useDefaultSchedule bool
 		schedule = "*/5 * * * *"
 	}
 
-	sgn, err := signer.NewFromEnv()
+	signers, err := signer.NewRegistryFromEnv(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	logger.Info("signer initialized for rebalance", slog.String("address", sgn.Address().Hex()))
+	for _, addr := range signers.Addresses() {
+		logger.Info("signer initialized for rebalance", slog.String("address", addr.Hex()))
+	}
 
 	rpcURL := os.Getenv("RPC_URL")
 	if rpcURL == "" {
 		return nil, errEnv("RPC_URL")
 	}
 
-	ethClient, err := ethclient.Dial(rpcURL)
+	ethClient, err := rpc.Dial(ctx, rpcConfigFromEnv(rpcURL), logger)
 	if err != nil {
 		return nil, err
 	}
 
-	factoryAddr := os.Getenv("FACTORY_ADDRESS")
-	if factoryAddr == "" {
+	pool, err := newPgxPoolFromEnv(ctx)
+	if err != nil {
 		ethClient.Close()
-		return nil, errEnv("FACTORY_ADDRESS")
+		return nil, err
 	}
 
-	vaultSource := NewFactoryVaultSource(ethClient, common.HexToAddress(factoryAddr))
+	closeAll := func() {
+		ethClient.Close()
+
+		if pool != nil {
+			pool.Close()
+		}
+	}
+
+	vaultSource, err := newVaultSourceFromEnv(ethClient, pool, logger)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
 
 	stateViewAddr := os.Getenv("STATEVIEW_CONTRACT_ADDR")
 	if stateViewAddr == "" {
-		ethClient.Close()
+		closeAll()
 		return nil, errEnv("STATEVIEW_CONTRACT_ADDR")
 	}
 
-	liqRepo, err := liquidityrepo.New(liquidityrepo.Config{
-		RPCURL:          rpcURL,
+	liqRepo, err := liquidityrepo.New(ethClient, liquidityrepo.Config{
 		ContractAddress: stateViewAddr,
 	})
 	if err != nil {
-		ethClient.Close()
+		closeAll()
 		return nil, err
 	}
 
-	liqSvc := liquidityservice.New(liqRepo)
-	strategySvc := strategyservice.New(liqSvc)
+	var tokenStore token.Repository
+	if pool != nil {
+		tokenStore = tokenrepo.New(db.New(pool))
+	}
+
+	tokenSvc := tokenservice.New(tokenStore, tokenrepo.NewERC20Reader(ethClient), logger)
+	liqSvc := liquidityservice.New(liqRepo, tokenSvc, nil)
+	strategySvc := strategyservice.New(liqSvc, liqRepo)
 
 	agentSvc := New(
 		vaultSource,
 		strategySvc,
-		sgn,
+		signers,
 		ethClient,
 		logger,
-		liqRepo,
+		liqSvc,
 	)
 
 	applyProtectionFromEnv(agentSvc, logger)
+	applyAlertingFromEnv(agentSvc, logger)
 
 	ctxCron, cancel := context.WithCancel(ctx)
 	c := cron.New()
@@ -95,8 +124,7 @@ func StartCron(ctx context.Context, logger *slog.Logger, useDefaultSchedule bool
 	})
 	if err != nil {
 		cancel()
-		ethClient.Close()
-		liqRepo.Close()
+		closeAll()
 
 		return nil, err
 	}
@@ -109,8 +137,7 @@ func StartCron(ctx context.Context, logger *slog.Logger, useDefaultSchedule bool
 	stop = func() {
 		c.Stop()
 		cancel()
-		ethClient.Close()
-		liqRepo.Close()
+		closeAll()
 	}
 
 	return stop, nil
@@ -136,6 +163,22 @@ func runOnce(ctx context.Context, agentSvc *Service, logger *slog.Logger) {
 	logger.InfoContext(ctx, "rebalance check completed", slog.Int("vaults", len(results)))
 }
 
+// rpcConfigFromEnv reads the RPC endpoints. RPC_URL takes writes unless RPC_WRITE_URL is
+// set; RPC_FALLBACK_URLS adds read endpoints.
+func rpcConfigFromEnv(rpcURL string) rpc.Config {
+	cfg := rpc.Config{
+		URLs:       append([]string{rpcURL}, splitList(os.Getenv("RPC_FALLBACK_URLS"))...),
+		WriteURL:   os.Getenv("RPC_WRITE_URL"),
+		MaxRetries: int(parseInt64(os.Getenv("RPC_MAX_RETRIES"), 0)),
+	}
+
+	if lag := parseInt64(os.Getenv("RPC_MAX_BLOCK_LAG"), 0); lag > 0 {
+		cfg.MaxBlockLag = uint64(lag)
+	}
+
+	return cfg
+}
+
 func applyProtectionFromEnv(svc *Service, logger *slog.Logger) {
 	swapSlippage := parseInt64(os.Getenv("SWAP_SLIPPAGE_BPS"), 50)
 	mintSlippage := parseInt64(os.Getenv("MINT_SLIPPAGE_BPS"), 50)
@@ -153,6 +196,45 @@ func applyProtectionFromEnv(svc *Service, logger *slog.Logger) {
 			logger.Warn("invalid TICK_RANGE_AROUND_CURRENT", slog.String("raw", tickRange))
 		}
 	}
+
+	smoothing := strategy.Smoothing{
+		Samples:   int(parseInt64(os.Getenv("TARGET_SMOOTHING_SAMPLES"), 0)),
+		BlockStep: uint64(parseInt64(os.Getenv("TARGET_SMOOTHING_BLOCK_STEP"), 0)), //nolint:gosec // a negative step leaves only the latest sample
+		Alpha:     parseFloat64(os.Getenv("TARGET_SMOOTHING_ALPHA"), 0.5),
+	}
+	if !smoothing.Enabled() {
+		return
+	}
+
+	if err := smoothing.Validate(); err != nil {
+		logger.Warn("invalid target smoothing, using the latest distribution only", slog.Any("error", err))
+
+		return
+	}
+
+	svc.SetTargetSmoothing(smoothing)
+	logger.Info("target smoothing set",
+		slog.Int("samples", smoothing.Samples),
+		slog.Uint64("block_step", smoothing.BlockStep),
+		slog.Float64("alpha", smoothing.Alpha))
+}
+
+func applyAlertingFromEnv(svc *Service, logger *slog.Logger) {
+	var alerter alert.Alerter = alert.NewLogAlerter(logger)
+	if url := os.Getenv("ALERT_WEBHOOK_URL"); url != "" {
+		alerter = alert.NewWebhookAlerter(url)
+	}
+
+	interval := defaultAlertInterval
+	if raw := os.Getenv("ALERT_REPEAT_INTERVAL"); raw != "" {
+		if d, err := time.ParseDuration(raw); err == nil && d > 0 {
+			interval = d
+		} else {
+			logger.Warn("invalid ALERT_REPEAT_INTERVAL", slog.String("raw", raw))
+		}
+	}
+
+	svc.SetAlerter(alert.NewThrottled(alerter, interval))
 }
 
 func parseInt64(s string, defaultVal int64) int64 {
@@ -36,6 +36,14 @@ func (s *Service) sendAndWait(ctx context.Context, txName string, tx *types.Tran
 	return nil
 }
 
+// maxGasPriceWei returns the MAX_GAS_PRICE_GWEI ceiling in wei. 1 Gwei = 10^9 wei.
+func (s *Service) maxGasPriceWei() *big.Int {
+	maxGasPriceWei := new(big.Float).Mul(big.NewFloat(s.maxGasPriceGwei), big.NewFloat(1e9))
+	maxGasPriceWeiInt, _ := maxGasPriceWei.Int(nil)
+
+	return maxGasPriceWeiInt
+}
+
 // executeRebalance orchestrates the execution of a rebalance plan.
 // Flow: Burn old positions -> Swap tokens -> Mint new positions.
 func (s *Service) executeRebalance(
@@ -54,10 +62,7 @@ func (s *Service) executeRebalance(
 		return fmt.Errorf("suggest gas price: %w", err)
 	}
 
-	// Convert maxGasPriceGwei to wei
-	// 1 Gwei = 10^9 wei
-	maxGasPriceWei := new(big.Float).Mul(big.NewFloat(s.maxGasPriceGwei), big.NewFloat(1e9))
-	maxGasPriceWeiInt, _ := maxGasPriceWei.Int(nil)
+	maxGasPriceWeiInt := s.maxGasPriceWei()
 
 	if gasPrice.Cmp(maxGasPriceWeiInt) > 0 {
 		s.logger.Warn("gas price too high, skipping rebalance",
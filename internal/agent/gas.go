@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"remora/internal/alert"
+)
+
+// Worst-case gas per rebalance step, with headroom over observed usage.
+const (
+	gasPerBurn = 350_000
+	gasPerSwap = 300_000
+	gasPerMint = 600_000
+
+	// feeCapMultiplier covers the EIP-1559 fee cap (about twice the base fee), which nodes
+	// require the sender to afford in full.
+	feeCapMultiplier = 2
+)
+
+// ErrInsufficientGasBalance is returned when the agent cannot pay for a rebalance in full.
+var ErrInsufficientGasBalance = errors.New("insufficient agent balance for gas")
+
+// GasBackend reads the agent's balance and the current gas price.
+type GasBackend interface {
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// GasPlan counts the transactions of a rebalance.
+type GasPlan struct {
+	Burns int
+	Swaps int
+	Mints int
+}
+
+// GasLimit returns the worst-case gas of the whole plan.
+func (p GasPlan) GasLimit() uint64 {
+	//nolint:gosec // counts are small and non-negative
+	return uint64(p.Burns)*gasPerBurn + uint64(p.Swaps)*gasPerSwap + uint64(p.Mints)*gasPerMint
+}
+
+// RequiredBalance returns the balance needed to pay for the plan at gasPrice.
+func (p GasPlan) RequiredBalance(gasPrice *big.Int) *big.Int {
+	required := new(big.Int).SetUint64(p.GasLimit())
+	required.Mul(required, gasPrice)
+
+	return required.Mul(required, big.NewInt(feeCapMultiplier))
+}
+
+// newBalanceGauge registers the agent balance gauge on the global meter provider.
+func newBalanceGauge() metric.Float64Gauge {
+	gauge, err := otel.Meter("remora/agent").Float64Gauge(
+		"remora.agent.native_balance",
+		metric.WithUnit("ETH"),
+		metric.WithDescription("Native balance of each agent signer"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return gauge
+}
+
+// recordBalance reads and records the native balance of agent.
+func (s *Service) recordBalance(ctx context.Context, agent common.Address) (*big.Int, error) {
+	balance, err := s.gasBackend.BalanceAt(ctx, agent, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get agent balance: %w", err)
+	}
+
+	if s.balanceGauge != nil {
+		eth, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(params.Ether)).Float64()
+		s.balanceGauge.Record(ctx, eth, metric.WithAttributes(attribute.String("agent", agent.Hex())))
+	}
+
+	return balance, nil
+}
+
+// checkGasBalance refuses a plan agent cannot pay for in full, so a run never burns positions
+// and then fails to mint for lack of gas. A shortfall fires a low-balance alert.
+func (s *Service) checkGasBalance(ctx context.Context, vaultAddr, agent common.Address, plan GasPlan) error {
+	balance, err := s.recordBalance(ctx, agent)
+	if err != nil {
+		return err
+	}
+
+	gasPrice, err := s.gasBackend.SuggestGasPrice(ctx)
+	if err != nil {
+		return fmt.Errorf("suggest gas price: %w", err)
+	}
+
+	// executeRebalance skips a rebalance above the MAX_GAS_PRICE_GWEI ceiling, so a price spike
+	// must not raise a low balance alert the rebalance would never pay for.
+	if ceiling := s.maxGasPriceWei(); gasPrice.Cmp(ceiling) > 0 {
+		gasPrice = ceiling
+	}
+
+	required := plan.RequiredBalance(gasPrice)
+	if balance.Cmp(required) >= 0 {
+		return nil
+	}
+
+	s.logger.WarnContext(ctx, "agent balance too low for rebalance",
+		slog.String("vault", vaultAddr.Hex()),
+		slog.String("agent", agent.Hex()),
+		slog.String("balance", balance.String()),
+		slog.String("required", required.String()))
+
+	if s.alerter != nil {
+		err := s.alerter.Alert(ctx, alert.Alert{
+			Key:   "low_balance:" + agent.Hex(),
+			Title: "Agent balance low",
+			Message: fmt.Sprintf("agent %s has %s wei, needs %s wei to rebalance vault %s",
+				agent.Hex(), balance, required, vaultAddr.Hex()),
+			Labels: map[string]string{
+				"agent":    agent.Hex(),
+				"vault":    vaultAddr.Hex(),
+				"balance":  balance.String(),
+				"required": required.String(),
+			},
+		})
+		if err != nil {
+			s.logger.ErrorContext(ctx, "send low balance alert failed", slog.Any("error", err))
+		}
+	}
+
+	return fmt.Errorf("%w: have %s, need %s", ErrInsufficientGasBalance, balance, required)
+}
@@ -0,0 +1,103 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/alert"
+)
+
+type fakeGasBackend struct {
+	balance  *big.Int
+	gasPrice *big.Int
+}
+
+func (f *fakeGasBackend) BalanceAt(context.Context, common.Address, *big.Int) (*big.Int, error) {
+	return f.balance, nil
+}
+
+func (f *fakeGasBackend) SuggestGasPrice(context.Context) (*big.Int, error) {
+	return f.gasPrice, nil
+}
+
+type recordingAlerter struct{ alerts []alert.Alert }
+
+func (r *recordingAlerter) Alert(_ context.Context, a alert.Alert) error {
+	r.alerts = append(r.alerts, a)
+
+	return nil
+}
+
+func TestGasPlan_RequiredBalance(t *testing.T) {
+	t.Parallel()
+
+	plan := GasPlan{Burns: 2, Swaps: 1, Mints: 3}
+
+	want := uint64(2*gasPerBurn + gasPerSwap + 3*gasPerMint)
+	if plan.GasLimit() != want {
+		t.Fatalf("GasLimit() = %d, want %d", plan.GasLimit(), want)
+	}
+
+	got := plan.RequiredBalance(big.NewInt(10))
+	if got.Uint64() != want*10*feeCapMultiplier {
+		t.Errorf("RequiredBalance() = %s, want %d", got, want*10*feeCapMultiplier)
+	}
+}
+
+func TestService_CheckGasBalance(t *testing.T) {
+	t.Parallel()
+
+	plan := GasPlan{Burns: 1, Mints: 1}
+	required := plan.RequiredBalance(big.NewInt(1e9))
+
+	tests := []struct {
+		name      string
+		balance   *big.Int
+		gasPrice  int64
+		wantErr   error
+		wantAlert bool
+	}{
+		{name: "enough balance", balance: required},
+		{name: "price above the ceiling", balance: required, gasPrice: 5e9},
+		{
+			name:      "one wei short",
+			balance:   new(big.Int).Sub(required, big.NewInt(1)),
+			wantErr:   ErrInsufficientGasBalance,
+			wantAlert: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gasPrice := big.NewInt(1e9)
+			if tt.gasPrice != 0 {
+				gasPrice = big.NewInt(tt.gasPrice)
+			}
+
+			alerter := &recordingAlerter{}
+			svc := &Service{
+				gasBackend:      &fakeGasBackend{balance: tt.balance, gasPrice: gasPrice},
+				logger:          slog.New(slog.NewTextHandler(io.Discard, nil)),
+				alerter:         alerter,
+				maxGasPriceGwei: 1,
+			}
+
+			err := svc.checkGasBalance(t.Context(), common.Address{1}, common.Address{2}, plan)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("checkGasBalance() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := len(alerter.alerts) == 1; got != tt.wantAlert {
+				t.Errorf("alerts = %v, want alert %v", alerter.alerts, tt.wantAlert)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"remora/internal/db"
+	"remora/internal/vault"
+)
+
+// Vault source kinds accepted in VAULT_SOURCES.
+const (
+	vaultSourceFactory  = "factory"
+	vaultSourceStatic   = "static"
+	vaultSourcePostgres = "postgres"
+	vaultSourceLogs     = "factory_logs"
+)
+
+// newVaultSourceFromEnv builds the VaultSource selected by environment variables:
+//
+//	VAULT_SOURCES    comma-separated list of factory, factory_logs, static, postgres (default: factory)
+//	VAULT_ADDRESSES  addresses served by the static source
+//	VAULT_ALLOWLIST  if set, only these vaults are processed
+//	VAULT_DENYLIST   vaults that are never processed
+//
+// The postgres source requires pool; its disabled rows are always applied as a deny-list.
+// The factory_logs source persists its progress in pool when set, in memory otherwise.
+// A single source without allow/deny lists is returned as-is; anything else is wrapped in a CompositeVaultSource.
+//
+//nolint:ireturn // returns VaultSource by design
+func newVaultSourceFromEnv(ethClient *ethclient.Client, pool *pgxpool.Pool, logger *slog.Logger) (VaultSource, error) {
+	kinds := splitList(os.Getenv("VAULT_SOURCES"))
+	if len(kinds) == 0 {
+		kinds = []string{vaultSourceFactory}
+	}
+
+	allow, err := parseAddressList(os.Getenv("VAULT_ALLOWLIST"))
+	if err != nil {
+		return nil, fmt.Errorf("parse VAULT_ALLOWLIST: %w", err)
+	}
+
+	deny, err := parseAddressList(os.Getenv("VAULT_DENYLIST"))
+	if err != nil {
+		return nil, fmt.Errorf("parse VAULT_DENYLIST: %w", err)
+	}
+
+	var (
+		sources     []VaultSource
+		denySources []VaultSource
+	)
+
+	for _, kind := range kinds {
+		switch kind {
+		case vaultSourceFactory:
+			factoryAddr := os.Getenv("FACTORY_ADDRESS")
+			if factoryAddr == "" {
+				return nil, errEnv("FACTORY_ADDRESS")
+			}
+
+			sources = append(sources, vault.NewFactoryVaultSource(ethClient, common.HexToAddress(factoryAddr)))
+		case vaultSourceLogs:
+			src, err := newLogVaultSourceFromEnv(ethClient, pool, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			sources = append(sources, src)
+		case vaultSourceStatic:
+			addrs, err := parseAddressList(os.Getenv("VAULT_ADDRESSES"))
+			if err != nil {
+				return nil, fmt.Errorf("parse VAULT_ADDRESSES: %w", err)
+			}
+
+			if len(addrs) == 0 {
+				return nil, errEnv("VAULT_ADDRESSES")
+			}
+
+			sources = append(sources, NewStaticVaultSource(addrs))
+		case vaultSourcePostgres:
+			if pool == nil {
+				return nil, errEnv("DATABASE_URL")
+			}
+
+			pg := NewPostgresVaultSource(db.New(pool))
+			sources = append(sources, pg)
+			denySources = append(denySources, pg.Disabled())
+		default:
+			return nil, fmt.Errorf("unknown vault source: %s", kind)
+		}
+	}
+
+	logger.Info("vault sources configured",
+		slog.Any("sources", kinds),
+		slog.Int("allowlist", len(allow)),
+		slog.Int("denylist", len(deny)),
+	)
+
+	if len(sources) == 1 && len(denySources) == 0 && len(allow) == 0 && len(deny) == 0 {
+		return sources[0], nil
+	}
+
+	composite := NewCompositeVaultSource(sources, allow, deny, logger)
+	for _, src := range denySources {
+		composite.AddDenySource(src)
+	}
+
+	return composite, nil
+}
+
+// newLogVaultSourceFromEnv builds a LogVaultSource from environment variables:
+//
+//	FACTORY_ADDRESS              factory emitting the creation events (required)
+//	FACTORY_START_BLOCK          block to start scanning from (factory deploy block)
+//	FACTORY_CONFIRMATIONS        blocks behind head to stop at (default 12)
+//	FACTORY_LOG_BLOCK_RANGE      max blocks per eth_getLogs call (default 5000)
+//	FACTORY_VAULT_CREATED_EVENT  creation event signature (default: VaultCreated from the factory ABI)
+//	FACTORY_VAULT_TOPIC_INDEX    topic holding the vault address (default: from the factory ABI)
+func newLogVaultSourceFromEnv(ethClient *ethclient.Client, pool *pgxpool.Pool, logger *slog.Logger) (*LogVaultSource, error) {
+	factoryAddr := os.Getenv("FACTORY_ADDRESS")
+	if factoryAddr == "" {
+		return nil, errEnv("FACTORY_ADDRESS")
+	}
+
+	cfg := LogVaultSourceConfig{
+		FactoryAddress: common.HexToAddress(factoryAddr),
+		EventSignature: os.Getenv("FACTORY_VAULT_CREATED_EVENT"),
+		Confirmations:  defaultConfirmations,
+	}
+
+	var err error
+
+	if cfg.StartBlock, err = parseUintEnv("FACTORY_START_BLOCK", 0); err != nil {
+		return nil, err
+	}
+
+	if cfg.Confirmations, err = parseUintEnv("FACTORY_CONFIRMATIONS", defaultConfirmations); err != nil {
+		return nil, err
+	}
+
+	if cfg.BlockRange, err = parseUintEnv("FACTORY_LOG_BLOCK_RANGE", defaultLogBlockRange); err != nil {
+		return nil, err
+	}
+
+	_, defaultTopicIndex := vault.VaultCreatedTopic()
+
+	topicIndex, err := parseUintEnv("FACTORY_VAULT_TOPIC_INDEX", uint64(defaultTopicIndex)) //nolint:gosec // topic indexes are 1-3
+	if err != nil {
+		return nil, err
+	}
+
+	if topicIndex < 1 || topicIndex > 3 {
+		return nil, fmt.Errorf("FACTORY_VAULT_TOPIC_INDEX must be 1-3, got %d", topicIndex)
+	}
+
+	cfg.VaultTopicIndex = int(topicIndex)
+
+	var store DiscoveryStore = NewMemoryDiscoveryStore()
+	if pool != nil {
+		store = NewPostgresDiscoveryStore(pool)
+	} else {
+		logger.Warn("DATABASE_URL not set, factory log discovery will rescan from FACTORY_START_BLOCK on restart")
+	}
+
+	return NewLogVaultSource(ethClient, store, cfg, logger), nil
+}
+
+// parseUintEnv parses an unsigned integer env var, returning def when it is unset.
+func parseUintEnv(name string, def uint64) (uint64, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+
+	n, err := strconv.ParseUint(v, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse %s: %w", name, err)
+	}
+
+	return n, nil
+}
+
+// newPgxPoolFromEnv connects to DATABASE_URL when it is set.
+// Returns (nil, nil) when DATABASE_URL is empty.
+func newPgxPoolFromEnv(ctx context.Context) (*pgxpool.Pool, error) {
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		return nil, nil //nolint:nilnil // database is optional for the agent
+	}
+
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("pgxpool new: %w", err)
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("pgx ping: %w", err)
+	}
+
+	return pool, nil
+}
+
+// splitList splits a comma-separated list, trimming and lowercasing entries.
+func splitList(s string) []string {
+	var out []string
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part != "" {
+			out = append(out, part)
+		}
+	}
+
+	return out
+}
+
+// Ensure the factory source implements VaultSource.
+var _ VaultSource = (*vault.FactoryVaultSource)(nil)
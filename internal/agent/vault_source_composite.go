@@ -0,0 +1,129 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// CompositeVaultSource unions several sources and filters the result with allow- and deny-lists.
+//
+// Union sources are best-effort: a failing source is logged and skipped as long as
+// at least one source succeeds. Deny sources fail closed: if one cannot be read,
+// the whole call fails so a quarantined vault is never processed by accident.
+type CompositeVaultSource struct {
+	sources     []VaultSource
+	denySources []VaultSource
+	allow       map[common.Address]struct{}
+	deny        map[common.Address]struct{}
+	logger      *slog.Logger
+}
+
+// NewCompositeVaultSource creates a composite source.
+// An empty allow list means every vault is allowed; deny always wins over allow.
+func NewCompositeVaultSource(sources []VaultSource, allow, deny []common.Address, logger *slog.Logger) *CompositeVaultSource {
+	return &CompositeVaultSource{
+		sources: sources,
+		allow:   toAddressSet(allow),
+		deny:    toAddressSet(deny),
+		logger:  logger,
+	}
+}
+
+// AddDenySource registers a source whose addresses are always excluded.
+func (s *CompositeVaultSource) AddDenySource(src VaultSource) {
+	s.denySources = append(s.denySources, src)
+}
+
+// GetVaultAddresses returns the de-duplicated union of all sources after filtering.
+// Order follows the order of sources, then the order within each source.
+func (s *CompositeVaultSource) GetVaultAddresses(ctx context.Context) ([]common.Address, error) {
+	if len(s.sources) == 0 {
+		return nil, errors.New("no vault sources configured")
+	}
+
+	deny := make(map[common.Address]struct{}, len(s.deny))
+	for addr := range s.deny {
+		deny[addr] = struct{}{}
+	}
+
+	for i, src := range s.denySources {
+		addrs, err := src.GetVaultAddresses(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("deny source %d: %w", i, err)
+		}
+
+		for _, addr := range addrs {
+			deny[addr] = struct{}{}
+		}
+	}
+
+	var (
+		result []common.Address
+		errs   []error
+	)
+
+	seen := make(map[common.Address]struct{})
+	failed := 0
+
+	for i, src := range s.sources {
+		addrs, err := src.GetVaultAddresses(ctx)
+		if err != nil {
+			failed++
+
+			errs = append(errs, fmt.Errorf("source %d: %w", i, err))
+			s.logger.WarnContext(ctx, "vault source failed, skipping", slog.Int("source", i), slog.Any("error", err))
+
+			continue
+		}
+
+		for _, addr := range addrs {
+			if _, ok := seen[addr]; ok {
+				continue
+			}
+
+			seen[addr] = struct{}{}
+
+			if !s.allowed(addr, deny) {
+				continue
+			}
+
+			result = append(result, addr)
+		}
+	}
+
+	if failed == len(s.sources) {
+		return nil, fmt.Errorf("all vault sources failed: %w", errors.Join(errs...))
+	}
+
+	return result, nil
+}
+
+func (s *CompositeVaultSource) allowed(addr common.Address, deny map[common.Address]struct{}) bool {
+	if _, ok := deny[addr]; ok {
+		return false
+	}
+
+	if len(s.allow) == 0 {
+		return true
+	}
+
+	_, ok := s.allow[addr]
+
+	return ok
+}
+
+func toAddressSet(addrs []common.Address) map[common.Address]struct{} {
+	set := make(map[common.Address]struct{}, len(addrs))
+	for _, addr := range addrs {
+		set[addr] = struct{}{}
+	}
+
+	return set
+}
+
+// Ensure CompositeVaultSource implements VaultSource.
+var _ VaultSource = (*CompositeVaultSource)(nil)
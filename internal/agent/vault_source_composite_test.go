@@ -0,0 +1,152 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var errSourceDown = errors.New("source down")
+
+func addr(n byte) common.Address {
+	return common.BytesToAddress([]byte{n})
+}
+
+func failingSource() VaultSource {
+	return vaultSourceFunc(func(context.Context) ([]common.Address, error) {
+		return nil, errSourceDown
+	})
+}
+
+func newTestComposite(sources []VaultSource, allow, deny []common.Address) *CompositeVaultSource {
+	return NewCompositeVaultSource(sources, allow, deny, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestCompositeVaultSource_UnionDedup(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		NewStaticVaultSource([]common.Address{addr(1), addr(2)}),
+		NewStaticVaultSource([]common.Address{addr(2), addr(3)}),
+	}, nil, nil)
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []common.Address{addr(1), addr(2), addr(3)}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d vaults, got %d", len(want), len(got))
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("index %d: expected %s, got %s", i, want[i].Hex(), got[i].Hex())
+		}
+	}
+}
+
+func TestCompositeVaultSource_AllowList(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		NewStaticVaultSource([]common.Address{addr(1), addr(2), addr(3)}),
+	}, []common.Address{addr(2)}, nil)
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != addr(2) {
+		t.Errorf("expected only allowed vault, got %v", got)
+	}
+}
+
+func TestCompositeVaultSource_DenyWinsOverAllow(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		NewStaticVaultSource([]common.Address{addr(1), addr(2)}),
+	}, []common.Address{addr(1), addr(2)}, []common.Address{addr(1)})
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != addr(2) {
+		t.Errorf("expected denied vault to be removed, got %v", got)
+	}
+}
+
+func TestCompositeVaultSource_DenySource(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		NewStaticVaultSource([]common.Address{addr(1), addr(2), addr(3)}),
+	}, nil, nil)
+	s.AddDenySource(NewStaticVaultSource([]common.Address{addr(3)}))
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("expected 2 vaults, got %d", len(got))
+	}
+
+	for _, a := range got {
+		if a == addr(3) {
+			t.Error("quarantined vault should be skipped")
+		}
+	}
+}
+
+func TestCompositeVaultSource_DenySourceFailsClosed(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		NewStaticVaultSource([]common.Address{addr(1)}),
+	}, nil, nil)
+	s.AddDenySource(failingSource())
+
+	if _, err := s.GetVaultAddresses(context.Background()); !errors.Is(err, errSourceDown) {
+		t.Errorf("expected deny source error, got %v", err)
+	}
+}
+
+func TestCompositeVaultSource_PartialFailure(t *testing.T) {
+	s := newTestComposite([]VaultSource{
+		failingSource(),
+		NewStaticVaultSource([]common.Address{addr(1)}),
+	}, nil, nil)
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != addr(1) {
+		t.Errorf("expected vaults from healthy source, got %v", got)
+	}
+}
+
+func TestCompositeVaultSource_AllFailed(t *testing.T) {
+	s := newTestComposite([]VaultSource{failingSource(), failingSource()}, nil, nil)
+
+	if _, err := s.GetVaultAddresses(context.Background()); !errors.Is(err, errSourceDown) {
+		t.Errorf("expected error when all sources fail, got %v", err)
+	}
+}
+
+func TestParseAddressList(t *testing.T) {
+	got, err := parseAddressList(" 0x0000000000000000000000000000000000000001, ,0x0000000000000000000000000000000000000002")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != addr(1) || got[1] != addr(2) {
+		t.Errorf("unexpected addresses: %v", got)
+	}
+
+	if _, err := parseAddressList("0x1234,nope"); err == nil {
+		t.Error("expected error for invalid address")
+	}
+}
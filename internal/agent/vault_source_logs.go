@@ -0,0 +1,342 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"remora/internal/db"
+	"remora/internal/logscan"
+	"remora/internal/vault"
+)
+
+const (
+	defaultConfirmations = 12
+	defaultLogBlockRange = 5000
+)
+
+// LogFilterer is the subset of an Ethereum client needed for log-based discovery.
+type LogFilterer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// DiscoveredVault is a vault found in a factory creation log.
+type DiscoveredVault struct {
+	Address     common.Address
+	BlockNumber uint64
+	TxHash      common.Hash
+	LogIndex    uint
+}
+
+// DiscoveryStore persists the indexing cursor and the vaults found so far.
+type DiscoveryStore interface {
+	// LastIndexedBlock returns the last fully indexed block for the factory.
+	// ok is false when the factory has never been indexed.
+	LastIndexedBlock(ctx context.Context, factory common.Address) (block uint64, ok bool, err error)
+
+	// SaveProgress stores newly discovered vaults and advances the cursor in one step.
+	SaveProgress(ctx context.Context, factory common.Address, vaults []DiscoveredVault, lastBlock uint64) error
+
+	// KnownVaults returns all discovered vaults in creation order.
+	KnownVaults(ctx context.Context, factory common.Address) ([]common.Address, error)
+}
+
+// LogVaultSourceConfig configures a LogVaultSource.
+type LogVaultSourceConfig struct {
+	FactoryAddress common.Address
+	// EventSignature overrides the canonical signature of the factory's vault creation event,
+	// e.g. "VaultCreated(address,address)". Empty uses VaultCreated from the factory ABI.
+	EventSignature string
+	// VaultTopicIndex overrides the topic holding the vault address (the event must index it).
+	// Zero uses the index of the vault input of VaultCreated in the factory ABI.
+	VaultTopicIndex int
+	// StartBlock is the first block to scan when nothing has been indexed yet (factory deploy block).
+	StartBlock uint64
+	// Confirmations is how many blocks behind head indexing stops, so reorged logs are never stored.
+	Confirmations uint64
+	// BlockRange is the maximum number of blocks requested per eth_getLogs call.
+	BlockRange uint64
+}
+
+// LogVaultSource discovers vaults by incrementally indexing the factory's creation logs.
+// Each call only scans blocks after the persisted cursor, so discovery costs O(new blocks)
+// RPC calls instead of O(vaults).
+type LogVaultSource struct {
+	scanner    *logscan.Scanner
+	store      DiscoveryStore
+	factory    common.Address
+	topic      common.Hash
+	topicIndex int
+	logger     *slog.Logger
+
+	mu sync.Mutex // serializes indexing runs
+}
+
+// NewLogVaultSource creates a log-indexing vault source. Zero config values fall back to defaults.
+func NewLogVaultSource(client LogFilterer, store DiscoveryStore, cfg LogVaultSourceConfig, logger *slog.Logger) *LogVaultSource {
+	topic, topicIndex := vault.VaultCreatedTopic()
+
+	if cfg.EventSignature != "" {
+		topic = crypto.Keccak256Hash([]byte(cfg.EventSignature))
+	}
+
+	if cfg.VaultTopicIndex > 0 {
+		topicIndex = cfg.VaultTopicIndex
+	}
+
+	scanCfg := logscan.Config{
+		StartBlock:    cfg.StartBlock,
+		Confirmations: cfg.Confirmations,
+		BlockRange:    cfg.BlockRange,
+	}
+
+	return &LogVaultSource{
+		scanner:    logscan.New(client, nil, scanCfg, logscan.Config{Confirmations: defaultConfirmations, BlockRange: defaultLogBlockRange}, logger),
+		store:      store,
+		factory:    cfg.FactoryAddress,
+		topic:      topic,
+		topicIndex: topicIndex,
+		logger:     logger,
+	}
+}
+
+// GetVaultAddresses indexes any new confirmed blocks and returns all known vaults.
+func (s *LogVaultSource) GetVaultAddresses(ctx context.Context) ([]common.Address, error) {
+	if s.factory == (common.Address{}) {
+		return nil, errors.New("factory address not set")
+	}
+
+	if err := s.index(ctx); err != nil {
+		return nil, err
+	}
+
+	addrs, err := s.store.KnownVaults(ctx, s.factory)
+	if err != nil {
+		return nil, fmt.Errorf("known vaults: %w", err)
+	}
+
+	return addrs, nil
+}
+
+// index scans [cursor+1, head-confirmations] in chunks, persisting progress after each chunk.
+func (s *LogVaultSource) index(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	safe, ok, err := s.scanner.SafeBlock(ctx)
+	if err != nil || !ok {
+		return err
+	}
+
+	q := ethereum.FilterQuery{
+		Addresses: []common.Address{s.factory},
+		Topics:    [][]common.Hash{{s.topic}},
+	}
+
+	found, err := logscan.Sync(ctx, s.scanner, q, safe, discoveryCursor{store: s.store, factory: s.factory}, s.decode)
+	if err != nil {
+		return fmt.Errorf("index factory logs: %w", err)
+	}
+
+	s.logger.InfoContext(ctx, "factory logs indexed",
+		slog.String("factory", s.factory.Hex()),
+		slog.Uint64("safe_block", safe),
+		slog.Int("new_vaults", found),
+	)
+
+	return nil
+}
+
+// decode reads the vault address of a creation log.
+func (s *LogVaultSource) decode(lg logscan.Log) (DiscoveredVault, error) {
+	if len(lg.Topics) <= s.topicIndex || lg.Topics[0] != s.topic {
+		return DiscoveredVault{}, logscan.ErrSkip
+	}
+
+	return DiscoveredVault{
+		Address:     common.BytesToAddress(lg.Topics[s.topicIndex].Bytes()),
+		BlockNumber: lg.BlockNumber,
+		TxHash:      lg.TxHash,
+		LogIndex:    lg.Index,
+	}, nil
+}
+
+// discoveryCursor adapts a DiscoveryStore to the factory's scan cursor.
+type discoveryCursor struct {
+	store   DiscoveryStore
+	factory common.Address
+}
+
+func (c discoveryCursor) IndexedBlock(ctx context.Context) (uint64, bool, error) {
+	return c.store.LastIndexedBlock(ctx, c.factory) //nolint:wrapcheck // wrapped by logscan.Sync
+}
+
+func (c discoveryCursor) Save(ctx context.Context, vaults []DiscoveredVault, block uint64) error {
+	if err := c.store.SaveProgress(ctx, c.factory, vaults, block); err != nil {
+		return fmt.Errorf("save progress: %w", err)
+	}
+
+	return nil
+}
+
+// MemoryDiscoveryStore keeps discovery progress in memory.
+// Used when no database is configured; progress is lost on restart.
+type MemoryDiscoveryStore struct {
+	mu      sync.Mutex
+	cursors map[common.Address]uint64
+	vaults  map[common.Address][]common.Address
+	seen    map[common.Address]map[common.Address]struct{}
+}
+
+// NewMemoryDiscoveryStore creates an empty in-memory discovery store.
+func NewMemoryDiscoveryStore() *MemoryDiscoveryStore {
+	return &MemoryDiscoveryStore{
+		cursors: make(map[common.Address]uint64),
+		vaults:  make(map[common.Address][]common.Address),
+		seen:    make(map[common.Address]map[common.Address]struct{}),
+	}
+}
+
+// LastIndexedBlock implements DiscoveryStore.
+func (m *MemoryDiscoveryStore) LastIndexedBlock(_ context.Context, factory common.Address) (uint64, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	block, ok := m.cursors[factory]
+
+	return block, ok, nil
+}
+
+// SaveProgress implements DiscoveryStore.
+func (m *MemoryDiscoveryStore) SaveProgress(_ context.Context, factory common.Address, vaults []DiscoveredVault, lastBlock uint64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen, ok := m.seen[factory]
+	if !ok {
+		seen = make(map[common.Address]struct{})
+		m.seen[factory] = seen
+	}
+
+	for _, v := range vaults {
+		if _, ok := seen[v.Address]; ok {
+			continue
+		}
+
+		seen[v.Address] = struct{}{}
+		m.vaults[factory] = append(m.vaults[factory], v.Address)
+	}
+
+	m.cursors[factory] = lastBlock
+
+	return nil
+}
+
+// KnownVaults implements DiscoveryStore.
+func (m *MemoryDiscoveryStore) KnownVaults(_ context.Context, factory common.Address) ([]common.Address, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	addrs := make([]common.Address, len(m.vaults[factory]))
+	copy(addrs, m.vaults[factory])
+
+	return addrs, nil
+}
+
+// PostgresDiscoveryStore persists discovery progress in the vault_discovery_cursor
+// and factory_vault tables so indexing resumes where it stopped after a restart.
+type PostgresDiscoveryStore struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+// NewPostgresDiscoveryStore creates a discovery store backed by pool.
+func NewPostgresDiscoveryStore(pool *pgxpool.Pool) *PostgresDiscoveryStore {
+	return &PostgresDiscoveryStore{pool: pool, q: db.New(pool)}
+}
+
+// LastIndexedBlock implements DiscoveryStore.
+func (p *PostgresDiscoveryStore) LastIndexedBlock(ctx context.Context, factory common.Address) (uint64, bool, error) {
+	block, err := p.q.GetVaultDiscoveryCursor(ctx, factory.Hex())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+
+		return 0, false, fmt.Errorf("get discovery cursor: %w", err)
+	}
+
+	return uint64(block), true, nil //nolint:gosec // block numbers are non-negative
+}
+
+// SaveProgress implements DiscoveryStore. Vaults and cursor are written in one transaction.
+func (p *PostgresDiscoveryStore) SaveProgress(ctx context.Context, factory common.Address, vaults []DiscoveredVault, lastBlock uint64) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	q := p.q.WithTx(tx)
+	now := time.Now()
+
+	for _, v := range vaults {
+		if err := q.InsertFactoryVault(ctx, db.InsertFactoryVaultParams{
+			FactoryAddress: factory.Hex(),
+			VaultAddress:   v.Address.Hex(),
+			BlockNumber:    int64(v.BlockNumber), //nolint:gosec // block numbers fit in int64
+			TxHash:         v.TxHash.Hex(),
+			LogIndex:       int(v.LogIndex), //nolint:gosec // log index fits in int
+			CreatedAt:      now,
+		}); err != nil {
+			return fmt.Errorf("insert factory vault %s: %w", v.Address.Hex(), err)
+		}
+	}
+
+	if err := q.UpsertVaultDiscoveryCursor(ctx, db.UpsertVaultDiscoveryCursorParams{
+		FactoryAddress: factory.Hex(),
+		LastBlock:      int64(lastBlock), //nolint:gosec // block numbers fit in int64
+		UpdatedAt:      now,
+	}); err != nil {
+		return fmt.Errorf("upsert discovery cursor: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+// KnownVaults implements DiscoveryStore.
+func (p *PostgresDiscoveryStore) KnownVaults(ctx context.Context, factory common.Address) ([]common.Address, error) {
+	rows, err := p.q.ListFactoryVaults(ctx, factory.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("list factory vaults: %w", err)
+	}
+
+	addrs := make([]common.Address, 0, len(rows))
+	for _, row := range rows {
+		addrs = append(addrs, common.HexToAddress(row))
+	}
+
+	return addrs, nil
+}
+
+// Ensure implementations satisfy their interfaces.
+var (
+	_ VaultSource    = (*LogVaultSource)(nil)
+	_ DiscoveryStore = (*MemoryDiscoveryStore)(nil)
+	_ DiscoveryStore = (*PostgresDiscoveryStore)(nil)
+)
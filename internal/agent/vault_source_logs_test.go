@@ -0,0 +1,191 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"remora/internal/vault"
+)
+
+var errRangeTooLarge = errors.New("block range too large")
+
+// fakeFilterer serves logs from memory and rejects queries wider than maxRange.
+type fakeFilterer struct {
+	head     uint64
+	logs     []types.Log
+	maxRange uint64
+	err      error
+	queries  int
+}
+
+func (f *fakeFilterer) BlockNumber(context.Context) (uint64, error) {
+	return f.head, nil
+}
+
+func (f *fakeFilterer) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.queries++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if f.maxRange > 0 && to-from+1 > f.maxRange {
+		return nil, errRangeTooLarge
+	}
+
+	var out []types.Log
+
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= from && lg.BlockNumber <= to {
+			out = append(out, lg)
+		}
+	}
+
+	return out, nil
+}
+
+func vaultCreatedLog(block uint64, v common.Address) types.Log {
+	return types.Log{
+		BlockNumber: block,
+		Topics: []common.Hash{
+			vaultCreatedTopic(),
+			common.BytesToHash(v.Bytes()),
+		},
+	}
+}
+
+func vaultCreatedTopic() common.Hash {
+	topic, _ := vault.VaultCreatedTopic()
+
+	return topic
+}
+
+func newTestLogSource(f *fakeFilterer, store DiscoveryStore, cfg LogVaultSourceConfig) *LogVaultSource {
+	cfg.FactoryAddress = addr(0xff)
+
+	return NewLogVaultSource(f, store, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestLogVaultSource_Incremental(t *testing.T) {
+	f := &fakeFilterer{
+		head: 120,
+		logs: []types.Log{
+			vaultCreatedLog(10, addr(1)),
+			vaultCreatedLog(50, addr(2)),
+			vaultCreatedLog(115, addr(3)), // not yet confirmed
+		},
+	}
+	store := NewMemoryDiscoveryStore()
+	s := newTestLogSource(f, store, LogVaultSourceConfig{Confirmations: 10, BlockRange: 1000})
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 2 || got[0] != addr(1) || got[1] != addr(2) {
+		t.Fatalf("expected confirmed vaults only, got %v", got)
+	}
+
+	if last, _, _ := store.LastIndexedBlock(context.Background(), addr(0xff)); last != 110 {
+		t.Errorf("expected cursor at 110, got %d", last)
+	}
+
+	f.head = 130
+	f.queries = 0
+
+	got, err = s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 3 || got[2] != addr(3) {
+		t.Errorf("expected newly confirmed vault, got %v", got)
+	}
+
+	if f.queries != 1 {
+		t.Errorf("expected a single query for new blocks, got %d", f.queries)
+	}
+}
+
+func TestLogVaultSource_ShrinksBlockRange(t *testing.T) {
+	f := &fakeFilterer{
+		head:     100,
+		maxRange: 25,
+		logs:     []types.Log{vaultCreatedLog(80, addr(1))},
+	}
+	s := newTestLogSource(f, NewMemoryDiscoveryStore(), LogVaultSourceConfig{BlockRange: 100})
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != addr(1) {
+		t.Errorf("expected vault after shrinking range, got %v", got)
+	}
+}
+
+func TestLogVaultSource_SkipsRemovedAndForeignLogs(t *testing.T) {
+	removed := vaultCreatedLog(5, addr(1))
+	removed.Removed = true
+
+	foreign := vaultCreatedLog(6, addr(2))
+	foreign.Topics[0] = crypto.Keccak256Hash([]byte("Other(address)"))
+
+	f := &fakeFilterer{
+		head: 30,
+		logs: []types.Log{removed, foreign, vaultCreatedLog(7, addr(3))},
+	}
+	s := newTestLogSource(f, NewMemoryDiscoveryStore(), LogVaultSourceConfig{})
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 1 || got[0] != addr(3) {
+		t.Errorf("expected only valid creation log, got %v", got)
+	}
+}
+
+func TestLogVaultSource_OutageDoesNotShrinkRange(t *testing.T) {
+	f := &fakeFilterer{head: 10000, err: errors.New("dial tcp: connection refused")}
+	s := newTestLogSource(f, NewMemoryDiscoveryStore(), LogVaultSourceConfig{BlockRange: 4096})
+
+	if _, err := s.GetVaultAddresses(context.Background()); err == nil {
+		t.Fatal("expected the provider error")
+	}
+
+	if f.queries != 1 {
+		t.Errorf("expected a single query, got %d", f.queries)
+	}
+}
+
+func TestLogVaultSource_DefaultsConfirmations(t *testing.T) {
+	f := &fakeFilterer{head: 100, logs: []types.Log{vaultCreatedLog(95, addr(1))}}
+	store := NewMemoryDiscoveryStore()
+	s := newTestLogSource(f, store, LogVaultSourceConfig{})
+
+	got, err := s.GetVaultAddresses(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(got) != 0 {
+		t.Errorf("expected unconfirmed vault to be skipped, got %v", got)
+	}
+
+	if last, _, _ := store.LastIndexedBlock(context.Background(), addr(0xff)); last != 100-defaultConfirmations {
+		t.Errorf("expected cursor at %d, got %d", 100-defaultConfirmations, last)
+	}
+}
@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/db"
+)
+
+// ErrVaultNotRegistered is returned when enabling or disabling a vault missing from the registry.
+var ErrVaultNotRegistered = errors.New("vault not in registry")
+
+// PostgresVaultSource reads vaults from the vault_registry table.
+// Rows can be enabled or disabled at runtime to onboard or quarantine vaults without a redeploy.
+type PostgresVaultSource struct {
+	q *db.Queries
+}
+
+// NewPostgresVaultSource creates a vault source backed by the vault registry.
+func NewPostgresVaultSource(q *db.Queries) *PostgresVaultSource {
+	return &PostgresVaultSource{q: q}
+}
+
+// GetVaultAddresses returns all enabled vaults in the registry.
+func (s *PostgresVaultSource) GetVaultAddresses(ctx context.Context) ([]common.Address, error) {
+	return s.list(ctx, true)
+}
+
+// Disabled returns a VaultSource listing the vaults that are disabled in the registry.
+// It is meant to be used as a deny source so quarantined vaults are skipped
+// even when another source (e.g. the factory) still reports them.
+//
+//nolint:ireturn // returns VaultSource by design
+func (s *PostgresVaultSource) Disabled() VaultSource {
+	return vaultSourceFunc(func(ctx context.Context) ([]common.Address, error) {
+		return s.list(ctx, false)
+	})
+}
+
+// Add registers a vault, enabled, or updates its label if it is already registered.
+func (s *PostgresVaultSource) Add(ctx context.Context, address common.Address, label string) error {
+	now := time.Now()
+
+	err := s.q.UpsertVaultRegistry(ctx, db.UpsertVaultRegistryParams{
+		Address:   registryAddress(address),
+		Enabled:   true,
+		Label:     label,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("upsert vault registry: %w", err)
+	}
+
+	return nil
+}
+
+// SetEnabled onboards (enabled) or quarantines (disabled) a registered vault.
+func (s *PostgresVaultSource) SetEnabled(ctx context.Context, address common.Address, enabled bool) error {
+	n, err := s.q.SetVaultRegistryEnabled(ctx, db.SetVaultRegistryEnabledParams{
+		Address:   registryAddress(address),
+		Enabled:   enabled,
+		UpdatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("set vault registry enabled: %w", err)
+	}
+
+	if n == 0 {
+		return fmt.Errorf("%w: %s", ErrVaultNotRegistered, address.Hex())
+	}
+
+	return nil
+}
+
+// registryAddress is the stored form of an address. The table only accepts lowercase hex, so a
+// vault cannot be registered twice under different casings.
+func registryAddress(address common.Address) string {
+	return strings.ToLower(address.Hex())
+}
+
+func (s *PostgresVaultSource) list(ctx context.Context, enabled bool) ([]common.Address, error) {
+	rows, err := s.q.ListVaultRegistryAddresses(ctx, enabled)
+	if err != nil {
+		return nil, fmt.Errorf("list vault registry: %w", err)
+	}
+
+	addrs := make([]common.Address, 0, len(rows))
+	for _, row := range rows {
+		if !common.IsHexAddress(row) {
+			return nil, fmt.Errorf("invalid address in vault registry: %s", row)
+		}
+
+		addrs = append(addrs, common.HexToAddress(row))
+	}
+
+	return addrs, nil
+}
+
+// vaultSourceFunc adapts a function to the VaultSource interface.
+type vaultSourceFunc func(ctx context.Context) ([]common.Address, error)
+
+func (f vaultSourceFunc) GetVaultAddresses(ctx context.Context) ([]common.Address, error) {
+	return f(ctx)
+}
+
+// Ensure PostgresVaultSource implements VaultSource.
+var _ VaultSource = (*PostgresVaultSource)(nil)
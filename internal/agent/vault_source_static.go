@@ -0,0 +1,54 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// StaticVaultSource serves a fixed list of vault addresses from configuration.
+type StaticVaultSource struct {
+	addresses []common.Address
+}
+
+// NewStaticVaultSource creates a vault source that always returns the given addresses.
+func NewStaticVaultSource(addresses []common.Address) *StaticVaultSource {
+	addrs := make([]common.Address, len(addresses))
+	copy(addrs, addresses)
+
+	return &StaticVaultSource{addresses: addrs}
+}
+
+// GetVaultAddresses returns the configured vault addresses.
+func (s *StaticVaultSource) GetVaultAddresses(_ context.Context) ([]common.Address, error) {
+	addrs := make([]common.Address, len(s.addresses))
+	copy(addrs, s.addresses)
+
+	return addrs, nil
+}
+
+// parseAddressList parses a comma-separated list of hex addresses.
+// Empty entries are ignored; an invalid entry is an error.
+func parseAddressList(s string) ([]common.Address, error) {
+	var addrs []common.Address
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if !common.IsHexAddress(part) {
+			return nil, fmt.Errorf("invalid address: %s", part)
+		}
+
+		addrs = append(addrs, common.HexToAddress(part))
+	}
+
+	return addrs, nil
+}
+
+// Ensure StaticVaultSource implements VaultSource.
+var _ VaultSource = (*StaticVaultSource)(nil)
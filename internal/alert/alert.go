@@ -0,0 +1,130 @@
+// Package alert delivers operational alerts to logs or a webhook.
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// Alert is a single notification. Key identifies the condition, e.g. "low_balance:<address>",
+// so repeats can be throttled.
+type Alert struct {
+	Key     string            `json:"key"`
+	Title   string            `json:"title"`
+	Message string            `json:"message"`
+	Labels  map[string]string `json:"labels,omitempty"`
+}
+
+// Alerter sends alerts.
+type Alerter interface {
+	Alert(ctx context.Context, a Alert) error
+}
+
+// LogAlerter writes alerts to the log at error level.
+type LogAlerter struct {
+	logger *slog.Logger
+}
+
+var _ Alerter = (*LogAlerter)(nil)
+
+func NewLogAlerter(logger *slog.Logger) *LogAlerter {
+	return &LogAlerter{logger: logger}
+}
+
+func (l *LogAlerter) Alert(ctx context.Context, a Alert) error {
+	attrs := []any{slog.String("key", a.Key), slog.String("title", a.Title)}
+	for k, v := range a.Labels {
+		attrs = append(attrs, slog.String(k, v))
+	}
+
+	l.logger.ErrorContext(ctx, "alert: "+a.Message, attrs...)
+
+	return nil
+}
+
+// WebhookAlerter posts alerts as JSON to a URL (e.g. an Alertmanager or chat webhook relay).
+type WebhookAlerter struct {
+	url    string
+	client *http.Client
+}
+
+var _ Alerter = (*WebhookAlerter)(nil)
+
+func NewWebhookAlerter(url string) *WebhookAlerter {
+	return &WebhookAlerter{url: url, client: &http.Client{Timeout: webhookTimeout}}
+}
+
+func (w *WebhookAlerter) Alert(ctx context.Context, a Alert) error {
+	body, err := json.Marshal(a)
+	if err != nil {
+		return fmt.Errorf("marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create alert request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("post alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: status %d", ErrWebhookFailed, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Throttled forwards each alert key at most once per interval.
+type Throttled struct {
+	next     Alerter
+	interval time.Duration
+	now      func() time.Time
+
+	mu   sync.Mutex
+	sent map[string]time.Time
+}
+
+var _ Alerter = (*Throttled)(nil)
+
+func NewThrottled(next Alerter, interval time.Duration) *Throttled {
+	return &Throttled{next: next, interval: interval, now: time.Now, sent: make(map[string]time.Time)}
+}
+
+func (t *Throttled) Alert(ctx context.Context, a Alert) error {
+	now := t.now()
+
+	t.mu.Lock()
+	if last, ok := t.sent[a.Key]; ok && now.Sub(last) < t.interval {
+		t.mu.Unlock()
+
+		return nil
+	}
+
+	t.sent[a.Key] = now
+	t.mu.Unlock()
+
+	if err := t.next.Alert(ctx, a); err != nil {
+		// Let the next occurrence retry instead of being throttled.
+		t.mu.Lock()
+		delete(t.sent, a.Key)
+		t.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package alert
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type countingAlerter struct {
+	count int
+	err   error
+}
+
+func (c *countingAlerter) Alert(context.Context, Alert) error {
+	c.count++
+
+	return c.err
+}
+
+func TestThrottled(t *testing.T) {
+	t.Parallel()
+
+	next := &countingAlerter{}
+	now := time.Now()
+	th := NewThrottled(next, time.Hour)
+	th.now = func() time.Time { return now }
+
+	_ = th.Alert(t.Context(), Alert{Key: "a"})
+	_ = th.Alert(t.Context(), Alert{Key: "a"})
+	_ = th.Alert(t.Context(), Alert{Key: "b"})
+
+	if next.count != 2 {
+		t.Fatalf("count = %d, want 2", next.count)
+	}
+
+	now = now.Add(time.Hour)
+	_ = th.Alert(t.Context(), Alert{Key: "a"})
+
+	if next.count != 3 {
+		t.Errorf("count after interval = %d, want 3", next.count)
+	}
+
+	next.err = errors.New("down")
+	_ = th.Alert(t.Context(), Alert{Key: "c"})
+	_ = th.Alert(t.Context(), Alert{Key: "c"})
+
+	if next.count != 5 {
+		t.Errorf("failed alerts were throttled: count = %d, want 5", next.count)
+	}
+}
+
+func TestWebhookAlerter(t *testing.T) {
+	t.Parallel()
+
+	var got Alert
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		if got.Key == "fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	w := NewWebhookAlerter(srv.URL)
+
+	if err := w.Alert(t.Context(), Alert{Key: "k", Message: "m", Labels: map[string]string{"x": "y"}}); err != nil {
+		t.Fatalf("Alert() error = %v", err)
+	}
+
+	if got.Key != "k" || got.Labels["x"] != "y" {
+		t.Errorf("posted alert = %+v", got)
+	}
+
+	if err := w.Alert(t.Context(), Alert{Key: "fail"}); !errors.Is(err, ErrWebhookFailed) {
+		t.Errorf("Alert() error = %v, want %v", err, ErrWebhookFailed)
+	}
+}
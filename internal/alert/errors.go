@@ -0,0 +1,5 @@
+package alert
+
+import "errors"
+
+var ErrWebhookFailed = errors.New("alert webhook failed")
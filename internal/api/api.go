@@ -8,30 +8,73 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/go-chi/chi/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
 
+	"remora/internal/apikey"
+	apikeyrepo "remora/internal/apikey/repository"
+	apikeysvc "remora/internal/apikey/service"
+	"remora/internal/auth"
+	authrepo "remora/internal/auth/repository"
+	authsvc "remora/internal/auth/service"
 	"remora/internal/config/api"
 	"remora/internal/db"
 	"remora/internal/liquidity"
+	liquiditycache "remora/internal/liquidity/cache"
+	"remora/internal/liquidity/poolid"
 	liquidityrepo "remora/internal/liquidity/repository"
 	liquiditysvc "remora/internal/liquidity/service"
+	"remora/internal/liquidity/snapshot"
+	snapshotrepo "remora/internal/liquidity/snapshot/repository"
+	"remora/internal/liquidity/stream"
+	"remora/internal/logscan"
+	poolindexer "remora/internal/pool/indexer"
+	poolrepo "remora/internal/pool/repository"
+	poolsvc "remora/internal/pool/service"
+	"remora/internal/ratelimit"
+	"remora/internal/rpc"
+	"remora/internal/token"
+	tokenrepo "remora/internal/token/repository"
+	tokensvc "remora/internal/token/service"
 	"remora/internal/user"
 	"remora/internal/user/repository"
 	"remora/internal/user/service"
 	"remora/internal/vault"
 	vaultapi "remora/internal/vault/api"
+	vaultindexer "remora/internal/vault/indexer"
+	vaultrepo "remora/internal/vault/repository"
+	volumeindexer "remora/internal/volume/indexer"
+	volumerepo "remora/internal/volume/repository"
+	volumesvc "remora/internal/volume/service"
+)
+
+const (
+	redisDialTimeout = time.Second
+	redisIOTimeout   = 500 * time.Millisecond
 )
 
 type Server struct {
-	config        *api.Config
-	httpServer    *http.Server
-	pool          *pgxpool.Pool
-	liquidityRepo *liquidityrepo.Repository
-	ethClient     *ethclient.Client
+	config      *api.Config
+	httpServer  *http.Server
+	pool        *pgxpool.Pool
+	redisClient *redis.Client
+	ethClient   *ethclient.Client
+	streamHub   *stream.Hub
+	jobs        []job
+	stopJobs    context.CancelFunc
+	jobsDone    sync.WaitGroup
+}
+
+// job is a background loop run alongside the HTTP server until shutdown.
+type job struct {
+	name string
+	run  func(ctx context.Context)
 }
 
 type Service struct {
@@ -40,7 +83,12 @@ type Service struct {
 }
 
 func NewServer(ctx context.Context, cfg *api.Config) (*Server, error) {
-	pool, err := newPgxPool(ctx, cfg.PostgreSQL)
+	anonymousScopes, err := apikey.ParseScopes(cfg.Auth.AnonymousScopes)
+	if err != nil {
+		return nil, fmt.Errorf("parse anonymous scopes: %w", err)
+	}
+
+	pool, err := NewPgxPool(ctx, cfg.PostgreSQL)
 	if err != nil {
 		return nil, fmt.Errorf("connect database: %w", err)
 	}
@@ -48,53 +96,225 @@ func NewServer(ctx context.Context, cfg *api.Config) (*Server, error) {
 	queries := db.New(pool)
 
 	userSvc := service.New(repository.New(queries))
+	authSvc := authsvc.New(authrepo.New(queries), userSvc, auth.SIWEConfig{
+		Domain:   cfg.Auth.SIWE.Domain,
+		URI:      cfg.Auth.SIWE.URI,
+		ChainID:  cfg.Auth.SIWE.ChainID,
+		NonceTTL: cfg.Auth.SIWE.NonceTTL,
+	})
+	apiKeySvc := apikeysvc.New(apikeyrepo.New(queries))
 
-	var liquidityRepo *liquidityrepo.Repository
+	swapPools, err := parsePoolIDs(cfg.Pool.Swaps.Pools)
+	if err != nil {
+		pool.Close()
+
+		return nil, fmt.Errorf("swap pools: %w", err)
+	}
+
+	var (
+		liquidityRepo *liquidityrepo.Repository
+		vaultFactory  vaultapi.VaultFactory
+		ethClient     *ethclient.Client
+	)
 
 	if cfg.Ethereum.UseMock {
 		slog.InfoContext(ctx, "using mock liquidity repository") //nolint:sloglint // startup config logging, no logger instance available at this scope
 
 		liquidityRepo = liquidityrepo.NewMock()
 	} else {
-		var err error
+		ethClient, err = rpc.Dial(ctx, rpc.Config{
+			URLs:           append([]string{cfg.Ethereum.RPCURL}, cfg.Ethereum.FallbackRPCURLs...),
+			MaxRetries:     cfg.Ethereum.RPC.MaxRetries,
+			RetryBackoff:   cfg.Ethereum.RPC.RetryBackoff,
+			MaxBlockLag:    cfg.Ethereum.RPC.MaxBlockLag,
+			HealthInterval: cfg.Ethereum.RPC.HealthInterval,
+		}, slog.Default()) //nolint:sloglint // no logger instance available at this scope
+		if err != nil {
+			pool.Close()
+
+			return nil, fmt.Errorf("dial ethereum: %w", err)
+		}
 
-		liquidityRepo, err = liquidityrepo.New(liquidityrepo.Config{
-			RPCURL:          cfg.Ethereum.RPCURL,
+		liquidityRepo, err = liquidityrepo.New(ethClient, liquidityrepo.Config{
 			ContractAddress: cfg.Ethereum.StateViewContractAddr,
 		})
 		if err != nil {
 			pool.Close()
+			ethClient.Close()
 
 			return nil, fmt.Errorf("create liquidity repository: %w", err)
 		}
+
+		vaultFactory = func(addr common.Address) (vault.Vault, error) {
+			return vault.NewClient(addr, ethClient, nil)
+		}
 	}
 
-	liquiditySvc := liquiditysvc.New(liquidityRepo)
+	// Token metadata needs the chain; without it bins are served unpriced.
+	var tokenSvc token.Service
+	if ethClient != nil {
+		tokenSvc = tokensvc.New(tokenrepo.New(queries), tokenrepo.NewERC20Reader(ethClient), slog.Default()) //nolint:sloglint // no logger instance available at this scope
+	}
 
-	var (
-		vaultFactory vaultapi.VaultFactory
-		ethClient    *ethclient.Client
-	)
+	redisClient := newRedisClient(ctx, cfg.Redis)
+
+	poolRepo := poolrepo.New(pool)
+	poolSvc := poolsvc.New(poolRepo, tokenSvc, slog.Default()) //nolint:sloglint // no logger instance available at this scope
+
+	volumeRepo := volumerepo.New(pool)
+	volumeSvc := volumesvc.New(volumeRepo, swapPools)
+
+	var liquiditySvc liquidity.Service = liquiditysvc.New(liquidityRepo, tokenSvc, poolSvc)
+	if cfg.Liquidity.Cache.Enable {
+		liquiditySvc = liquiditycache.New(
+			liquiditySvc,
+			liquidityRepo,
+			redisClient,
+			"remora:liquidity:distribution:",
+			cfg.Liquidity.Cache.TTL,
+			slog.Default(), //nolint:sloglint // no logger instance available at this scope
+		)
+	}
+
+	// Stream pollers read through the same service, so pinned-block reads share its cache.
+	var streamHub *stream.Hub
+	if cfg.Liquidity.Stream.Enable {
+		streamHub = stream.NewHub(liquiditySvc, liquidityRepo, stream.Config{
+			Interval:   cfg.Liquidity.Stream.Interval,
+			TickRange:  cfg.Liquidity.Stream.TickRange,
+			BufferSize: cfg.Liquidity.Stream.BufferSize,
+			MaxPools:   cfg.Liquidity.Stream.MaxPools,
+			MaxClient:  cfg.Liquidity.Stream.MaxClient,
+		}, slog.Default()) //nolint:sloglint // no logger instance available at this scope
+	}
+
+	vaultEvents := vaultrepo.New(pool)
+
+	var vaultIndexer *vaultindexer.Indexer
+
+	if cfg.Vault.Indexer.Enable && ethClient != nil {
+		if !common.IsHexAddress(cfg.Vault.FactoryAddress) {
+			pool.Close()
+			_ = redisClient.Close()
+			ethClient.Close()
+
+			return nil, fmt.Errorf("invalid vault factory address: %q", cfg.Vault.FactoryAddress)
+		}
 
-	if !cfg.Ethereum.UseMock && cfg.Ethereum.RPCURL != "" {
-		ethClient, err = ethclient.Dial(cfg.Ethereum.RPCURL)
+		vaultIndexer, err = vaultindexer.New(
+			ethClient,
+			vaultindexer.NewChainConfigReader(ethClient),
+			vault.NewFactoryVaultSource(ethClient, common.HexToAddress(cfg.Vault.FactoryAddress)),
+			vaultEvents,
+			vaultindexer.Config{
+				StartBlock:    cfg.Vault.Indexer.StartBlock,
+				Confirmations: cfg.Vault.Indexer.Confirmations,
+				BlockRange:    cfg.Vault.Indexer.BlockRange,
+				Interval:      cfg.Vault.Indexer.Interval,
+			},
+			slog.Default(), //nolint:sloglint // no logger instance available at this scope
+		)
 		if err != nil {
 			pool.Close()
+			_ = redisClient.Close()
+			ethClient.Close()
 
-			if liquidityRepo != nil {
-				liquidityRepo.Close()
-			}
+			return nil, fmt.Errorf("create vault indexer: %w", err)
+		}
+	}
 
-			return nil, fmt.Errorf("dial ethereum: %w", err)
+	var jobs []job
+
+	// Only the vault indexer fills the owner index, so "my vaults" is served only while it runs.
+	var vaultOwners vault.OwnerIndex
+
+	if vaultIndexer != nil {
+		jobs = append(jobs, job{name: "vault indexer", run: vaultIndexer.Run})
+		vaultOwners = vaultEvents
+	}
+
+	if cfg.Pool.Indexer.Enable && ethClient != nil {
+		if !common.IsHexAddress(cfg.Pool.ManagerAddress) {
+			pool.Close()
+			_ = redisClient.Close()
+			ethClient.Close()
+
+			return nil, fmt.Errorf("invalid pool manager address: %q", cfg.Pool.ManagerAddress)
 		}
 
-		vaultFactory = func(addr common.Address) (vault.Vault, error) {
-			return vault.NewClient(addr, ethClient, nil)
+		poolIndexer := poolindexer.New(ethClient, poolRepo, tokenSvc, poolindexer.Config{
+			Config: logscan.Config{
+				StartBlock:    cfg.Pool.Indexer.StartBlock,
+				Confirmations: cfg.Pool.Indexer.Confirmations,
+				BlockRange:    cfg.Pool.Indexer.BlockRange,
+				Interval:      cfg.Pool.Indexer.Interval,
+			},
+			PoolManager: common.HexToAddress(cfg.Pool.ManagerAddress),
+		}, slog.Default()) //nolint:sloglint // no logger instance available at this scope
+
+		jobs = append(jobs, job{name: "pool indexer", run: poolIndexer.Run})
+	}
+
+	if cfg.Pool.Swaps.Indexer.Enable && ethClient != nil && len(swapPools) > 0 {
+		if !common.IsHexAddress(cfg.Pool.ManagerAddress) {
+			pool.Close()
+			_ = redisClient.Close()
+			ethClient.Close()
+
+			return nil, fmt.Errorf("invalid pool manager address: %q", cfg.Pool.ManagerAddress)
 		}
+
+		swapIndexer := volumeindexer.New(ethClient, volumeRepo, volumeindexer.Config{
+			Config: logscan.Config{
+				StartBlock:    cfg.Pool.Swaps.Indexer.StartBlock,
+				Confirmations: cfg.Pool.Swaps.Indexer.Confirmations,
+				BlockRange:    cfg.Pool.Swaps.Indexer.BlockRange,
+				Interval:      cfg.Pool.Swaps.Indexer.Interval,
+			},
+			PoolManager: common.HexToAddress(cfg.Pool.ManagerAddress),
+			Pools:       swapPools,
+		}, slog.Default()) //nolint:sloglint // no logger instance available at this scope
+
+		jobs = append(jobs, job{name: "swap indexer", run: swapIndexer.Run})
+	}
+
+	if cfg.Liquidity.Snapshot.Enable && ethClient != nil {
+		recorder, err := newSnapshotRecorder(cfg.Liquidity.Snapshot, ethClient, liquidityRepo, queries)
+		if err != nil {
+			pool.Close()
+			_ = redisClient.Close()
+			ethClient.Close()
+
+			return nil, fmt.Errorf("create liquidity snapshot recorder: %w", err)
+		}
+
+		jobs = append(jobs, job{name: "liquidity snapshot recorder", run: recorder.Run})
+	}
+
+	var limiter ratelimit.Limiter
+	if cfg.RateLimit.Enable {
+		limiter = ratelimit.NewFallbackLimiter(
+			ratelimit.NewRedisLimiter(redisClient, "remora:ratelimit:"),
+			ratelimit.NewMemoryLimiter(),
+			slog.Default(), //nolint:sloglint // no logger instance available at this scope
+		)
 	}
 
 	r := chi.NewRouter()
-	AddRoutes(r, cfg, userSvc, liquiditySvc, vaultFactory)
+	AddRoutes(r, cfg, RouteDeps{
+		Auth:            authSvc,
+		APIKeys:         apiKeySvc,
+		AnonymousScopes: anonymousScopes,
+		Limiter:         limiter,
+		Users:           userSvc,
+		Liquidity:       liquiditySvc,
+		Stream:          streamHub,
+		Pools:           poolSvc,
+		Volume:          volumeSvc,
+		VaultFactory:    vaultFactory,
+		VaultEvents:     vaultEvents,
+		VaultOwners:     vaultOwners,
+	})
 
 	return &Server{
 		config: cfg,
@@ -104,13 +324,26 @@ func NewServer(ctx context.Context, cfg *api.Config) (*Server, error) {
 			WriteTimeout: cfg.HTTP.WriteTimeout,
 			Handler:      r,
 		},
-		pool:          pool,
-		liquidityRepo: liquidityRepo,
-		ethClient:     ethClient,
+		pool:        pool,
+		redisClient: redisClient,
+		ethClient:   ethClient,
+		streamHub:   streamHub,
+		jobs:        jobs,
 	}, nil
 }
 
 func (s *Server) Start() func(context.Context) error {
+	var ctx context.Context
+
+	ctx, s.stopJobs = context.WithCancel(context.Background())
+
+	for _, j := range s.jobs {
+		s.jobsDone.Go(func() {
+			slog.Info("starting " + j.name)
+			j.run(ctx)
+		})
+	}
+
 	go func() {
 		slog.Info("starting http server", slog.String("addr", s.httpServer.Addr))
 
@@ -121,23 +354,83 @@ func (s *Server) Start() func(context.Context) error {
 	}()
 
 	return func(ctx context.Context) error {
-		if s.ethClient != nil {
-			s.ethClient.Close()
+		// Ends open streams so their connections do not hold up the HTTP shutdown.
+		if s.streamHub != nil {
+			s.streamHub.Close()
 		}
 
-		if s.liquidityRepo != nil {
-			s.liquidityRepo.Close()
+		s.stopJobs()
+		s.jobsDone.Wait()
+
+		if s.ethClient != nil {
+			s.ethClient.Close()
 		}
 
 		if s.pool != nil {
 			s.pool.Close()
 		}
 
+		if s.redisClient != nil {
+			_ = s.redisClient.Close()
+		}
+
 		return s.httpServer.Shutdown(ctx)
 	}
 }
 
-func newPgxPool(ctx context.Context, pg api.PostgreSQL) (*pgxpool.Pool, error) {
+// newSnapshotRecorder creates the liquidity snapshot recorder. It reads through an uncached
+// liquidity service without token metadata, since snapshots store neither bins nor prices.
+func newSnapshotRecorder(cfg api.LiquiditySnapshot, ethClient *ethclient.Client, liquidityRepo *liquidityrepo.Repository, queries *db.Queries) (*snapshot.Recorder, error) {
+	pools := make([]poolid.PoolKey, len(cfg.Pools))
+
+	for i, p := range cfg.Pools {
+		pools[i] = poolid.PoolKey{
+			Currency0:   p.Currency0,
+			Currency1:   p.Currency1,
+			Fee:         p.Fee,
+			TickSpacing: p.TickSpacing,
+			Hooks:       p.Hooks,
+		}
+
+		if err := poolid.ValidatePoolKey(&pools[i]); err != nil {
+			return nil, fmt.Errorf("pool %d: %w", i, err)
+		}
+	}
+
+	return snapshot.NewRecorder(
+		ethClient,
+		liquiditysvc.New(liquidityRepo, nil, nil),
+		snapshotrepo.New(queries),
+		snapshot.Config{
+			Pools:         pools,
+			EveryBlocks:   cfg.EveryBlocks,
+			TickRange:     cfg.TickRange,
+			Confirmations: cfg.Confirmations,
+			Interval:      cfg.Interval,
+			KeyframeEvery: cfg.KeyframeEvery,
+		},
+		slog.Default(), //nolint:sloglint // no logger instance available at this scope
+	), nil
+}
+
+// parsePoolIDs parses configured pool IDs.
+func parsePoolIDs(raw []string) ([]common.Hash, error) {
+	ids := make([]common.Hash, len(raw))
+
+	for i, s := range raw {
+		id, err := poolid.ParsePoolID(s)
+		if err != nil {
+			return nil, fmt.Errorf("pool %d: %q: %w", i, s, err)
+		}
+
+		ids[i] = id
+	}
+
+	return ids, nil
+}
+
+// NewPgxPool connects to PostgreSQL and pings it.
+func NewPgxPool(ctx context.Context, pg api.PostgreSQL) (*pgxpool.Pool, error) {
 	hostAndPort := net.JoinHostPort(pg.Host, pg.Port)
 	connectURI := fmt.Sprintf(
 		"postgres://%s:%s@%s/%s?sslmode=disable",
@@ -159,3 +452,23 @@ func newPgxPool(ctx context.Context, pg api.PostgreSQL) (*pgxpool.Pool, error) {
 
 	return pool, nil
 }
+
+// newRedisClient returns a client for cfg. Redis is optional: an unreachable server is logged,
+// and callers fall back to in-process behaviour until it comes back. Timeouts are short so an
+// outage does not stall requests.
+func newRedisClient(ctx context.Context, cfg api.Redis) *redis.Client {
+	client := redis.NewClient(&redis.Options{
+		Addr:         net.JoinHostPort(cfg.Host, cfg.Port),
+		Password:     cfg.Password,
+		DB:           cfg.DB,
+		DialTimeout:  redisDialTimeout,
+		ReadTimeout:  redisIOTimeout,
+		WriteTimeout: redisIOTimeout,
+	})
+
+	if err := client.Ping(ctx).Err(); err != nil {
+		slog.WarnContext(ctx, "redis unavailable", slog.Any("error", err)) //nolint:sloglint // no logger instance available at this scope
+	}
+
+	return client
+}
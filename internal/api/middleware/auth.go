@@ -20,18 +20,15 @@ const (
 	queryToken = "token"
 )
 
-type ctxKeyAuthUserID struct{}
+type (
+	ctxKeyAuthUserID    struct{}
+	ctxKeyAuthSessionID struct{}
+)
 
 func AuthMiddleware(authService auth.Service) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get(headerAuthorization)
-			token := strings.TrimPrefix(authHeader, bearerPrefix)
-
-			if token == "" {
-				token = r.URL.Query().Get(queryToken)
-			}
-
+			token := GetToken(r)
 			ctx := r.Context()
 
 			tokenInfo, err := authService.ValidateToken(ctx, token)
@@ -52,12 +49,23 @@ func AuthMiddleware(authService auth.Service) func(http.Handler) http.Handler {
 			}
 
 			ctx = SetUserID(ctx, tokenInfo.UserID)
+			ctx = SetSessionID(ctx, tokenInfo.ID)
 
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// GetToken returns the bearer token of r, falling back to the token query param.
+func GetToken(r *http.Request) string {
+	token := strings.TrimPrefix(r.Header.Get(headerAuthorization), bearerPrefix)
+	if token == "" {
+		token = r.URL.Query().Get(queryToken)
+	}
+
+	return token
+}
+
 func GetUserID(r *http.Request) uuid.UUID {
 	userID, _ := r.Context().Value(ctxKeyAuthUserID{}).(uuid.UUID)
 
@@ -67,3 +75,14 @@ func GetUserID(r *http.Request) uuid.UUID {
 func SetUserID(ctx context.Context, userID uuid.UUID) context.Context {
 	return context.WithValue(ctx, ctxKeyAuthUserID{}, userID)
 }
+
+// GetSessionID returns the ID of the session the request was authenticated with.
+func GetSessionID(r *http.Request) uuid.UUID {
+	sessionID, _ := r.Context().Value(ctxKeyAuthSessionID{}).(uuid.UUID)
+
+	return sessionID
+}
+
+func SetSessionID(ctx context.Context, sessionID uuid.UUID) context.Context {
+	return context.WithValue(ctx, ctxKeyAuthSessionID{}, sessionID)
+}
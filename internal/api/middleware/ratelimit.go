@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"remora/internal/httpwrap"
+	"remora/internal/ratelimit"
+)
+
+// RateLimit limits requests per route and caller using policy. It must run inside a chi group
+// (so the route pattern is known) and after authentication middleware, so callers are keyed by
+// API key or user ID and otherwise by client IP (as set by chimiddleware.RealIP).
+// Limiter errors fail open: the request is served and the error logged.
+func RateLimit(limiter ratelimit.Limiter, policy *ratelimit.Policy, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			pattern := chi.RouteContext(r.Context()).RoutePattern()
+			route := r.Method + " " + pattern
+
+			limit := policy.For(r.Method, pattern)
+			if !limit.Enabled() {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			res, err := limiter.Allow(r.Context(), route+"|"+Subject(r), limit)
+			if err != nil {
+				logger.ErrorContext(r.Context(), "rate limit failed", slog.String("route", route), slog.Any("error", err))
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			h := w.Header()
+			h.Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			h.Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			h.Set("RateLimit-Reset", ceilSeconds(res.Reset))
+			h.Set("RateLimit-Policy", strconv.Itoa(limit.Capacity())+";w="+ceilSeconds(limit.Period))
+
+			if !res.Allowed {
+				h.Set("Retry-After", ceilSeconds(res.RetryAfter))
+				httpwrap.NewTooManyRequestsError(nil).Render(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Subject identifies the caller for per-caller limits: API key, then wallet user, then client IP.
+func Subject(r *http.Request) string {
+	if principal, ok := GetPrincipal(r); ok && principal.APIKeyID != uuid.Nil {
+		return "key:" + principal.APIKeyID.String()
+	}
+
+	if userID := GetUserID(r); userID != uuid.Nil {
+		return "user:" + userID.String()
+	}
+
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	return "ip:" + ip
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.FormatInt(int64(math.Ceil(d.Seconds())), 10)
+}
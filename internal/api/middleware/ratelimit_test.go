@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/ratelimit"
+)
+
+func TestRateLimit(t *testing.T) {
+	t.Parallel()
+
+	policy := ratelimit.NewPolicy(ratelimit.Limit{}, map[string]ratelimit.Limit{
+		"GET /items/{id}": {Requests: 1, Period: time.Minute},
+	})
+
+	r := chi.NewRouter()
+	r.Group(func(r chi.Router) {
+		r.Use(RateLimit(ratelimit.NewMemoryLimiter(), policy, slog.New(slog.NewTextHandler(io.Discard, nil))))
+		r.Get("/items/{id}", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+		r.Get("/free", func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	})
+
+	do := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	rec := do("/items/1", "10.0.0.1:1234")
+	if rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Remaining") != "0" || rec.Header().Get("RateLimit-Limit") != "1" {
+		t.Fatalf("first request: code %d headers %v", rec.Code, rec.Header())
+	}
+
+	// The limit applies per route pattern, not per path.
+	rec = do("/items/2", "10.0.0.1:5678")
+	if rec.Code != http.StatusTooManyRequests || rec.Header().Get("Retry-After") != "60" {
+		t.Fatalf("second request: code %d headers %v", rec.Code, rec.Header())
+	}
+
+	if rec := do("/items/1", "10.0.0.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("other client: code %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	if rec := do("/free", "10.0.0.1:1234"); rec.Code != http.StatusOK || rec.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("unlimited route: code %d headers %v", rec.Code, rec.Header())
+	}
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"remora/internal/apikey"
+	"remora/internal/auth"
+	"remora/internal/httpwrap"
+)
+
+const headerAPIKey = "X-API-Key"
+
+// sessionScopes are granted to wallet sessions.
+var sessionScopes = []apikey.Scope{apikey.ScopeReadLiquidity, apikey.ScopeReadVaults} //nolint:gochecknoglobals // fixed list
+
+var errMissingScope = errors.New("missing scope")
+
+type ctxKeyPrincipal struct{}
+
+// Principal is the caller of a request and the scopes it holds.
+// At most one of UserID (wallet session) and APIKeyID is set; neither for anonymous callers.
+type Principal struct {
+	UserID   uuid.UUID
+	APIKeyID uuid.UUID
+	Scopes   []apikey.Scope
+}
+
+// Authenticate resolves the caller from an API key (X-API-Key header, or a bearer token with the
+// API key prefix) or a session token. Requests without credentials get anonymousScopes;
+// requests with invalid credentials are rejected rather than downgraded to anonymous.
+func Authenticate(authService auth.Service, keys apikey.Service, anonymousScopes []apikey.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			token := GetToken(r)
+
+			var principal Principal
+
+			switch raw := r.Header.Get(headerAPIKey); {
+			case raw != "" || strings.HasPrefix(token, apikey.KeyPrefix):
+				if raw == "" {
+					raw = token
+				}
+
+				key, err := keys.Authenticate(ctx, raw)
+				if err != nil {
+					renderAuthError(w, r, err, apikey.ErrInvalidKey)
+
+					return
+				}
+
+				principal = Principal{APIKeyID: key.ID, Scopes: key.Scopes}
+			case token != "":
+				tokenInfo, err := authService.ValidateToken(ctx, token)
+				if err != nil {
+					renderAuthError(w, r, err, auth.ErrTokenExpired, auth.ErrTokenNotFound)
+
+					return
+				}
+
+				principal = Principal{UserID: tokenInfo.UserID, Scopes: sessionScopes}
+				ctx = SetUserID(ctx, tokenInfo.UserID)
+				ctx = SetSessionID(ctx, tokenInfo.ID)
+			default:
+				principal = Principal{Scopes: anonymousScopes}
+			}
+
+			next.ServeHTTP(w, r.WithContext(SetPrincipal(ctx, principal)))
+		})
+	}
+}
+
+// RequireScopes rejects requests whose principal lacks any of scopes. It must run after Authenticate.
+func RequireScopes(scopes ...apikey.Scope) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := GetPrincipal(r)
+
+			for _, s := range scopes {
+				if ok && apikey.HasScope(principal.Scopes, s) {
+					continue
+				}
+
+				err := fmt.Errorf("%w: %s", errMissingScope, s)
+
+				// Anonymous callers may gain the scope by authenticating; authenticated ones cannot.
+				if !ok || (principal.UserID == uuid.Nil && principal.APIKeyID == uuid.Nil) {
+					httpwrap.NewUnauthorizedError(err).Render(w, r)
+
+					return
+				}
+
+				httpwrap.NewForbiddenError(err).Render(w, r)
+
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func renderAuthError(w http.ResponseWriter, r *http.Request, err error, unauthorized ...error) {
+	for _, target := range unauthorized {
+		if errors.Is(err, target) {
+			httpwrap.NewUnauthorizedError(err).Render(w, r)
+
+			return
+		}
+	}
+
+	httpwrap.NewInternalServerError(err).Render(w, r)
+}
+
+func GetPrincipal(r *http.Request) (Principal, bool) {
+	principal, ok := r.Context().Value(ctxKeyPrincipal{}).(Principal)
+
+	return principal, ok
+}
+
+func SetPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, ctxKeyPrincipal{}, principal)
+}
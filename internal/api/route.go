@@ -12,16 +12,44 @@ import (
 	"github.com/riandyrn/otelchi"
 
 	"remora/internal/api/middleware"
+	"remora/internal/apikey"
+	apikeyapi "remora/internal/apikey/api"
+	"remora/internal/auth"
+	authapi "remora/internal/auth/api"
 	apiconfig "remora/internal/config/api"
 	"remora/internal/liquidity"
 	liquidityapi "remora/internal/liquidity/api"
+	"remora/internal/liquidity/stream"
+	"remora/internal/pool"
+	poolapi "remora/internal/pool/api"
+	"remora/internal/ratelimit"
 	"remora/internal/user"
 	userapi "remora/internal/user/api"
+	"remora/internal/vault"
 	vaultapi "remora/internal/vault/api"
+	"remora/internal/volume"
+	volumeapi "remora/internal/volume/api"
 )
 
+// RouteDeps are the services AddRoutes serves. Limiter, Stream, VaultEvents and VaultOwners may
+// be nil; the routes needing them are then left out or answer as disabled.
+type RouteDeps struct {
+	Auth            auth.Service
+	APIKeys         apikey.Service
+	AnonymousScopes []apikey.Scope
+	Limiter         ratelimit.Limiter
+	Users           user.Service
+	Liquidity       liquidity.Service
+	Stream          *stream.Hub
+	Pools           pool.Service
+	Volume          volume.Service
+	VaultFactory    vaultapi.VaultFactory
+	VaultEvents     vault.EventLister
+	VaultOwners     vault.OwnerIndex
+}
+
 // AddRoutes registers API routes on the provided router (central routing).
-func AddRoutes(r chi.Router, cfg *apiconfig.Config, userSvc user.Service, liquiditySvc liquidity.Service, vaultFactory vaultapi.VaultFactory) {
+func AddRoutes(r chi.Router, cfg *apiconfig.Config, deps RouteDeps) {
 	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: parseLogLevel(cfg.Log.Level),
 	}))
@@ -43,10 +71,44 @@ func AddRoutes(r chi.Router, cfg *apiconfig.Config, userSvc user.Service, liquid
 		}))
 	}
 
+	// Rate limiting runs in each group after authentication so callers are keyed by credential.
+	rateLimit := func(next http.Handler) http.Handler { return next }
+	if deps.Limiter != nil {
+		rateLimit = middleware.RateLimit(deps.Limiter, newRateLimitPolicy(cfg.RateLimit), logger)
+	}
+
 	r.Route("/v1", func(r chi.Router) {
-		userapi.AddRoutes(r, userSvc)
-		liquidityapi.AddRoutes(r, liquiditySvc)
-		vaultapi.AddRoutes(r, vaultFactory, liquiditySvc)
+		r.Group(func(r chi.Router) {
+			r.Use(rateLimit)
+
+			authapi.AddRoutes(r, deps.Auth)
+			userapi.AddRoutes(r, deps.Users)
+		})
+
+		// Read routes accept API keys, sessions or anonymous callers, subject to per-group scopes.
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.Authenticate(deps.Auth, deps.APIKeys, deps.AnonymousScopes))
+			r.Use(rateLimit)
+
+			r.With(middleware.RequireScopes(apikey.ScopeReadLiquidity)).Group(func(r chi.Router) {
+				liquidityapi.AddRoutes(r, deps.Liquidity, deps.Stream)
+				poolapi.AddRoutes(r, deps.Pools)
+				volumeapi.AddRoutes(r, deps.Volume)
+			})
+			r.With(middleware.RequireScopes(apikey.ScopeReadVaults)).Group(func(r chi.Router) {
+				vaultapi.AddRoutes(r, deps.VaultFactory, deps.Liquidity, deps.VaultEvents)
+			})
+			r.With(middleware.RequireScopes(apikey.ScopeAdmin)).Group(func(r chi.Router) {
+				apikeyapi.AddRoutes(r, deps.APIKeys)
+			})
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.AuthMiddleware(deps.Auth))
+			r.Use(rateLimit)
+
+			vaultapi.AddOwnerRoutes(r, deps.Users, deps.VaultOwners, deps.VaultFactory, deps.Liquidity)
+		})
 	})
 
 	r.Get("/health", func(w http.ResponseWriter, _ *http.Request) {
@@ -54,6 +116,19 @@ func AddRoutes(r chi.Router, cfg *apiconfig.Config, userSvc user.Service, liquid
 	})
 }
 
+func newRateLimitPolicy(cfg apiconfig.RateLimit) *ratelimit.Policy {
+	routes := make(map[string]ratelimit.Limit, len(cfg.Routes))
+	for _, rule := range cfg.Routes {
+		routes[rule.Route] = newRateLimit(rule)
+	}
+
+	return ratelimit.NewPolicy(newRateLimit(cfg.Default), routes)
+}
+
+func newRateLimit(rule apiconfig.RateLimitRule) ratelimit.Limit {
+	return ratelimit.Limit{Requests: rule.Requests, Period: rule.Period, Burst: rule.Burst}
+}
+
 func parseLogLevel(level string) slog.Level {
 	switch level {
 	case "DEBUG":
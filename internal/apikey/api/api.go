@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"remora/internal/apikey"
+	"remora/internal/httpwrap"
+)
+
+// AddRoutes registers API key management routes. The caller must require the admin scope.
+func AddRoutes(r chi.Router, svc apikey.Service) {
+	r.Post("/admin/api-keys", httpwrap.Handler(postAPIKey(svc)))
+	r.Get("/admin/api-keys", httpwrap.Handler(listAPIKeys(svc)))
+	r.Delete("/admin/api-keys/{id}", httpwrap.Handler(deleteAPIKey(svc)))
+}
+
+// CreateAPIKeyRequest is the body of POST /admin/api-keys.
+type CreateAPIKeyRequest struct {
+	Name      string     `json:"name"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
+
+// CreateAPIKeyResponse returns the new key; Key is shown only once.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+
+	Key string `json:"key"`
+}
+
+// APIKeysResponse is the API response listing API keys.
+type APIKeysResponse struct {
+	APIKeys []APIKeyResponse `json:"apiKeys"`
+}
+
+// APIKeyResponse describes an API key without its secret.
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expiresAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+	RevokedAt  *time.Time `json:"revokedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// postAPIKey returns a handler that issues an API key.
+func postAPIKey(svc apikey.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		var req CreateAPIKeyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				ErrorMsg:   "invalid request body: " + err.Error(),
+				Err:        err,
+			}
+		}
+
+		scopes, err := apikey.ParseScopes(req.Scopes)
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("scopes")
+		}
+
+		if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+			return nil, httpwrap.NewInvalidParamErrorResponse("expiresAt")
+		}
+
+		key, raw, err := svc.Create(r.Context(), apikey.CreateParams{
+			Name:      req.Name,
+			Scopes:    scopes,
+			ExpiresAt: req.ExpiresAt,
+		})
+		if err != nil {
+			if errors.Is(err, apikey.ErrInvalidKey) || errors.Is(err, apikey.ErrInvalidScope) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusBadRequest,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			}
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "create api key failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusCreated,
+			Body: &CreateAPIKeyResponse{
+				APIKeyResponse: toAPIKeyResponse(key),
+				Key:            raw,
+			},
+		}, nil
+	}
+}
+
+// listAPIKeys returns a handler listing all API keys, newest first.
+func listAPIKeys(svc apikey.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		keys, err := svc.List(r.Context())
+		if err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "list api keys failed",
+				Err:        err,
+			}
+		}
+
+		resp := &APIKeysResponse{APIKeys: make([]APIKeyResponse, len(keys))}
+		for i := range keys {
+			resp.APIKeys[i] = toAPIKeyResponse(&keys[i])
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       resp,
+		}, nil
+	}
+}
+
+// deleteAPIKey returns a handler that revokes an API key.
+func deleteAPIKey(svc apikey.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("id")
+		}
+
+		if err := svc.Revoke(r.Context(), id); err != nil {
+			if errors.Is(err, apikey.ErrNotFound) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusNotFound,
+					ErrorMsg:   "not found",
+					Err:        err,
+				}
+			}
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "revoke api key failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusNoContent}, nil
+	}
+}
+
+func toAPIKeyResponse(k *apikey.APIKey) APIKeyResponse {
+	scopes := make([]string, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return APIKeyResponse{
+		ID:         k.ID,
+		Name:       k.Name,
+		Prefix:     k.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+		CreatedAt:  k.CreatedAt,
+	}
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"remora/internal/apikey"
+	"remora/internal/db"
+)
+
+type Repository struct {
+	q *db.Queries
+}
+
+var _ apikey.Repository = (*Repository)(nil)
+
+func New(q *db.Queries) *Repository {
+	return &Repository{q: q}
+}
+
+func (r *Repository) Create(ctx context.Context, key *apikey.APIKey, keyHash string) error {
+	scopes := make([]string, len(key.Scopes))
+	for i, s := range key.Scopes {
+		scopes[i] = string(s)
+	}
+
+	err := r.q.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+		ID:        key.ID,
+		Name:      key.Name,
+		Prefix:    key.Prefix,
+		KeyHash:   keyHash,
+		Scopes:    scopes,
+		ExpiresAt: key.ExpiresAt,
+		CreatedAt: key.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) GetByPrefix(ctx context.Context, prefix string) (*apikey.APIKey, string, error) {
+	row, err := r.q.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", apikey.ErrNotFound
+		}
+
+		return nil, "", fmt.Errorf("get api key: %w", err)
+	}
+
+	key := toDomain(row)
+
+	return &key, row.KeyHash, nil
+}
+
+func (r *Repository) List(ctx context.Context) ([]apikey.APIKey, error) {
+	rows, err := r.q.ListAPIKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+
+	keys := make([]apikey.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = toDomain(row)
+	}
+
+	return keys, nil
+}
+
+func (r *Repository) Revoke(ctx context.Context, id uuid.UUID, at time.Time) error {
+	n, err := r.q.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{ID: id, RevokedAt: &at})
+	if err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	if n == 0 {
+		return apikey.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *Repository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	err := r.q.TouchAPIKey(ctx, db.TouchAPIKeyParams{ID: id, LastUsedAt: &at})
+	if err != nil {
+		return fmt.Errorf("touch api key: %w", err)
+	}
+
+	return nil
+}
+
+func toDomain(row db.ApiKey) apikey.APIKey {
+	scopes := make([]apikey.Scope, len(row.Scopes))
+	for i, s := range row.Scopes {
+		scopes[i] = apikey.Scope(s)
+	}
+
+	return apikey.APIKey{
+		ID:         row.ID,
+		Name:       row.Name,
+		Prefix:     row.Prefix,
+		Scopes:     scopes,
+		ExpiresAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+		RevokedAt:  row.RevokedAt,
+		CreatedAt:  row.CreatedAt,
+	}
+}
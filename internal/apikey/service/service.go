@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"remora/internal/apikey"
+)
+
+const (
+	prefixIDLength = 4  // bytes, hex-encoded after apikey.KeyPrefix
+	secretLength   = 32 // bytes
+)
+
+type Service struct {
+	repo apikey.Repository
+	now  func() time.Time
+}
+
+var _ apikey.Service = (*Service)(nil)
+
+func New(repo apikey.Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Create issues a key of the form rmk_<prefix id>_<secret>.
+func (s *Service) Create(ctx context.Context, params apikey.CreateParams) (*apikey.APIKey, string, error) {
+	if strings.TrimSpace(params.Name) == "" {
+		return nil, "", fmt.Errorf("%w: name is required", apikey.ErrInvalidKey)
+	}
+
+	if len(params.Scopes) == 0 {
+		return nil, "", fmt.Errorf("%w: at least one scope is required", apikey.ErrInvalidScope)
+	}
+
+	prefixID, err := randomHex(prefixIDLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	secret, err := randomHex(secretLength)
+	if err != nil {
+		return nil, "", err
+	}
+
+	key := &apikey.APIKey{
+		ID:        uuid.New(),
+		Name:      params.Name,
+		Prefix:    apikey.KeyPrefix + prefixID,
+		Scopes:    params.Scopes,
+		ExpiresAt: params.ExpiresAt,
+		CreatedAt: s.now(),
+	}
+
+	raw := key.Prefix + "_" + secret
+
+	if err := s.repo.Create(ctx, key, hashKey(raw)); err != nil {
+		return nil, "", fmt.Errorf("create api key: %w", err)
+	}
+
+	return key, raw, nil
+}
+
+func (s *Service) Authenticate(ctx context.Context, raw string) (*apikey.APIKey, error) {
+	prefix, ok := keyPrefix(raw)
+	if !ok {
+		return nil, apikey.ErrInvalidKey
+	}
+
+	key, hash, err := s.repo.GetByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, apikey.ErrNotFound) {
+			return nil, apikey.ErrInvalidKey
+		}
+
+		return nil, fmt.Errorf("get api key: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hash), []byte(hashKey(raw))) != 1 {
+		return nil, apikey.ErrInvalidKey
+	}
+
+	now := s.now()
+	if !key.IsActive(now) {
+		return nil, apikey.ErrInvalidKey
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= apikey.DefaultTouchInterval {
+		if err := s.repo.TouchLastUsed(ctx, key.ID, now); err != nil {
+			return nil, fmt.Errorf("touch api key: %w", err)
+		}
+
+		key.LastUsedAt = &now
+	}
+
+	return key, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]apikey.APIKey, error) {
+	keys, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (s *Service) Revoke(ctx context.Context, id uuid.UUID) error {
+	if err := s.repo.Revoke(ctx, id, s.now()); err != nil {
+		return fmt.Errorf("revoke api key: %w", err)
+	}
+
+	return nil
+}
+
+// keyPrefix returns the rmk_<prefix id> part of a raw key.
+func keyPrefix(raw string) (string, bool) {
+	rest, ok := strings.CutPrefix(raw, apikey.KeyPrefix)
+	if !ok {
+		return "", false
+	}
+
+	id, secret, ok := strings.Cut(rest, "_")
+	if !ok || len(id) != 2*prefixIDLength || len(secret) != 2*secretLength {
+		return "", false
+	}
+
+	return apikey.KeyPrefix + id, true
+}
+
+func hashKey(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate random bytes: %w", err)
+	}
+
+	return hex.EncodeToString(b), nil
+}
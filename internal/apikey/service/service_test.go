@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"remora/internal/apikey"
+	"remora/internal/apikey/mocks"
+)
+
+func newTestService(ctrl *gomock.Controller, now time.Time) (*Service, *mocks.MockRepository) {
+	repo := mocks.NewMockRepository(ctrl)
+	svc := New(repo)
+	svc.now = func() time.Time { return now }
+
+	return svc, repo
+}
+
+// issueKey creates a key through the service and returns it with its raw value and stored hash.
+func issueKey(t *testing.T, svc *Service, repo *mocks.MockRepository) (*apikey.APIKey, string, string) {
+	t.Helper()
+
+	var hash string
+
+	repo.EXPECT().Create(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, _ *apikey.APIKey, h string) error {
+			hash = h
+
+			return nil
+		})
+
+	key, raw, err := svc.Create(t.Context(), apikey.CreateParams{
+		Name:   "quant",
+		Scopes: []apikey.Scope{apikey.ScopeReadLiquidity},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	return key, raw, hash
+}
+
+func TestService_Create(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc, repo := newTestService(ctrl, time.Now())
+
+	key, raw, hash := issueKey(t, svc, repo)
+
+	if !strings.HasPrefix(raw, key.Prefix+"_") {
+		t.Errorf("raw key %q does not start with prefix %q", raw, key.Prefix)
+	}
+
+	if hash != hashKey(raw) || strings.Contains(hash, raw) {
+		t.Errorf("stored hash %q is not the key hash", hash)
+	}
+
+	if _, _, err := svc.Create(t.Context(), apikey.CreateParams{Name: "x"}); !errors.Is(err, apikey.ErrInvalidScope) {
+		t.Errorf("Create() without scopes error = %v, want %v", err, apikey.ErrInvalidScope)
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	past := now.Add(-time.Hour)
+	recent := now.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		mutate    func(k *apikey.APIKey, raw string) string
+		wantTouch bool
+		wantErr   error
+	}{
+		{
+			name:      "valid key is touched",
+			mutate:    func(_ *apikey.APIKey, raw string) string { return raw },
+			wantTouch: true,
+		},
+		{
+			name: "recently used key is not written",
+			mutate: func(k *apikey.APIKey, raw string) string {
+				k.LastUsedAt = &recent
+
+				return raw
+			},
+		},
+		{
+			name: "wrong secret",
+			mutate: func(_ *apikey.APIKey, raw string) string {
+				if strings.HasSuffix(raw, "0") {
+					return strings.TrimSuffix(raw, "0") + "1"
+				}
+
+				return raw[:len(raw)-1] + "0"
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "revoked key",
+			mutate: func(k *apikey.APIKey, raw string) string {
+				k.RevokedAt = &past
+
+				return raw
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+		{
+			name: "expired key",
+			mutate: func(k *apikey.APIKey, raw string) string {
+				k.ExpiresAt = &past
+
+				return raw
+			},
+			wantErr: apikey.ErrInvalidKey,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			svc, repo := newTestService(ctrl, now)
+
+			key, raw, hash := issueKey(t, svc, repo)
+			raw = tt.mutate(key, raw)
+
+			repo.EXPECT().GetByPrefix(gomock.Any(), key.Prefix).Return(key, hash, nil)
+
+			if tt.wantTouch {
+				repo.EXPECT().TouchLastUsed(gomock.Any(), key.ID, now).Return(nil)
+			}
+
+			got, err := svc.Authenticate(t.Context(), raw)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if err == nil && got.ID != key.ID {
+				t.Errorf("Authenticate() id = %v, want %v", got.ID, key.ID)
+			}
+		})
+	}
+}
+
+func TestService_Authenticate_Malformed(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc, _ := newTestService(ctrl, time.Now())
+
+	for _, raw := range []string{"", "rmk_", "rmk_abcd_ef", "sk_0123456789abcdef"} {
+		if _, err := svc.Authenticate(t.Context(), raw); !errors.Is(err, apikey.ErrInvalidKey) {
+			t.Errorf("Authenticate(%q) error = %v, want %v", raw, err, apikey.ErrInvalidKey)
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"remora/internal/api/middleware"
+	"remora/internal/auth"
+	"remora/internal/httpwrap"
+)
+
+// AddRoutes registers Sign-In with Ethereum routes on the provided router.
+func AddRoutes(r chi.Router, svc auth.Service) {
+	r.Post("/auth/nonce", httpwrap.Handler(postNonce(svc)))
+	r.Post("/auth/login", httpwrap.Handler(postLogin(svc)))
+
+	r.Group(func(r chi.Router) {
+		r.Use(middleware.AuthMiddleware(svc))
+
+		r.Post("/auth/logout", httpwrap.Handler(postLogout(svc)))
+		r.Post("/auth/refresh", httpwrap.Handler(postRefresh(svc)))
+		r.Get("/auth/sessions", httpwrap.Handler(getSessions(svc)))
+		r.Delete("/auth/sessions/{id}", httpwrap.Handler(deleteSession(svc)))
+	})
+}
+
+// NonceResponse is the API response for a freshly issued SIWE nonce.
+type NonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// LoginRequest is the body of POST /auth/login.
+type LoginRequest struct {
+	// Message is the EIP-4361 message exactly as signed.
+	Message string `json:"message"`
+	// Signature is the 0x-prefixed 65-byte personal_sign signature.
+	Signature string `json:"signature"`
+	// Device is an optional client-chosen label shown in the session list.
+	Device string `json:"device"`
+}
+
+// LoginResponse is the API response for a successful login or refresh.
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+	UserID    uuid.UUID `json:"userId"`
+	SessionID uuid.UUID `json:"sessionId"`
+}
+
+// SessionsResponse is the API response listing the caller's active sessions.
+type SessionsResponse struct {
+	Sessions []SessionResponse `json:"sessions"`
+}
+
+// SessionResponse is a single session; the token itself is never returned.
+type SessionResponse struct {
+	ID         uuid.UUID `json:"id"`
+	Device     string    `json:"device"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	// Current is true for the session the request was made with.
+	Current bool `json:"current"`
+}
+
+// postNonce returns a handler that issues a single-use SIWE nonce.
+func postNonce(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		nonce, err := svc.IssueNonce(r.Context())
+		if err != nil {
+			slog.ErrorContext(r.Context(), "issue nonce failed", slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "issue nonce failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body: &NonceResponse{
+				Nonce:     nonce.Value,
+				ExpiresAt: nonce.ExpiredAt,
+			},
+		}, nil
+	}
+}
+
+// postLogin returns a handler that verifies a signed SIWE message and returns a session token.
+func postLogin(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		var req LoginRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				ErrorMsg:   "invalid request body: " + err.Error(),
+				Err:        err,
+			}
+		}
+
+		if req.Message == "" {
+			return nil, httpwrap.NewInvalidParamErrorResponse("message")
+		}
+
+		signature, err := hexutil.Decode(req.Signature)
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("signature")
+		}
+
+		client := auth.ClientInfo{Device: req.Device, UserAgent: r.UserAgent()}
+
+		token, err := svc.LoginWithSIWE(r.Context(), req.Message, signature, client)
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidMessage) ||
+				errors.Is(err, auth.ErrInvalidSignature) ||
+				errors.Is(err, auth.ErrInvalidNonce) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusUnauthorized,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			}
+
+			slog.ErrorContext(r.Context(), "siwe login failed", slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "login failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       toLoginResponse(token),
+		}, nil
+	}
+}
+
+// postLogout returns a handler that revokes the caller's token.
+func postLogout(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		if err := svc.Logout(r.Context(), middleware.GetToken(r)); err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "logout failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusNoContent}, nil
+	}
+}
+
+// postRefresh returns a handler that swaps the caller's token for a new one on the same session.
+func postRefresh(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		token, err := svc.Refresh(r.Context(), middleware.GetToken(r))
+		if err != nil {
+			if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrTokenExpired) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusUnauthorized,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			}
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "refresh failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       toLoginResponse(token),
+		}, nil
+	}
+}
+
+// getSessions returns a handler listing the caller's active sessions.
+func getSessions(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		sessions, err := svc.ListSessions(r.Context(), middleware.GetUserID(r))
+		if err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "list sessions failed",
+				Err:        err,
+			}
+		}
+
+		current := middleware.GetSessionID(r)
+		resp := &SessionsResponse{Sessions: make([]SessionResponse, len(sessions))}
+
+		for i, s := range sessions {
+			resp.Sessions[i] = SessionResponse{
+				ID:         s.ID,
+				Device:     s.Device,
+				UserAgent:  s.UserAgent,
+				CreatedAt:  s.CreatedAt,
+				LastUsedAt: s.LastUsedAt,
+				ExpiresAt:  s.ExpiredAt,
+				Current:    s.ID == current,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       resp,
+		}, nil
+	}
+}
+
+// deleteSession returns a handler that revokes one of the caller's sessions.
+func deleteSession(svc auth.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		id, err := uuid.Parse(chi.URLParam(r, "id"))
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("id")
+		}
+
+		if err := svc.RevokeSession(r.Context(), middleware.GetUserID(r), id); err != nil {
+			if errors.Is(err, auth.ErrSessionNotFound) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusNotFound,
+					ErrorMsg:   "not found",
+					Err:        err,
+				}
+			}
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "revoke session failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusNoContent}, nil
+	}
+}
+
+func toLoginResponse(t *auth.Token) *LoginResponse {
+	return &LoginResponse{
+		Token:     t.Token,
+		ExpiresAt: t.ExpiredAt,
+		UserID:    t.UserID,
+		SessionID: t.ID,
+	}
+}
@@ -2,29 +2,74 @@ package auth
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"time"
 
 	"github.com/google/uuid"
 )
 
 const (
+	// DefaultExpiredDurationInHours is the idle timeout: using a session pushes its expiry this far out.
 	DefaultExpiredDurationInHours = 24
-	DefaultTokenLength            = 32
+	// DefaultMaxSessionDurationInHours caps a session's lifetime regardless of activity or refreshes.
+	DefaultMaxSessionDurationInHours = 24 * 30
+	// DefaultTouchInterval throttles sliding-expiry writes to one per session per interval.
+	DefaultTouchInterval = 5 * time.Minute
+	DefaultTokenLength   = 32
+	DefaultNonceLength   = 16
+
+	MaxDeviceLength    = 128
+	MaxUserAgentLength = 512
 )
 
 type Service interface {
-	Login(ctx context.Context, userID uuid.UUID) (*Token, error)
+	Login(ctx context.Context, userID uuid.UUID, client ClientInfo) (*Token, error)
+	// ValidateToken resolves a bearer token to its session, sliding the session expiry forward.
 	ValidateToken(ctx context.Context, token string) (*Token, error)
+	// Refresh replaces token with a new one for the same session.
+	Refresh(ctx context.Context, token string) (*Token, error)
 	Logout(ctx context.Context, token string) error
+	// ListSessions returns the user's active sessions; Token is empty on each.
+	ListSessions(ctx context.Context, userID uuid.UUID) ([]Token, error)
+	RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error
+	// IssueNonce returns a single-use nonce to embed in a SIWE message.
+	IssueNonce(ctx context.Context) (*Nonce, error)
+	// LoginWithSIWE verifies a signed SIWE message, consumes its nonce and logs in the signer.
+	LoginWithSIWE(ctx context.Context, message string, signature []byte, client ClientInfo) (*Token, error)
+}
+
+// ClientInfo describes the client a session was opened from.
+type ClientInfo struct {
+	Device    string
+	UserAgent string
 }
 
+// Token is a session. Token holds the raw bearer token only when it was just issued or presented;
+// storage keeps its SHA-256 hash.
 type Token struct {
-	UserID    uuid.UUID
-	Token     string
-	CreatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	Token      string
+	Device     string
+	UserAgent  string
+	CreatedAt  time.Time
+	ExpiredAt  time.Time
+	LastUsedAt time.Time
+}
+
+type Nonce struct {
+	Value     string
 	ExpiredAt time.Time
 }
 
 func (t *Token) IsValid() bool {
 	return t.ExpiredAt.After(time.Now())
 }
+
+// HashToken returns the hex SHA-256 of a bearer token, the form it is stored and looked up in.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,11 @@ package auth
 import "errors"
 
 var (
-	ErrTokenExpired  = errors.New("token expired")
-	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenExpired    = errors.New("token expired")
+	ErrTokenNotFound   = errors.New("token not found")
+	ErrSessionNotFound = errors.New("session not found")
+
+	ErrInvalidMessage   = errors.New("invalid siwe message")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrInvalidNonce     = errors.New("invalid or used nonce")
 )
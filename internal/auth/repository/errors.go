@@ -2,4 +2,7 @@ package repository
 
 import "errors"
 
-var ErrTokenNotFound = errors.New("token not found")
+var (
+	ErrTokenNotFound = errors.New("token not found")
+	ErrNonceNotFound = errors.New("nonce not found")
+)
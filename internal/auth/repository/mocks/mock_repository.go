@@ -0,0 +1,172 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks Repository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	auth "remora/internal/auth"
+	repository "remora/internal/auth/repository"
+	time "time"
+
+	uuid "github.com/google/uuid"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepository is a mock of Repository interface.
+type MockRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepositoryMockRecorder
+}
+
+// MockRepositoryMockRecorder is the mock recorder for MockRepository.
+type MockRepositoryMockRecorder struct {
+	mock *MockRepository
+}
+
+// NewMockRepository creates a new mock instance.
+func NewMockRepository(ctrl *gomock.Controller) *MockRepository {
+	mock := &MockRepository{ctrl: ctrl}
+	mock.recorder = &MockRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
+	return m.recorder
+}
+
+// ConsumeNonce mocks base method.
+func (m *MockRepository) ConsumeNonce(ctx context.Context, nonce string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeNonce", ctx, nonce)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeNonce indicates an expected call of ConsumeNonce.
+func (mr *MockRepositoryMockRecorder) ConsumeNonce(ctx, nonce any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeNonce", reflect.TypeOf((*MockRepository)(nil).ConsumeNonce), ctx, nonce)
+}
+
+// CreateAuthToken mocks base method.
+func (m *MockRepository) CreateAuthToken(ctx context.Context, params *repository.CreateAuthTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuthToken", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuthToken indicates an expected call of CreateAuthToken.
+func (mr *MockRepositoryMockRecorder) CreateAuthToken(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuthToken", reflect.TypeOf((*MockRepository)(nil).CreateAuthToken), ctx, params)
+}
+
+// CreateNonce mocks base method.
+func (m *MockRepository) CreateNonce(ctx context.Context, nonce string, expiredAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNonce", ctx, nonce, expiredAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNonce indicates an expected call of CreateNonce.
+func (mr *MockRepositoryMockRecorder) CreateNonce(ctx, nonce, expiredAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNonce", reflect.TypeOf((*MockRepository)(nil).CreateNonce), ctx, nonce, expiredAt)
+}
+
+// DeleteAuthToken mocks base method.
+func (m *MockRepository) DeleteAuthToken(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthToken", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthToken indicates an expected call of DeleteAuthToken.
+func (mr *MockRepositoryMockRecorder) DeleteAuthToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthToken", reflect.TypeOf((*MockRepository)(nil).DeleteAuthToken), ctx, token)
+}
+
+// DeleteAuthTokenByID mocks base method.
+func (m *MockRepository) DeleteAuthTokenByID(ctx context.Context, userID, id uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuthTokenByID", ctx, userID, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuthTokenByID indicates an expected call of DeleteAuthTokenByID.
+func (mr *MockRepositoryMockRecorder) DeleteAuthTokenByID(ctx, userID, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuthTokenByID", reflect.TypeOf((*MockRepository)(nil).DeleteAuthTokenByID), ctx, userID, id)
+}
+
+// GetAuthToken mocks base method.
+func (m *MockRepository) GetAuthToken(ctx context.Context, token string) (*auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuthToken", ctx, token)
+	ret0, _ := ret[0].(*auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuthToken indicates an expected call of GetAuthToken.
+func (mr *MockRepositoryMockRecorder) GetAuthToken(ctx, token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuthToken", reflect.TypeOf((*MockRepository)(nil).GetAuthToken), ctx, token)
+}
+
+// ListAuthTokens mocks base method.
+func (m *MockRepository) ListAuthTokens(ctx context.Context, userID uuid.UUID) ([]auth.Token, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuthTokens", ctx, userID)
+	ret0, _ := ret[0].([]auth.Token)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuthTokens indicates an expected call of ListAuthTokens.
+func (mr *MockRepositoryMockRecorder) ListAuthTokens(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuthTokens", reflect.TypeOf((*MockRepository)(nil).ListAuthTokens), ctx, userID)
+}
+
+// RotateAuthToken mocks base method.
+func (m *MockRepository) RotateAuthToken(ctx context.Context, params *repository.RotateAuthTokenParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateAuthToken", ctx, params)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateAuthToken indicates an expected call of RotateAuthToken.
+func (mr *MockRepositoryMockRecorder) RotateAuthToken(ctx, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateAuthToken", reflect.TypeOf((*MockRepository)(nil).RotateAuthToken), ctx, params)
+}
+
+// TouchAuthToken mocks base method.
+func (m *MockRepository) TouchAuthToken(ctx context.Context, id uuid.UUID, expiredAt, usedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchAuthToken", ctx, id, expiredAt, usedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchAuthToken indicates an expected call of TouchAuthToken.
+func (mr *MockRepositoryMockRecorder) TouchAuthToken(ctx, id, expiredAt, usedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchAuthToken", reflect.TypeOf((*MockRepository)(nil).TouchAuthToken), ctx, id, expiredAt, usedAt)
+}
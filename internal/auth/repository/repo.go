@@ -24,19 +24,17 @@ func New(q *db.Queries) Repository {
 	return &repo{q: q}
 }
 
-func (r *repo) UpsertAuthToken(ctx context.Context, params *UpsertAuthTokenParams) error {
-	err := r.q.DeleteAuthTokensByUserID(ctx, params.UserID)
-	if err != nil {
-		return fmt.Errorf("delete existing auth tokens: %w", err)
-	}
-
-	err = r.q.CreateAuthToken(ctx, db.CreateAuthTokenParams{
-		ID:        uuid.New(),
-		UserID:    params.UserID,
-		TokenHash: params.Token,
-		ExpiresAt: params.ExpiredAt,
-		CreatedAt: params.CreatedAt,
-		UpdatedAt: params.CreatedAt,
+func (r *repo) CreateAuthToken(ctx context.Context, params *CreateAuthTokenParams) error {
+	err := r.q.CreateAuthToken(ctx, db.CreateAuthTokenParams{
+		ID:         params.ID,
+		UserID:     params.UserID,
+		TokenHash:  auth.HashToken(params.Token),
+		Device:     params.Device,
+		UserAgent:  params.UserAgent,
+		ExpiresAt:  params.ExpiredAt,
+		LastUsedAt: params.CreatedAt,
+		CreatedAt:  params.CreatedAt,
+		UpdatedAt:  params.CreatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("create auth token: %w", err)
@@ -47,7 +45,7 @@ func (r *repo) UpsertAuthToken(ctx context.Context, params *UpsertAuthTokenParam
 
 func (r *repo) GetAuthToken(ctx context.Context, token string) (*auth.Token, error) {
 	row, err := r.q.GetAuthTokenByHash(ctx, db.GetAuthTokenByHashParams{
-		TokenHash: token,
+		TokenHash: auth.HashToken(token),
 		ExpiresAt: time.Now(),
 	})
 	if err != nil {
@@ -58,16 +56,63 @@ func (r *repo) GetAuthToken(ctx context.Context, token string) (*auth.Token, err
 		return nil, fmt.Errorf("get auth token: %w", err)
 	}
 
-	return &auth.Token{
-		UserID:    row.UserID,
-		Token:     row.TokenHash,
-		CreatedAt: row.CreatedAt,
-		ExpiredAt: row.ExpiresAt,
-	}, nil
+	t := toDomainToken(row)
+	t.Token = token
+
+	return &t, nil
+}
+
+func (r *repo) ListAuthTokens(ctx context.Context, userID uuid.UUID) ([]auth.Token, error) {
+	rows, err := r.q.ListAuthTokensByUserID(ctx, db.ListAuthTokensByUserIDParams{
+		UserID:    userID,
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list auth tokens: %w", err)
+	}
+
+	tokens := make([]auth.Token, len(rows))
+	for i, row := range rows {
+		tokens[i] = toDomainToken(row)
+	}
+
+	return tokens, nil
+}
+
+func (r *repo) TouchAuthToken(ctx context.Context, id uuid.UUID, expiredAt, usedAt time.Time) error {
+	err := r.q.TouchAuthToken(ctx, db.TouchAuthTokenParams{
+		ID:         id,
+		ExpiresAt:  expiredAt,
+		LastUsedAt: usedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("touch auth token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) RotateAuthToken(ctx context.Context, params *RotateAuthTokenParams) error {
+	n, err := r.q.RotateAuthToken(ctx, db.RotateAuthTokenParams{
+		NewTokenHash: auth.HashToken(params.NewToken),
+		ExpiresAt:    params.ExpiredAt,
+		UpdatedAt:    params.UpdatedAt,
+		ID:           params.ID,
+		TokenHash:    auth.HashToken(params.Token),
+	})
+	if err != nil {
+		return fmt.Errorf("rotate auth token: %w", err)
+	}
+
+	if n == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
 }
 
 func (r *repo) DeleteAuthToken(ctx context.Context, token string) error {
-	err := r.q.DeleteAuthTokenByHash(ctx, token)
+	err := r.q.DeleteAuthTokenByHash(ctx, auth.HashToken(token))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil
@@ -78,3 +123,67 @@ func (r *repo) DeleteAuthToken(ctx context.Context, token string) error {
 
 	return nil
 }
+
+func (r *repo) DeleteAuthTokenByID(ctx context.Context, userID, id uuid.UUID) error {
+	n, err := r.q.DeleteAuthTokenByID(ctx, db.DeleteAuthTokenByIDParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete auth token by id: %w", err)
+	}
+
+	if n == 0 {
+		return ErrTokenNotFound
+	}
+
+	return nil
+}
+
+func (r *repo) CreateNonce(ctx context.Context, nonce string, expiredAt time.Time) error {
+	now := time.Now()
+
+	// Opportunistic cleanup keeps the table small without a separate job.
+	if err := r.q.DeleteExpiredAuthNonces(ctx, now); err != nil {
+		return fmt.Errorf("delete expired auth nonces: %w", err)
+	}
+
+	err := r.q.CreateAuthNonce(ctx, db.CreateAuthNonceParams{
+		Nonce:     nonce,
+		ExpiresAt: expiredAt,
+		CreatedAt: now,
+	})
+	if err != nil {
+		return fmt.Errorf("create auth nonce: %w", err)
+	}
+
+	return nil
+}
+
+func (r *repo) ConsumeNonce(ctx context.Context, nonce string) error {
+	_, err := r.q.ConsumeAuthNonce(ctx, db.ConsumeAuthNonceParams{
+		Nonce:     nonce,
+		ExpiresAt: time.Now(),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrNonceNotFound
+		}
+
+		return fmt.Errorf("consume auth nonce: %w", err)
+	}
+
+	return nil
+}
+
+func toDomainToken(row db.AuthToken) auth.Token {
+	return auth.Token{
+		ID:         row.ID,
+		UserID:     row.UserID,
+		Device:     row.Device,
+		UserAgent:  row.UserAgent,
+		CreatedAt:  row.CreatedAt,
+		ExpiredAt:  row.ExpiresAt,
+		LastUsedAt: row.LastUsedAt,
+	}
+}
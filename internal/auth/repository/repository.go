@@ -1,5 +1,7 @@
 package repository
 
+//go:generate mockgen -source=repository.go -destination=mocks/mock_repository.go -package=mocks Repository
+
 import (
 	"context"
 	"time"
@@ -9,15 +11,36 @@ import (
 	"remora/internal/auth"
 )
 
+// Repository persists sessions. Raw tokens are passed in and hashed before they reach storage.
 type Repository interface {
-	UpsertAuthToken(ctx context.Context, params *UpsertAuthTokenParams) error
+	CreateAuthToken(ctx context.Context, params *CreateAuthTokenParams) error
 	GetAuthToken(ctx context.Context, token string) (*auth.Token, error)
+	ListAuthTokens(ctx context.Context, userID uuid.UUID) ([]auth.Token, error)
+	TouchAuthToken(ctx context.Context, id uuid.UUID, expiredAt, usedAt time.Time) error
+	// RotateAuthToken swaps the session's token; ErrTokenNotFound when params.Token is no longer current.
+	RotateAuthToken(ctx context.Context, params *RotateAuthTokenParams) error
 	DeleteAuthToken(ctx context.Context, token string) error
+	// DeleteAuthTokenByID deletes one of the user's sessions; ErrTokenNotFound when it does not exist.
+	DeleteAuthTokenByID(ctx context.Context, userID, id uuid.UUID) error
+	CreateNonce(ctx context.Context, nonce string, expiredAt time.Time) error
+	// ConsumeNonce deletes an unexpired nonce; ErrNonceNotFound when it is unknown, expired or already used.
+	ConsumeNonce(ctx context.Context, nonce string) error
 }
 
-type UpsertAuthTokenParams struct {
+type CreateAuthTokenParams struct {
+	ID        uuid.UUID
 	UserID    uuid.UUID
 	Token     string
+	Device    string
+	UserAgent string
 	CreatedAt time.Time
 	ExpiredAt time.Time
 }
+
+type RotateAuthTokenParams struct {
+	ID        uuid.UUID
+	Token     string
+	NewToken  string
+	UpdatedAt time.Time
+	ExpiredAt time.Time
+}
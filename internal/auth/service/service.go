@@ -6,46 +6,65 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"remora/internal/auth"
 	"remora/internal/auth/repository"
+	"remora/internal/user"
 )
 
 type Service struct {
-	repo repository.Repository
+	repo  repository.Repository
+	users user.Service
+	siwe  auth.SIWEConfig
+	now   func() time.Time
 }
 
 var _ auth.Service = (*Service)(nil)
 
-func New(repo repository.Repository) *Service {
+func New(repo repository.Repository, users user.Service, siwe auth.SIWEConfig) *Service {
 	return &Service{
-		repo: repo,
+		repo:  repo,
+		users: users,
+		siwe:  siwe,
+		now:   time.Now,
 	}
 }
 
-func (s *Service) Login(ctx context.Context, userID uuid.UUID) (*auth.Token, error) {
-	now := time.Now()
+func (s *Service) Login(ctx context.Context, userID uuid.UUID, client auth.ClientInfo) (*auth.Token, error) {
+	now := s.now()
 
 	token := &auth.Token{
-		UserID:    userID,
-		Token:     generateToken(auth.DefaultTokenLength),
-		CreatedAt: now,
-		ExpiredAt: now.Add(time.Hour * auth.DefaultExpiredDurationInHours),
+		ID:         uuid.New(),
+		UserID:     userID,
+		Token:      generateToken(auth.DefaultTokenLength),
+		Device:     truncate(client.Device, auth.MaxDeviceLength),
+		UserAgent:  truncate(client.UserAgent, auth.MaxUserAgentLength),
+		CreatedAt:  now,
+		ExpiredAt:  now.Add(time.Hour * auth.DefaultExpiredDurationInHours),
+		LastUsedAt: now,
 	}
 
-	params := &repository.UpsertAuthTokenParams{
+	if token.Token == "" {
+		return nil, errors.New("generate token: empty")
+	}
+
+	params := &repository.CreateAuthTokenParams{
+		ID:        token.ID,
 		UserID:    token.UserID,
 		Token:     token.Token,
+		Device:    token.Device,
+		UserAgent: token.UserAgent,
 		CreatedAt: token.CreatedAt,
 		ExpiredAt: token.ExpiredAt,
 	}
 
-	err := s.repo.UpsertAuthToken(ctx, params)
+	err := s.repo.CreateAuthToken(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("upsert auth token: %w", err)
+		return nil, fmt.Errorf("create auth token: %w", err)
 	}
 
 	return token, nil
@@ -61,10 +80,61 @@ func (s *Service) ValidateToken(ctx context.Context, token string) (*auth.Token,
 		return nil, fmt.Errorf("get auth token: %w", err)
 	}
 
-	if !t.IsValid() {
+	now := s.now()
+	if !t.ExpiredAt.After(now) {
 		return nil, auth.ErrTokenExpired
 	}
 
+	// Sliding expiry, written at most once per touch interval to keep reads cheap.
+	if now.Sub(t.LastUsedAt) >= auth.DefaultTouchInterval {
+		expiredAt := expiry(t, now)
+
+		if err := s.repo.TouchAuthToken(ctx, t.ID, expiredAt, now); err != nil {
+			return nil, fmt.Errorf("touch auth token: %w", err)
+		}
+
+		t.ExpiredAt = expiredAt
+		t.LastUsedAt = now
+	}
+
+	return t, nil
+}
+
+func (s *Service) Refresh(ctx context.Context, token string) (*auth.Token, error) {
+	t, err := s.ValidateToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+
+	next := generateToken(auth.DefaultTokenLength)
+	if next == "" {
+		return nil, errors.New("generate token: empty")
+	}
+
+	expiredAt := expiry(t, now)
+
+	err = s.repo.RotateAuthToken(ctx, &repository.RotateAuthTokenParams{
+		ID:        t.ID,
+		Token:     token,
+		NewToken:  next,
+		UpdatedAt: now,
+		ExpiredAt: expiredAt,
+	})
+	if err != nil {
+		// A concurrent refresh already rotated the token.
+		if errors.Is(err, repository.ErrTokenNotFound) {
+			return nil, auth.ErrTokenNotFound
+		}
+
+		return nil, fmt.Errorf("rotate auth token: %w", err)
+	}
+
+	t.Token = next
+	t.ExpiredAt = expiredAt
+	t.LastUsedAt = now
+
 	return t, nil
 }
 
@@ -77,6 +147,76 @@ func (s *Service) Logout(ctx context.Context, token string) error {
 	return nil
 }
 
+func (s *Service) ListSessions(ctx context.Context, userID uuid.UUID) ([]auth.Token, error) {
+	tokens, err := s.repo.ListAuthTokens(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list auth tokens: %w", err)
+	}
+
+	return tokens, nil
+}
+
+func (s *Service) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	err := s.repo.DeleteAuthTokenByID(ctx, userID, sessionID)
+	if err != nil {
+		if errors.Is(err, repository.ErrTokenNotFound) {
+			return auth.ErrSessionNotFound
+		}
+
+		return fmt.Errorf("delete auth token by id: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) IssueNonce(ctx context.Context) (*auth.Nonce, error) {
+	nonce := &auth.Nonce{
+		Value:     generateToken(auth.DefaultNonceLength),
+		ExpiredAt: s.now().Add(s.siwe.NonceTTL),
+	}
+
+	if nonce.Value == "" {
+		return nil, errors.New("generate nonce: empty")
+	}
+
+	if err := s.repo.CreateNonce(ctx, nonce.Value, nonce.ExpiredAt); err != nil {
+		return nil, fmt.Errorf("create nonce: %w", err)
+	}
+
+	return nonce, nil
+}
+
+func (s *Service) LoginWithSIWE(ctx context.Context, message string, signature []byte, client auth.ClientInfo) (*auth.Token, error) {
+	msg, err := auth.ParseSIWEMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := msg.Validate(s.siwe, s.now()); err != nil {
+		return nil, err
+	}
+
+	if err := auth.VerifySIWESignature(message, signature, msg.Address); err != nil {
+		return nil, err
+	}
+
+	// Consumed only after the signature checks out, so a forged message cannot burn a nonce.
+	if err := s.repo.ConsumeNonce(ctx, msg.Nonce); err != nil {
+		if errors.Is(err, repository.ErrNonceNotFound) {
+			return nil, auth.ErrInvalidNonce
+		}
+
+		return nil, fmt.Errorf("consume nonce: %w", err)
+	}
+
+	u, err := s.users.UpsertByAddress(ctx, msg.Address.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("upsert user: %w", err)
+	}
+
+	return s.Login(ctx, u.ID, client)
+}
+
 func generateToken(length int) string {
 	b := make([]byte, length)
 
@@ -87,3 +227,23 @@ func generateToken(length int) string {
 
 	return hex.EncodeToString(b)
 }
+
+// expiry slides the session's expiry to the idle timeout from now, capped at its maximum lifetime.
+func expiry(t *auth.Token, now time.Time) time.Time {
+	idle := now.Add(time.Hour * auth.DefaultExpiredDurationInHours)
+	limit := t.CreatedAt.Add(time.Hour * auth.DefaultMaxSessionDurationInHours)
+
+	if idle.After(limit) {
+		return limit
+	}
+
+	return idle
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return strings.ToValidUTF8(s[:n], "")
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/mock/gomock"
+
+	"remora/internal/auth"
+	"remora/internal/auth/repository"
+	"remora/internal/auth/repository/mocks"
+)
+
+func newTestService(ctrl *gomock.Controller, now time.Time) (*Service, *mocks.MockRepository) {
+	repo := mocks.NewMockRepository(ctrl)
+	svc := New(repo, nil, auth.SIWEConfig{})
+	svc.now = func() time.Time { return now }
+
+	return svc, repo
+}
+
+func TestService_ValidateToken_SlidingExpiry(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	created := now.Add(-2 * time.Hour)
+	idle := now.Add(auth.DefaultExpiredDurationInHours * time.Hour)
+
+	tests := []struct {
+		name      string
+		token     auth.Token
+		wantTouch bool
+		want      time.Time
+		wantErr   error
+	}{
+		{
+			name:      "stale session is extended",
+			token:     auth.Token{CreatedAt: created, LastUsedAt: now.Add(-time.Hour), ExpiredAt: now.Add(time.Hour)},
+			wantTouch: true,
+			want:      idle,
+		},
+		{
+			name:  "recently used session is not written",
+			token: auth.Token{CreatedAt: created, LastUsedAt: now.Add(-time.Minute), ExpiredAt: now.Add(time.Hour)},
+			want:  now.Add(time.Hour),
+		},
+		{
+			name: "extension is capped at the max lifetime",
+			token: auth.Token{
+				CreatedAt:  now.Add(-auth.DefaultMaxSessionDurationInHours*time.Hour + time.Hour),
+				LastUsedAt: now.Add(-time.Hour),
+				ExpiredAt:  now.Add(time.Minute),
+			},
+			wantTouch: true,
+			want:      now.Add(time.Hour),
+		},
+		{
+			name:    "expired session is rejected",
+			token:   auth.Token{CreatedAt: created, LastUsedAt: now.Add(-time.Hour), ExpiredAt: now},
+			wantErr: auth.ErrTokenExpired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctrl := gomock.NewController(t)
+			svc, repo := newTestService(ctrl, now)
+
+			stored := tt.token
+			stored.ID = uuid.New()
+			repo.EXPECT().GetAuthToken(gomock.Any(), "raw").Return(&stored, nil)
+
+			if tt.wantTouch {
+				repo.EXPECT().TouchAuthToken(gomock.Any(), stored.ID, tt.want, now).Return(nil)
+			}
+
+			got, err := svc.ValidateToken(t.Context(), "raw")
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("ValidateToken() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ValidateToken() failed: %v", err)
+			}
+
+			if !got.ExpiredAt.Equal(tt.want) {
+				t.Errorf("ExpiredAt = %v, want %v", got.ExpiredAt, tt.want)
+			}
+		})
+	}
+}
+
+func TestService_Refresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().Truncate(time.Second)
+	ctrl := gomock.NewController(t)
+	svc, repo := newTestService(ctrl, now)
+
+	stored := &auth.Token{ID: uuid.New(), CreatedAt: now, LastUsedAt: now, ExpiredAt: now.Add(time.Hour)}
+	repo.EXPECT().GetAuthToken(gomock.Any(), "old").Return(stored, nil)
+	repo.EXPECT().
+		RotateAuthToken(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, p *repository.RotateAuthTokenParams) error {
+			if p.ID != stored.ID || p.Token != "old" || p.NewToken == "" || p.NewToken == "old" {
+				t.Errorf("unexpected rotate params: %+v", p)
+			}
+
+			return nil
+		})
+
+	got, err := svc.Refresh(t.Context(), "old")
+	if err != nil {
+		t.Fatalf("Refresh() failed: %v", err)
+	}
+
+	if got.ID != stored.ID || got.Token == "old" {
+		t.Errorf("expected new token on the same session, got %+v", got)
+	}
+}
+
+func TestService_RevokeSession_NotFound(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc, repo := newTestService(ctrl, time.Now())
+
+	userID, sessionID := uuid.New(), uuid.New()
+	repo.EXPECT().DeleteAuthTokenByID(gomock.Any(), userID, sessionID).Return(repository.ErrTokenNotFound)
+
+	if err := svc.RevokeSession(t.Context(), userID, sessionID); !errors.Is(err, auth.ErrSessionNotFound) {
+		t.Errorf("RevokeSession() error = %v, want %v", err, auth.ErrSessionNotFound)
+	}
+}
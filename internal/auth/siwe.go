@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	siweHeaderSuffix = " wants you to sign in with your Ethereum account:"
+	siweVersion      = "1"
+
+	signatureLength = 65
+)
+
+// SIWEConfig is what a Sign-In with Ethereum message must be bound to.
+type SIWEConfig struct {
+	// Domain is the RFC 3986 authority the frontend is served from.
+	Domain string
+	// URI, when set, must match the message URI exactly.
+	URI     string
+	ChainID int64
+	// NonceTTL bounds how long an issued nonce can be used.
+	NonceTTL time.Duration
+}
+
+// SIWEMessage is a parsed EIP-4361 message.
+type SIWEMessage struct {
+	Domain         string
+	Address        common.Address
+	Statement      string
+	URI            string
+	Version        string
+	ChainID        int64
+	Nonce          string
+	IssuedAt       time.Time
+	ExpirationTime *time.Time
+	NotBefore      *time.Time
+	RequestID      string
+	Resources      []string
+}
+
+// ParseSIWEMessage parses an EIP-4361 message.
+func ParseSIWEMessage(raw string) (*SIWEMessage, error) {
+	lines := strings.Split(strings.ReplaceAll(raw, "\r\n", "\n"), "\n")
+
+	const minLines = 8
+	if len(lines) < minLines {
+		return nil, fmt.Errorf("%w: too short", ErrInvalidMessage)
+	}
+
+	domain, ok := strings.CutSuffix(lines[0], siweHeaderSuffix)
+	if !ok || domain == "" {
+		return nil, fmt.Errorf("%w: bad header", ErrInvalidMessage)
+	}
+
+	// The address must be EIP-55 checksummed.
+	if !common.IsHexAddress(lines[1]) || common.HexToAddress(lines[1]).Hex() != lines[1] {
+		return nil, fmt.Errorf("%w: bad address", ErrInvalidMessage)
+	}
+
+	msg := &SIWEMessage{
+		Domain:  domain,
+		Address: common.HexToAddress(lines[1]),
+	}
+
+	// Between the address and the URI field: blank lines around an optional statement.
+	i := 2
+	for ; i < len(lines) && !strings.HasPrefix(lines[i], "URI: "); i++ {
+		if lines[i] == "" {
+			continue
+		}
+
+		if msg.Statement != "" {
+			return nil, fmt.Errorf("%w: multi-line statement", ErrInvalidMessage)
+		}
+
+		msg.Statement = lines[i]
+	}
+
+	fields := make(map[string]string)
+
+	for ; i < len(lines); i++ {
+		line := lines[i]
+		if line == "" {
+			continue
+		}
+
+		if line == "Resources:" {
+			for _, r := range lines[i+1:] {
+				res, ok := strings.CutPrefix(r, "- ")
+				if !ok {
+					return nil, fmt.Errorf("%w: bad resource %q", ErrInvalidMessage, r)
+				}
+
+				msg.Resources = append(msg.Resources, res)
+			}
+
+			break
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, fmt.Errorf("%w: bad line %q", ErrInvalidMessage, line)
+		}
+
+		if _, dup := fields[key]; dup {
+			return nil, fmt.Errorf("%w: duplicate field %q", ErrInvalidMessage, key)
+		}
+
+		fields[key] = value
+	}
+
+	if err := msg.setFields(fields); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (m *SIWEMessage) setFields(fields map[string]string) error {
+	required := func(key string) (string, error) {
+		v, ok := fields[key]
+		if !ok || v == "" {
+			return "", fmt.Errorf("%w: missing %s", ErrInvalidMessage, key)
+		}
+
+		return v, nil
+	}
+
+	var err error
+
+	if m.URI, err = required("URI"); err != nil {
+		return err
+	}
+
+	if m.Version, err = required("Version"); err != nil {
+		return err
+	}
+
+	if m.Nonce, err = required("Nonce"); err != nil {
+		return err
+	}
+
+	chainID, err := required("Chain ID")
+	if err != nil {
+		return err
+	}
+
+	if m.ChainID, err = strconv.ParseInt(chainID, 10, 64); err != nil {
+		return fmt.Errorf("%w: bad chain id", ErrInvalidMessage)
+	}
+
+	issuedAt, err := required("Issued At")
+	if err != nil {
+		return err
+	}
+
+	if m.IssuedAt, err = time.Parse(time.RFC3339, issuedAt); err != nil {
+		return fmt.Errorf("%w: bad issued at", ErrInvalidMessage)
+	}
+
+	if m.ExpirationTime, err = optionalTime(fields, "Expiration Time"); err != nil {
+		return err
+	}
+
+	if m.NotBefore, err = optionalTime(fields, "Not Before"); err != nil {
+		return err
+	}
+
+	m.RequestID = fields["Request ID"]
+
+	return nil
+}
+
+func optionalTime(fields map[string]string, key string) (*time.Time, error) {
+	v, ok := fields[key]
+	if !ok {
+		return nil, nil //nolint:nilnil // absent optional field
+	}
+
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad %s", ErrInvalidMessage, strings.ToLower(key))
+	}
+
+	return &t, nil
+}
+
+// Validate checks the message is bound to cfg and usable at now.
+func (m *SIWEMessage) Validate(cfg SIWEConfig, now time.Time) error {
+	switch {
+	case m.Domain != cfg.Domain:
+		return fmt.Errorf("%w: domain %q", ErrInvalidMessage, m.Domain)
+	case cfg.URI != "" && m.URI != cfg.URI:
+		return fmt.Errorf("%w: uri %q", ErrInvalidMessage, m.URI)
+	case m.ChainID != cfg.ChainID:
+		return fmt.Errorf("%w: chain id %d", ErrInvalidMessage, m.ChainID)
+	case m.Version != siweVersion:
+		return fmt.Errorf("%w: version %q", ErrInvalidMessage, m.Version)
+	case m.ExpirationTime != nil && !now.Before(*m.ExpirationTime):
+		return fmt.Errorf("%w: expired", ErrInvalidMessage)
+	case m.NotBefore != nil && now.Before(*m.NotBefore):
+		return fmt.Errorf("%w: not yet valid", ErrInvalidMessage)
+	}
+
+	return nil
+}
+
+// VerifySIWESignature checks that signature is an EIP-191 personal_sign of raw by address.
+func VerifySIWESignature(raw string, signature []byte, address common.Address) error {
+	if len(signature) != signatureLength {
+		return fmt.Errorf("%w: length %d", ErrInvalidSignature, len(signature))
+	}
+
+	sig := make([]byte, signatureLength)
+	copy(sig, signature)
+
+	// Wallets produce v in {27, 28}; Ecrecover expects {0, 1}.
+	if sig[crypto.RecoveryIDOffset] >= 27 { //nolint:mnd // legacy v offset
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pub, err := crypto.SigToPub(accounts.TextHash([]byte(raw)), sig)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	if crypto.PubkeyToAddress(*pub) != address {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"remora/internal/auth"
+)
+
+var testSIWEConfig = auth.SIWEConfig{
+	Domain:  "app.remora.xyz",
+	URI:     "https://app.remora.xyz",
+	ChainID: 1,
+}
+
+func siweMessage(addr common.Address, statement, extra string) string {
+	lines := []string{
+		"app.remora.xyz wants you to sign in with your Ethereum account:",
+		addr.Hex(),
+		"",
+	}
+
+	if statement != "" {
+		lines = append(lines, statement)
+	}
+
+	lines = append(lines,
+		"",
+		"URI: https://app.remora.xyz",
+		"Version: 1",
+		"Chain ID: 1",
+		"Nonce: 32891756",
+		"Issued At: 2026-02-14T09:00:00Z",
+	)
+
+	if extra != "" {
+		lines = append(lines, extra)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func TestParseSIWEMessage(t *testing.T) {
+	t.Parallel()
+
+	addr := common.HexToAddress("0x52908400098527886E0F7030069857D2E4169EE7")
+
+	msg, err := auth.ParseSIWEMessage(siweMessage(addr, "Sign in to Remora.",
+		"Expiration Time: 2026-02-14T10:00:00Z\nResources:\n- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq"))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+
+	if msg.Domain != "app.remora.xyz" || msg.Address != addr || msg.Statement != "Sign in to Remora." {
+		t.Errorf("unexpected header fields: %+v", msg)
+	}
+
+	if msg.Nonce != "32891756" || msg.ChainID != 1 || msg.ExpirationTime == nil || len(msg.Resources) != 1 {
+		t.Errorf("unexpected fields: %+v", msg)
+	}
+
+	if _, err := auth.ParseSIWEMessage(siweMessage(addr, "", "")); err != nil {
+		t.Errorf("message without statement: %v", err)
+	}
+
+	lower := strings.Replace(siweMessage(addr, "", ""), addr.Hex(), strings.ToLower(addr.Hex()), 1)
+	if _, err := auth.ParseSIWEMessage(lower); !errors.Is(err, auth.ErrInvalidMessage) {
+		t.Errorf("expected non-checksummed address to be rejected, got %v", err)
+	}
+
+	noNonce := strings.Replace(siweMessage(addr, "", ""), "Nonce: 32891756\n", "", 1)
+	if _, err := auth.ParseSIWEMessage(noNonce); !errors.Is(err, auth.ErrInvalidMessage) {
+		t.Errorf("expected missing nonce to be rejected, got %v", err)
+	}
+}
+
+func TestSIWEMessage_Validate(t *testing.T) {
+	t.Parallel()
+
+	addr := common.HexToAddress("0x52908400098527886E0F7030069857D2E4169EE7")
+	now := time.Date(2026, 2, 14, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		cfg     auth.SIWEConfig
+		extra   string
+		wantErr bool
+	}{
+		{name: "valid", cfg: testSIWEConfig},
+		{name: "wrong domain", cfg: auth.SIWEConfig{Domain: "evil.xyz", ChainID: 1}, wantErr: true},
+		{name: "wrong chain", cfg: auth.SIWEConfig{Domain: "app.remora.xyz", ChainID: 10}, wantErr: true},
+		{name: "expired", cfg: testSIWEConfig, extra: "Expiration Time: 2026-02-14T09:10:00Z", wantErr: true},
+		{name: "not before", cfg: testSIWEConfig, extra: "Not Before: 2026-02-14T10:00:00Z", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			msg, err := auth.ParseSIWEMessage(siweMessage(addr, "", tt.extra))
+			if err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+
+			err = msg.Validate(tt.cfg, now)
+			if tt.wantErr != (err != nil) {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifySIWESignature(t *testing.T) {
+	t.Parallel()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+	raw := siweMessage(addr, "Sign in to Remora.", "")
+
+	sig, err := crypto.Sign(accounts.TextHash([]byte(raw)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Wallets return v as 27/28.
+	sig[crypto.RecoveryIDOffset] += 27
+
+	if err := auth.VerifySIWESignature(raw, sig, addr); err != nil {
+		t.Errorf("valid signature rejected: %v", err)
+	}
+
+	other := common.HexToAddress("0x52908400098527886E0F7030069857D2E4169EE7")
+	if err := auth.VerifySIWESignature(raw, sig, other); !errors.Is(err, auth.ErrInvalidSignature) {
+		t.Errorf("expected signer mismatch, got %v", err)
+	}
+
+	tampered := strings.Replace(raw, "Chain ID: 1", "Chain ID: 5", 1)
+	if err := auth.VerifySIWESignature(tampered, sig, addr); !errors.Is(err, auth.ErrInvalidSignature) {
+		t.Errorf("expected tampered message to be rejected, got %v", err)
+	}
+
+	if err := auth.VerifySIWESignature(raw, sig[:64], addr); !errors.Is(err, auth.ErrInvalidSignature) {
+		t.Errorf("expected short signature to be rejected, got %v", err)
+	}
+}
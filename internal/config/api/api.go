@@ -25,6 +25,11 @@ type Config struct {
 	PostgreSQL PostgreSQL `mapstructure:"postgresql" structs:"postgresql"`
 	Redis      Redis      `mapstructure:"redis" structs:"redis"`
 	Ethereum   Ethereum   `mapstructure:"ethereum" structs:"ethereum"`
+	Vault      Vault      `mapstructure:"vault" structs:"vault"`
+	Pool       Pool       `mapstructure:"pool" structs:"pool"`
+	Auth       Auth       `mapstructure:"auth" structs:"auth"`
+	RateLimit  RateLimit  `mapstructure:"rate_limit" structs:"rate_limit"`
+	Liquidity  Liquidity  `mapstructure:"liquidity" structs:"liquidity"`
 }
 
 type PostgreSQL struct {
@@ -43,7 +48,120 @@ type Redis struct {
 }
 
 type Ethereum struct {
-	RPCURL                string `mapstructure:"rpc_url" structs:"rpc_url"`
-	StateViewContractAddr string `mapstructure:"stateview_contract_addr" structs:"stateview_contract_addr"`
-	UseMock               bool   `mapstructure:"use_mock" structs:"use_mock"`
+	RPCURL                string      `mapstructure:"rpc_url" structs:"rpc_url"`
+	FallbackRPCURLs       []string    `mapstructure:"fallback_rpc_urls" structs:"fallback_rpc_urls"`
+	RPC                   EthereumRPC `mapstructure:"rpc" structs:"rpc"`
+	StateViewContractAddr string      `mapstructure:"stateview_contract_addr" structs:"stateview_contract_addr"`
+	UseMock               bool        `mapstructure:"use_mock" structs:"use_mock"`
+}
+
+// EthereumRPC tunes retries and failover across the RPC endpoints.
+type EthereumRPC struct {
+	MaxRetries     int           `mapstructure:"max_retries" structs:"max_retries"`
+	RetryBackoff   time.Duration `mapstructure:"retry_backoff" structs:"retry_backoff"`
+	MaxBlockLag    uint64        `mapstructure:"max_block_lag" structs:"max_block_lag"`
+	HealthInterval time.Duration `mapstructure:"health_interval" structs:"health_interval"`
+}
+
+type Vault struct {
+	FactoryAddress string       `mapstructure:"factory_address" structs:"factory_address"`
+	Indexer        VaultIndexer `mapstructure:"indexer" structs:"indexer"`
+}
+
+type VaultIndexer struct {
+	Enable        bool          `mapstructure:"enable" structs:"enable"`
+	StartBlock    uint64        `mapstructure:"start_block" structs:"start_block"`
+	Confirmations uint64        `mapstructure:"confirmations" structs:"confirmations"`
+	BlockRange    uint64        `mapstructure:"block_range" structs:"block_range"`
+	Interval      time.Duration `mapstructure:"interval" structs:"interval"`
+}
+
+type Pool struct {
+	ManagerAddress string      `mapstructure:"manager_address" structs:"manager_address"`
+	Indexer        PoolIndexer `mapstructure:"indexer" structs:"indexer"`
+	Swaps          PoolSwaps   `mapstructure:"swaps" structs:"swaps"`
+}
+
+type PoolIndexer struct {
+	Enable        bool          `mapstructure:"enable" structs:"enable"`
+	StartBlock    uint64        `mapstructure:"start_block" structs:"start_block"`
+	Confirmations uint64        `mapstructure:"confirmations" structs:"confirmations"`
+	BlockRange    uint64        `mapstructure:"block_range" structs:"block_range"`
+	Interval      time.Duration `mapstructure:"interval" structs:"interval"`
+}
+
+// PoolSwaps configures swap volume recording. Pools lists the IDs of the tracked pools; their
+// volume is served whether or not the indexer runs.
+type PoolSwaps struct {
+	Pools   []string    `mapstructure:"pools" structs:"pools"`
+	Indexer PoolIndexer `mapstructure:"indexer" structs:"indexer"`
+}
+
+type Auth struct {
+	SIWE SIWE `mapstructure:"siwe" structs:"siwe"`
+	// AnonymousScopes are granted to requests without credentials.
+	AnonymousScopes []string `mapstructure:"anonymous_scopes" structs:"anonymous_scopes"`
+}
+
+type SIWE struct {
+	Domain   string        `mapstructure:"domain" structs:"domain"`
+	URI      string        `mapstructure:"uri" structs:"uri"`
+	ChainID  int64         `mapstructure:"chain_id" structs:"chain_id"`
+	NonceTTL time.Duration `mapstructure:"nonce_ttl" structs:"nonce_ttl"`
+}
+
+type Liquidity struct {
+	Cache    LiquidityCache    `mapstructure:"cache" structs:"cache"`
+	Snapshot LiquiditySnapshot `mapstructure:"snapshot" structs:"snapshot"`
+	Stream   LiquidityStream   `mapstructure:"stream" structs:"stream"`
+}
+
+type LiquidityCache struct {
+	Enable bool          `mapstructure:"enable" structs:"enable"`
+	TTL    time.Duration `mapstructure:"ttl" structs:"ttl"`
+}
+
+// LiquiditySnapshot configures the background recorder of pool liquidity history.
+type LiquiditySnapshot struct {
+	Enable        bool          `mapstructure:"enable" structs:"enable"`
+	Pools         []PoolKey     `mapstructure:"pools" structs:"pools"`
+	EveryBlocks   uint64        `mapstructure:"every_blocks" structs:"every_blocks"`
+	TickRange     int32         `mapstructure:"tick_range" structs:"tick_range"`
+	Confirmations uint64        `mapstructure:"confirmations" structs:"confirmations"`
+	Interval      time.Duration `mapstructure:"interval" structs:"interval"`
+	KeyframeEvery int           `mapstructure:"keyframe_every" structs:"keyframe_every"`
+}
+
+// LiquidityStream configures the server-sent event stream of live pool updates.
+type LiquidityStream struct {
+	Enable     bool          `mapstructure:"enable" structs:"enable"`
+	Interval   time.Duration `mapstructure:"interval" structs:"interval"`
+	TickRange  int32         `mapstructure:"tick_range" structs:"tick_range"`
+	BufferSize int           `mapstructure:"buffer_size" structs:"buffer_size"`
+	MaxPools   int           `mapstructure:"max_pools" structs:"max_pools"`
+	MaxClient  int           `mapstructure:"max_client" structs:"max_client"`
+}
+
+type PoolKey struct {
+	Currency0   string `mapstructure:"currency0" structs:"currency0"`
+	Currency1   string `mapstructure:"currency1" structs:"currency1"`
+	Fee         uint32 `mapstructure:"fee" structs:"fee"`
+	TickSpacing int32  `mapstructure:"tick_spacing" structs:"tick_spacing"`
+	Hooks       string `mapstructure:"hooks" structs:"hooks"`
+}
+
+type RateLimit struct {
+	Enable  bool            `mapstructure:"enable" structs:"enable"`
+	Default RateLimitRule   `mapstructure:"default" structs:"default"`
+	Routes  []RateLimitRule `mapstructure:"routes" structs:"routes"`
+}
+
+// RateLimitRule is a token bucket of Requests per Period holding up to Burst (default Requests).
+// Route is "METHOD /pattern" with the chi route pattern, e.g. "GET /v1/vaults/{address}/state";
+// it is ignored for the default rule.
+type RateLimitRule struct {
+	Route    string        `mapstructure:"route" structs:"route"`
+	Requests int           `mapstructure:"requests" structs:"requests"`
+	Period   time.Duration `mapstructure:"period" structs:"period"`
+	Burst    int           `mapstructure:"burst" structs:"burst"`
 }
@@ -11,6 +11,8 @@ type internalBin struct {
 	tickUpper  int
 	priceLower float64
 	priceUpper float64
+	invLower   float64
+	invUpper   float64
 	liquidity  float64
 	isCurrent  bool
 }
@@ -36,6 +38,8 @@ func toInternalBins(bins []Bin) []internalBin {
 			tickUpper:  int(b.TickUpper),
 			priceLower: b.PriceLower,
 			priceUpper: b.PriceUpper,
+			invLower:   b.InversePriceLower,
+			invUpper:   b.InversePriceUpper,
 			liquidity:  liq,
 			isCurrent:  b.IsCurrent,
 		}
@@ -56,11 +60,14 @@ func toSegments(bins []internalBin, segments []internalSegment, target []float64
 		liqInt, _ := liqFloat.Int(nil)
 
 		outputSeg := Segment{
-			TickLower:      int32(bins[seg.l].tickLower), //nolint:gosec // safe conversion
-			TickUpper:      int32(bins[seg.r].tickUpper), //nolint:gosec // safe conversion
-			PriceLower:     bins[seg.l].priceLower,
-			PriceUpper:     bins[seg.r].priceUpper,
-			LiquidityAdded: liqInt,
+			TickLower:  int32(bins[seg.l].tickLower), //nolint:gosec // safe conversion
+			TickUpper:  int32(bins[seg.r].tickUpper), //nolint:gosec // safe conversion
+			PriceLower: bins[seg.l].priceLower,
+			PriceUpper: bins[seg.r].priceUpper,
+			// Inverse prices fall as ticks rise, so the segment's inverse range comes from the opposite ends.
+			InversePriceLower: bins[seg.r].invLower,
+			InversePriceUpper: bins[seg.l].invUpper,
+			LiquidityAdded:    liqInt,
 		}
 		outputSegments = append(outputSegments, outputSeg)
 
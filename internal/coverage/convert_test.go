@@ -95,9 +95,9 @@ func TestToSegments_Empty(t *testing.T) {
 
 func TestToSegments_SingleSegment(t *testing.T) {
 	bins := []internalBin{
-		{tickLower: 0, tickUpper: 100, priceLower: 1.0, priceUpper: 1.5},
-		{tickLower: 100, tickUpper: 200, priceLower: 1.5, priceUpper: 2.0},
-		{tickLower: 200, tickUpper: 300, priceLower: 2.0, priceUpper: 2.5},
+		{tickLower: 0, tickUpper: 100, priceLower: 1.0, priceUpper: 1.5, invLower: 1 / 1.5, invUpper: 1.0},
+		{tickLower: 100, tickUpper: 200, priceLower: 1.5, priceUpper: 2.0, invLower: 0.5, invUpper: 1 / 1.5},
+		{tickLower: 200, tickUpper: 300, priceLower: 2.0, priceUpper: 2.5, invLower: 0.4, invUpper: 0.5},
 	}
 	segments := []internalSegment{
 		{l: 0, r: 2, h: 50, liquidityAdded: 50},
@@ -123,6 +123,9 @@ func TestToSegments_SingleSegment(t *testing.T) {
 	if seg.PriceUpper != 2.5 {
 		t.Errorf("PriceUpper: expected 2.5, got %f", seg.PriceUpper)
 	}
+	if seg.InversePriceLower != 0.4 || seg.InversePriceUpper != 1.0 {
+		t.Errorf("inverse prices: expected [0.4, 1.0], got [%f, %f]", seg.InversePriceLower, seg.InversePriceUpper)
+	}
 	if seg.LiquidityAdded.Cmp(big.NewInt(50)) != 0 {
 		t.Errorf("LiquidityAdded: expected 50, got %s", seg.LiquidityAdded)
 	}
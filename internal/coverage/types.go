@@ -7,23 +7,28 @@ import (
 
 // Bin represents a single tick bin with liquidity data.
 // Uses blockchain-native types for seamless integration with Uniswap v4.
+// Prices are token1 per token0; inverse prices are token0 per token1.
 type Bin struct {
-	TickLower  int32    `json:"tickLower"`
-	TickUpper  int32    `json:"tickUpper"`
-	PriceLower float64  `json:"priceLower"`
-	PriceUpper float64  `json:"priceUpper"`
-	Liquidity  *big.Int `json:"liquidity"`
-	IsCurrent  bool     `json:"isCurrent"`
+	TickLower         int32    `json:"tickLower"`
+	TickUpper         int32    `json:"tickUpper"`
+	PriceLower        float64  `json:"priceLower"`
+	PriceUpper        float64  `json:"priceUpper"`
+	InversePriceLower float64  `json:"inversePriceLower"`
+	InversePriceUpper float64  `json:"inversePriceUpper"`
+	Liquidity         *big.Int `json:"liquidity"`
+	IsCurrent         bool     `json:"isCurrent"`
 }
 
 // Segment represents an LP position segment.
 // Uses blockchain-native types for seamless integration with Uniswap v4.
 type Segment struct {
-	TickLower      int32    `json:"tickLower"`
-	TickUpper      int32    `json:"tickUpper"`
-	PriceLower     float64  `json:"priceLower"`
-	PriceUpper     float64  `json:"priceUpper"`
-	LiquidityAdded *big.Int `json:"liquidityAdded"`
+	TickLower         int32    `json:"tickLower"`
+	TickUpper         int32    `json:"tickUpper"`
+	PriceLower        float64  `json:"priceLower"`
+	PriceUpper        float64  `json:"priceUpper"`
+	InversePriceLower float64  `json:"inversePriceLower"`
+	InversePriceUpper float64  `json:"inversePriceUpper"`
+	LiquidityAdded    *big.Int `json:"liquidityAdded"`
 }
 
 // Config holds the algorithm configuration.
@@ -12,28 +12,21 @@ import (
 	"github.com/google/uuid"
 )
 
-const deleteAuthTokensByUserID = `-- name: DeleteAuthTokensByUserID :exec
-DELETE FROM auth_token WHERE user_id = $1
-`
-
-func (q *Queries) DeleteAuthTokensByUserID(ctx context.Context, userID uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteAuthTokensByUserID, userID)
-
-	return err
-}
-
 const createAuthToken = `-- name: CreateAuthToken :exec
-INSERT INTO auth_token (id, user_id, token_hash, expires_at, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO auth_token (id, user_id, token_hash, device, user_agent, expires_at, last_used_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 `
 
 type CreateAuthTokenParams struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	TokenHash string
-	ExpiresAt time.Time
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	TokenHash  string
+	Device     string
+	UserAgent  string
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 func (q *Queries) CreateAuthToken(ctx context.Context, arg CreateAuthTokenParams) error {
@@ -41,16 +34,18 @@ func (q *Queries) CreateAuthToken(ctx context.Context, arg CreateAuthTokenParams
 		arg.ID,
 		arg.UserID,
 		arg.TokenHash,
+		arg.Device,
+		arg.UserAgent,
 		arg.ExpiresAt,
+		arg.LastUsedAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
 	)
-
 	return err
 }
 
 const getAuthTokenByHash = `-- name: GetAuthTokenByHash :one
-SELECT id, user_id, token_hash, expires_at, created_at, updated_at
+SELECT id, user_id, token_hash, expires_at, created_at, updated_at, device, user_agent, last_used_at
 FROM auth_token
 WHERE token_hash = $1 AND expires_at > $2
 `
@@ -70,8 +65,10 @@ func (q *Queries) GetAuthTokenByHash(ctx context.Context, arg GetAuthTokenByHash
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Device,
+		&i.UserAgent,
+		&i.LastUsedAt,
 	)
-
 	return i, err
 }
 
@@ -81,6 +78,152 @@ DELETE FROM auth_token WHERE token_hash = $1
 
 func (q *Queries) DeleteAuthTokenByHash(ctx context.Context, tokenHash string) error {
 	_, err := q.db.Exec(ctx, deleteAuthTokenByHash, tokenHash)
+	return err
+}
+
+const consumeAuthNonce = `-- name: ConsumeAuthNonce :one
+DELETE FROM auth_nonce
+WHERE nonce = $1 AND expires_at > $2
+RETURNING nonce
+`
+
+type ConsumeAuthNonceParams struct {
+	Nonce     string
+	ExpiresAt time.Time
+}
+
+func (q *Queries) ConsumeAuthNonce(ctx context.Context, arg ConsumeAuthNonceParams) (string, error) {
+	row := q.db.QueryRow(ctx, consumeAuthNonce, arg.Nonce, arg.ExpiresAt)
+	var nonce string
+	err := row.Scan(&nonce)
+	return nonce, err
+}
+
+const createAuthNonce = `-- name: CreateAuthNonce :exec
+INSERT INTO auth_nonce (nonce, expires_at, created_at)
+VALUES ($1, $2, $3)
+`
+
+type CreateAuthNonceParams struct {
+	Nonce     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+func (q *Queries) CreateAuthNonce(ctx context.Context, arg CreateAuthNonceParams) error {
+	_, err := q.db.Exec(ctx, createAuthNonce, arg.Nonce, arg.ExpiresAt, arg.CreatedAt)
+	return err
+}
+
+const deleteExpiredAuthNonces = `-- name: DeleteExpiredAuthNonces :exec
+DELETE FROM auth_nonce WHERE expires_at <= $1
+`
+
+func (q *Queries) DeleteExpiredAuthNonces(ctx context.Context, expiresAt time.Time) error {
+	_, err := q.db.Exec(ctx, deleteExpiredAuthNonces, expiresAt)
+	return err
+}
+
+const deleteAuthTokenByID = `-- name: DeleteAuthTokenByID :execrows
+DELETE FROM auth_token WHERE id = $1 AND user_id = $2
+`
+
+type DeleteAuthTokenByIDParams struct {
+	ID     uuid.UUID
+	UserID uuid.UUID
+}
+
+func (q *Queries) DeleteAuthTokenByID(ctx context.Context, arg DeleteAuthTokenByIDParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteAuthTokenByID, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listAuthTokensByUserID = `-- name: ListAuthTokensByUserID :many
+SELECT id, user_id, token_hash, expires_at, created_at, updated_at, device, user_agent, last_used_at
+FROM auth_token
+WHERE user_id = $1 AND expires_at > $2
+ORDER BY last_used_at DESC
+`
+
+type ListAuthTokensByUserIDParams struct {
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+}
+
+func (q *Queries) ListAuthTokensByUserID(ctx context.Context, arg ListAuthTokensByUserIDParams) ([]AuthToken, error) {
+	rows, err := q.db.Query(ctx, listAuthTokensByUserID, arg.UserID, arg.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []AuthToken{}
+	for rows.Next() {
+		var i AuthToken
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.TokenHash,
+			&i.ExpiresAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Device,
+			&i.UserAgent,
+			&i.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const rotateAuthToken = `-- name: RotateAuthToken :execrows
+UPDATE auth_token
+SET token_hash = $1, expires_at = $2, last_used_at = $3, updated_at = $3
+WHERE id = $4 AND token_hash = $5
+`
+
+type RotateAuthTokenParams struct {
+	NewTokenHash string
+	ExpiresAt    time.Time
+	UpdatedAt    time.Time
+	ID           uuid.UUID
+	TokenHash    string
+}
+
+func (q *Queries) RotateAuthToken(ctx context.Context, arg RotateAuthTokenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, rotateAuthToken,
+		arg.NewTokenHash,
+		arg.ExpiresAt,
+		arg.UpdatedAt,
+		arg.ID,
+		arg.TokenHash,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const touchAuthToken = `-- name: TouchAuthToken :exec
+UPDATE auth_token
+SET expires_at = $2, last_used_at = $3, updated_at = $3
+WHERE id = $1
+`
+
+type TouchAuthTokenParams struct {
+	ID         uuid.UUID
+	ExpiresAt  time.Time
+	LastUsedAt time.Time
+}
 
+func (q *Queries) TouchAuthToken(ctx context.Context, arg TouchAuthTokenParams) error {
+	_, err := q.db.Exec(ctx, touchAuthToken, arg.ID, arg.ExpiresAt, arg.LastUsedAt)
 	return err
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
 type User struct {
@@ -18,10 +19,142 @@ type User struct {
 }
 
 type AuthToken struct {
-	ID        uuid.UUID
-	UserID    uuid.UUID
-	TokenHash string
-	ExpiresAt time.Time
+	ID         uuid.UUID
+	UserID     uuid.UUID
+	TokenHash  string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Device     string
+	UserAgent  string
+	LastUsedAt time.Time
+}
+
+type VaultRegistry struct {
+	Address   string
+	Enabled   bool
+	Label     string
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
+
+type FactoryVault struct {
+	FactoryAddress string
+	VaultAddress   string
+	BlockNumber    int64
+	TxHash         string
+	LogIndex       int
+	CreatedAt      time.Time
+}
+
+type VaultDiscoveryCursor struct {
+	FactoryAddress string
+	LastBlock      int64
+	UpdatedAt      time.Time
+}
+
+type VaultConfig struct {
+	VaultAddress     string
+	Owner            string
+	Agent            string
+	AgentPaused      bool
+	SwapAllowed      bool
+	AllowedTickLower int
+	AllowedTickUpper int
+	MaxPositionsK    decimal.Decimal
+	IndexedBlock     int64
+	UpdatedAt        time.Time
+}
+
+type VaultEvent struct {
+	VaultAddress string
+	BlockNumber  int64
+	LogIndex     int
+	BlockHash    string
+	TxHash       string
+	EventType    string
+	Data         []byte
+	BlockTime    time.Time
+	CreatedAt    time.Time
+}
+
+type VaultPosition struct {
+	VaultAddress string
+	TokenID      decimal.Decimal
+	TickLower    int
+	TickUpper    int
+	BlockNumber  int64
+}
+
+type AuthNonce struct {
+	Nonce     string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+type ApiKey struct {
+	ID         uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+type Token struct {
+	Address   string
+	Symbol    string
+	Decimals  int16
+	CreatedAt time.Time
+}
+
+type LiquiditySnapshot struct {
+	PoolID       string
+	BlockNumber  int64
+	BlockTime    time.Time
+	SqrtPriceX96 decimal.Decimal
+	Tick         int
+	Liquidity    decimal.Decimal
+	Keyframe     bool
+	Ticks        []byte
+	CreatedAt    time.Time
+}
+
+type IndexerCursor struct {
+	Name        string
+	BlockNumber int64
+	UpdatedAt   time.Time
+}
+
+type Pool struct {
+	PoolID           string
+	Currency0        string
+	Currency1        string
+	Fee              int
+	TickSpacing      int
+	Hooks            string
+	InitBlock        int64
+	InitTxHash       string
+	InitSqrtPriceX96 decimal.Decimal
+	InitTick         int
+	CreatedAt        time.Time
+}
+
+type PoolSwap struct {
+	PoolID       string
+	BlockNumber  int64
+	LogIndex     int
+	TxHash       string
+	BlockTime    time.Time
+	Sender       string
+	Amount0      decimal.Decimal
+	Amount1      decimal.Decimal
+	SqrtPriceX96 decimal.Decimal
+	Liquidity    decimal.Decimal
+	Tick         int
+	Fee          int
+	CreatedAt    time.Time
+}
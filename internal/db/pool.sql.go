@@ -0,0 +1,153 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: pool.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const getIndexerCursor = `-- name: GetIndexerCursor :one
+SELECT block_number
+FROM indexer_cursor
+WHERE name = $1
+`
+
+func (q *Queries) GetIndexerCursor(ctx context.Context, name string) (int64, error) {
+	row := q.db.QueryRow(ctx, getIndexerCursor, name)
+	var block_number int64
+	err := row.Scan(&block_number)
+	return block_number, err
+}
+
+const getPool = `-- name: GetPool :one
+SELECT pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at
+FROM pool
+WHERE pool_id = $1
+`
+
+func (q *Queries) GetPool(ctx context.Context, poolID string) (Pool, error) {
+	row := q.db.QueryRow(ctx, getPool, poolID)
+	var i Pool
+	err := row.Scan(
+		&i.PoolID,
+		&i.Currency0,
+		&i.Currency1,
+		&i.Fee,
+		&i.TickSpacing,
+		&i.Hooks,
+		&i.InitBlock,
+		&i.InitTxHash,
+		&i.InitSqrtPriceX96,
+		&i.InitTick,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertPool = `-- name: InsertPool :exec
+INSERT INTO pool (pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+ON CONFLICT (pool_id) DO NOTHING
+`
+
+type InsertPoolParams struct {
+	PoolID           string
+	Currency0        string
+	Currency1        string
+	Fee              int
+	TickSpacing      int
+	Hooks            string
+	InitBlock        int64
+	InitTxHash       string
+	InitSqrtPriceX96 decimal.Decimal
+	InitTick         int
+	CreatedAt        time.Time
+}
+
+func (q *Queries) InsertPool(ctx context.Context, arg InsertPoolParams) error {
+	_, err := q.db.Exec(ctx, insertPool,
+		arg.PoolID,
+		arg.Currency0,
+		arg.Currency1,
+		arg.Fee,
+		arg.TickSpacing,
+		arg.Hooks,
+		arg.InitBlock,
+		arg.InitTxHash,
+		arg.InitSqrtPriceX96,
+		arg.InitTick,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listPools = `-- name: ListPools :many
+SELECT pool_id, currency0, currency1, fee, tick_spacing, hooks, init_block, init_tx_hash, init_sqrt_price_x96, init_tick, created_at
+FROM pool
+WHERE $1::VARCHAR = ''
+   OR currency0 = $1::VARCHAR
+   OR currency1 = $1::VARCHAR
+ORDER BY init_block DESC, pool_id
+LIMIT $2
+`
+
+type ListPoolsParams struct {
+	Token    string
+	RowLimit int32
+}
+
+// Newest first; an empty token lists every pool.
+func (q *Queries) ListPools(ctx context.Context, arg ListPoolsParams) ([]Pool, error) {
+	rows, err := q.db.Query(ctx, listPools, arg.Token, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Pool{}
+	for rows.Next() {
+		var i Pool
+		if err := rows.Scan(
+			&i.PoolID,
+			&i.Currency0,
+			&i.Currency1,
+			&i.Fee,
+			&i.TickSpacing,
+			&i.Hooks,
+			&i.InitBlock,
+			&i.InitTxHash,
+			&i.InitSqrtPriceX96,
+			&i.InitTick,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertIndexerCursor = `-- name: UpsertIndexerCursor :exec
+INSERT INTO indexer_cursor (name, block_number, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (name) DO UPDATE SET block_number = EXCLUDED.block_number, updated_at = EXCLUDED.updated_at
+`
+
+type UpsertIndexerCursorParams struct {
+	Name        string
+	BlockNumber int64
+	UpdatedAt   time.Time
+}
+
+func (q *Queries) UpsertIndexerCursor(ctx context.Context, arg UpsertIndexerCursorParams) error {
+	_, err := q.db.Exec(ctx, upsertIndexerCursor, arg.Name, arg.BlockNumber, arg.UpdatedAt)
+	return err
+}
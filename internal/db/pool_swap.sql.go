@@ -0,0 +1,249 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: pool_swap.sql
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+const getLatestPoolSwap = `-- name: GetLatestPoolSwap :one
+SELECT pool_id, block_number, log_index, tx_hash, block_time, sender, amount0, amount1, sqrt_price_x96, liquidity, tick, fee, created_at
+FROM pool_swap
+WHERE pool_id = $1 AND block_time < $2
+ORDER BY block_number DESC, log_index DESC
+LIMIT 1
+`
+
+type GetLatestPoolSwapParams struct {
+	PoolID string
+	Until  time.Time
+}
+
+func (q *Queries) GetLatestPoolSwap(ctx context.Context, arg GetLatestPoolSwapParams) (PoolSwap, error) {
+	row := q.db.QueryRow(ctx, getLatestPoolSwap, arg.PoolID, arg.Until)
+	var i PoolSwap
+	err := row.Scan(
+		&i.PoolID,
+		&i.BlockNumber,
+		&i.LogIndex,
+		&i.TxHash,
+		&i.BlockTime,
+		&i.Sender,
+		&i.Amount0,
+		&i.Amount1,
+		&i.SqrtPriceX96,
+		&i.Liquidity,
+		&i.Tick,
+		&i.Fee,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getPoolSwapRangeFees = `-- name: GetPoolSwapRangeFees :one
+SELECT COUNT(*) AS swaps,
+       COALESCE(SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 / liquidity ELSE 0 END), 0)::NUMERIC AS fees0_per_liquidity,
+       COALESCE(SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 / liquidity ELSE 0 END), 0)::NUMERIC AS fees1_per_liquidity
+FROM pool_swap
+WHERE pool_id = $1 AND block_time >= $2 AND block_time < $3
+  AND tick >= $4 AND tick < $5 AND liquidity > 0
+`
+
+type GetPoolSwapRangeFeesParams struct {
+	PoolID    string
+	Since     time.Time
+	Until     time.Time
+	TickLower int
+	TickUpper int
+}
+
+type GetPoolSwapRangeFeesRow struct {
+	Swaps             int64
+	Fees0PerLiquidity decimal.Decimal
+	Fees1PerLiquidity decimal.Decimal
+}
+
+// Fees per unit of active liquidity for swaps that ended in [tick_lower, tick_upper): what one
+// unit of liquidity over that range would have earned.
+func (q *Queries) GetPoolSwapRangeFees(ctx context.Context, arg GetPoolSwapRangeFeesParams) (GetPoolSwapRangeFeesRow, error) {
+	row := q.db.QueryRow(ctx, getPoolSwapRangeFees,
+		arg.PoolID,
+		arg.Since,
+		arg.Until,
+		arg.TickLower,
+		arg.TickUpper,
+	)
+	var i GetPoolSwapRangeFeesRow
+	err := row.Scan(&i.Swaps, &i.Fees0PerLiquidity, &i.Fees1PerLiquidity)
+	return i, err
+}
+
+const insertPoolSwap = `-- name: InsertPoolSwap :exec
+INSERT INTO pool_swap (pool_id, block_number, log_index, tx_hash, block_time, sender, amount0, amount1, sqrt_price_x96, liquidity, tick, fee, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+ON CONFLICT (block_number, log_index) DO NOTHING
+`
+
+type InsertPoolSwapParams struct {
+	PoolID       string
+	BlockNumber  int64
+	LogIndex     int
+	TxHash       string
+	BlockTime    time.Time
+	Sender       string
+	Amount0      decimal.Decimal
+	Amount1      decimal.Decimal
+	SqrtPriceX96 decimal.Decimal
+	Liquidity    decimal.Decimal
+	Tick         int
+	Fee          int
+	CreatedAt    time.Time
+}
+
+func (q *Queries) InsertPoolSwap(ctx context.Context, arg InsertPoolSwapParams) error {
+	_, err := q.db.Exec(ctx, insertPoolSwap,
+		arg.PoolID,
+		arg.BlockNumber,
+		arg.LogIndex,
+		arg.TxHash,
+		arg.BlockTime,
+		arg.Sender,
+		arg.Amount0,
+		arg.Amount1,
+		arg.SqrtPriceX96,
+		arg.Liquidity,
+		arg.Tick,
+		arg.Fee,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listPoolSwapVolumeByTick = `-- name: ListPoolSwapVolumeByTick :many
+SELECT (FLOOR(tick::NUMERIC / $1::INT) * $1::INT)::INT AS tick_lower,
+       COUNT(*) AS swaps,
+       SUM(ABS(amount0))::NUMERIC AS volume0,
+       SUM(ABS(amount1))::NUMERIC AS volume1,
+       SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees0,
+       SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees1
+FROM pool_swap
+WHERE pool_id = $2 AND block_time >= $3 AND block_time < $4
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListPoolSwapVolumeByTickParams struct {
+	BinSize int
+	PoolID  string
+	Since   time.Time
+	Until   time.Time
+}
+
+type ListPoolSwapVolumeByTickRow struct {
+	TickLower int
+	Swaps     int64
+	Volume0   decimal.Decimal
+	Volume1   decimal.Decimal
+	Fees0     decimal.Decimal
+	Fees1     decimal.Decimal
+}
+
+// Swaps are binned by the tick they ended at. Fees are charged on the amount paid in.
+func (q *Queries) ListPoolSwapVolumeByTick(ctx context.Context, arg ListPoolSwapVolumeByTickParams) ([]ListPoolSwapVolumeByTickRow, error) {
+	rows, err := q.db.Query(ctx, listPoolSwapVolumeByTick,
+		arg.BinSize,
+		arg.PoolID,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPoolSwapVolumeByTickRow{}
+	for rows.Next() {
+		var i ListPoolSwapVolumeByTickRow
+		if err := rows.Scan(
+			&i.TickLower,
+			&i.Swaps,
+			&i.Volume0,
+			&i.Volume1,
+			&i.Fees0,
+			&i.Fees1,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPoolSwapVolumeByTime = `-- name: ListPoolSwapVolumeByTime :many
+SELECT date_bin(make_interval(secs => $1::INT), block_time, TIMESTAMP '1970-01-01')::TIMESTAMP AS bucket_start,
+       COUNT(*) AS swaps,
+       SUM(ABS(amount0))::NUMERIC AS volume0,
+       SUM(ABS(amount1))::NUMERIC AS volume1,
+       SUM(CASE WHEN amount0 < 0 THEN -amount0 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees0,
+       SUM(CASE WHEN amount1 < 0 THEN -amount1 * fee / 1000000 ELSE 0 END)::NUMERIC AS fees1
+FROM pool_swap
+WHERE pool_id = $2 AND block_time >= $3 AND block_time < $4
+GROUP BY 1
+ORDER BY 1
+`
+
+type ListPoolSwapVolumeByTimeParams struct {
+	BucketSeconds int
+	PoolID        string
+	Since         time.Time
+	Until         time.Time
+}
+
+type ListPoolSwapVolumeByTimeRow struct {
+	BucketStart time.Time
+	Swaps       int64
+	Volume0     decimal.Decimal
+	Volume1     decimal.Decimal
+	Fees0       decimal.Decimal
+	Fees1       decimal.Decimal
+}
+
+func (q *Queries) ListPoolSwapVolumeByTime(ctx context.Context, arg ListPoolSwapVolumeByTimeParams) ([]ListPoolSwapVolumeByTimeRow, error) {
+	rows, err := q.db.Query(ctx, listPoolSwapVolumeByTime,
+		arg.BucketSeconds,
+		arg.PoolID,
+		arg.Since,
+		arg.Until,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListPoolSwapVolumeByTimeRow{}
+	for rows.Next() {
+		var i ListPoolSwapVolumeByTimeRow
+		if err := rows.Scan(
+			&i.BucketStart,
+			&i.Swaps,
+			&i.Volume0,
+			&i.Volume1,
+			&i.Fees0,
+			&i.Fees1,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
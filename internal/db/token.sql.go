@@ -0,0 +1,83 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: token.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const getToken = `-- name: GetToken :one
+SELECT address, symbol, decimals, created_at
+FROM token
+WHERE address = $1
+`
+
+func (q *Queries) GetToken(ctx context.Context, address string) (Token, error) {
+	row := q.db.QueryRow(ctx, getToken, address)
+	var i Token
+	err := row.Scan(
+		&i.Address,
+		&i.Symbol,
+		&i.Decimals,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const upsertToken = `-- name: UpsertToken :exec
+INSERT INTO token (address, symbol, decimals, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (address) DO UPDATE SET symbol = EXCLUDED.symbol, decimals = EXCLUDED.decimals
+`
+
+type UpsertTokenParams struct {
+	Address   string
+	Symbol    string
+	Decimals  int16
+	CreatedAt time.Time
+}
+
+func (q *Queries) UpsertToken(ctx context.Context, arg UpsertTokenParams) error {
+	_, err := q.db.Exec(ctx, upsertToken,
+		arg.Address,
+		arg.Symbol,
+		arg.Decimals,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listTokens = `-- name: ListTokens :many
+SELECT address, symbol, decimals, created_at
+FROM token
+WHERE address = ANY($1::VARCHAR[])
+`
+
+func (q *Queries) ListTokens(ctx context.Context, addresses []string) ([]Token, error) {
+	rows, err := q.db.Query(ctx, listTokens, addresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Token{}
+	for rows.Next() {
+		var i Token
+		if err := rows.Scan(
+			&i.Address,
+			&i.Symbol,
+			&i.Decimals,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
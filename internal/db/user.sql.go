@@ -89,3 +89,34 @@ func (q *Queries) ListUsers(ctx context.Context) ([]uuid.UUID, error) {
 	}
 	return items, nil
 }
+
+const upsertUserByAddress = `-- name: UpsertUserByAddress :one
+INSERT INTO "user" ("id", "address", "created_at", "updated_at")
+VALUES ($1, $2, $3, $4)
+ON CONFLICT ("address") DO UPDATE SET "updated_at" = EXCLUDED."updated_at"
+RETURNING id, address, created_at, updated_at
+`
+
+type UpsertUserByAddressParams struct {
+	ID        uuid.UUID
+	Address   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (q *Queries) UpsertUserByAddress(ctx context.Context, arg UpsertUserByAddressParams) (User, error) {
+	row := q.db.QueryRow(ctx, upsertUserByAddress,
+		arg.ID,
+		arg.Address,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
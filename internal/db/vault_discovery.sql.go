@@ -0,0 +1,90 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: vault_discovery.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const getVaultDiscoveryCursor = `-- name: GetVaultDiscoveryCursor :one
+SELECT last_block FROM vault_discovery_cursor WHERE factory_address = $1
+`
+
+func (q *Queries) GetVaultDiscoveryCursor(ctx context.Context, factoryAddress string) (int64, error) {
+	row := q.db.QueryRow(ctx, getVaultDiscoveryCursor, factoryAddress)
+	var last_block int64
+	err := row.Scan(&last_block)
+	return last_block, err
+}
+
+const insertFactoryVault = `-- name: InsertFactoryVault :exec
+INSERT INTO factory_vault (factory_address, vault_address, block_number, tx_hash, log_index, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (factory_address, vault_address) DO NOTHING
+`
+
+type InsertFactoryVaultParams struct {
+	FactoryAddress string
+	VaultAddress   string
+	BlockNumber    int64
+	TxHash         string
+	LogIndex       int
+	CreatedAt      time.Time
+}
+
+func (q *Queries) InsertFactoryVault(ctx context.Context, arg InsertFactoryVaultParams) error {
+	_, err := q.db.Exec(ctx, insertFactoryVault,
+		arg.FactoryAddress,
+		arg.VaultAddress,
+		arg.BlockNumber,
+		arg.TxHash,
+		arg.LogIndex,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listFactoryVaults = `-- name: ListFactoryVaults :many
+SELECT vault_address FROM factory_vault WHERE factory_address = $1 ORDER BY block_number, log_index
+`
+
+func (q *Queries) ListFactoryVaults(ctx context.Context, factoryAddress string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listFactoryVaults, factoryAddress)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var vault_address string
+		if err := rows.Scan(&vault_address); err != nil {
+			return nil, err
+		}
+		items = append(items, vault_address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertVaultDiscoveryCursor = `-- name: UpsertVaultDiscoveryCursor :exec
+INSERT INTO vault_discovery_cursor (factory_address, last_block, updated_at)
+VALUES ($1, $2, $3)
+ON CONFLICT (factory_address) DO UPDATE SET last_block = EXCLUDED.last_block, updated_at = EXCLUDED.updated_at
+`
+
+type UpsertVaultDiscoveryCursorParams struct {
+	FactoryAddress string
+	LastBlock      int64
+	UpdatedAt      time.Time
+}
+
+func (q *Queries) UpsertVaultDiscoveryCursor(ctx context.Context, arg UpsertVaultDiscoveryCursorParams) error {
+	_, err := q.db.Exec(ctx, upsertVaultDiscoveryCursor, arg.FactoryAddress, arg.LastBlock, arg.UpdatedAt)
+	return err
+}
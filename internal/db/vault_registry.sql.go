@@ -0,0 +1,78 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.29.0
+// source: vault_registry.sql
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+const listVaultRegistryAddresses = `-- name: ListVaultRegistryAddresses :many
+SELECT address FROM vault_registry WHERE enabled = $1 ORDER BY created_at, address
+`
+
+func (q *Queries) ListVaultRegistryAddresses(ctx context.Context, enabled bool) ([]string, error) {
+	rows, err := q.db.Query(ctx, listVaultRegistryAddresses, enabled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []string{}
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, err
+		}
+		items = append(items, address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setVaultRegistryEnabled = `-- name: SetVaultRegistryEnabled :execrows
+UPDATE vault_registry SET enabled = $2, updated_at = $3 WHERE address = $1
+`
+
+type SetVaultRegistryEnabledParams struct {
+	Address   string
+	Enabled   bool
+	UpdatedAt time.Time
+}
+
+func (q *Queries) SetVaultRegistryEnabled(ctx context.Context, arg SetVaultRegistryEnabledParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setVaultRegistryEnabled, arg.Address, arg.Enabled, arg.UpdatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const upsertVaultRegistry = `-- name: UpsertVaultRegistry :exec
+INSERT INTO vault_registry (address, enabled, label, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (address) DO UPDATE SET label = EXCLUDED.label, updated_at = EXCLUDED.updated_at
+`
+
+type UpsertVaultRegistryParams struct {
+	Address   string
+	Enabled   bool
+	Label     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (q *Queries) UpsertVaultRegistry(ctx context.Context, arg UpsertVaultRegistryParams) error {
+	_, err := q.db.Exec(ctx, upsertVaultRegistry,
+		arg.Address,
+		arg.Enabled,
+		arg.Label,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
@@ -81,6 +81,36 @@ func (e *errorRenderer) Render(w http.ResponseWriter, _ *http.Request) {
 	_ = json.NewEncoder(w).Encode(map[string]string{"error": e.msg})
 }
 
+// NewBadRequestError returns an ErrorRenderer for 400 Bad Request.
+func NewBadRequestError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
+	msg := "bad request"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	return &errorRenderer{statusCode: http.StatusBadRequest, msg: msg}
+}
+
+// NewNotFoundError returns an ErrorRenderer for 404 Not Found.
+func NewNotFoundError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
+	msg := "not found"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	return &errorRenderer{statusCode: http.StatusNotFound, msg: msg}
+}
+
+// NewServiceUnavailableError returns an ErrorRenderer for 503 Service Unavailable.
+func NewServiceUnavailableError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
+	msg := "service unavailable"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	return &errorRenderer{statusCode: http.StatusServiceUnavailable, msg: msg}
+}
+
 // NewUnauthorizedError returns an ErrorRenderer for 401 Unauthorized.
 func NewUnauthorizedError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
 	msg := "unauthorized"
@@ -91,6 +121,26 @@ func NewUnauthorizedError(err error) ErrorRenderer { //nolint:ireturn // public
 	return &errorRenderer{statusCode: http.StatusUnauthorized, msg: msg}
 }
 
+// NewForbiddenError returns an ErrorRenderer for 403 Forbidden.
+func NewForbiddenError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
+	msg := "forbidden"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	return &errorRenderer{statusCode: http.StatusForbidden, msg: msg}
+}
+
+// NewTooManyRequestsError returns an ErrorRenderer for 429 Too Many Requests.
+func NewTooManyRequestsError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
+	msg := "too many requests"
+	if err != nil {
+		msg = err.Error()
+	}
+
+	return &errorRenderer{statusCode: http.StatusTooManyRequests, msg: msg}
+}
+
 // NewInternalServerError returns an ErrorRenderer for 500 Internal Server Error.
 func NewInternalServerError(err error) ErrorRenderer { //nolint:ireturn // public API returns interface
 	msg := "internal error"
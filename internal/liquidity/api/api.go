@@ -2,19 +2,28 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 
 	"remora/internal/httpwrap"
 	"remora/internal/liquidity"
 	"remora/internal/liquidity/poolid"
+	"remora/internal/liquidity/stream"
+	"remora/internal/token"
 )
 
-// AddRoutes registers liquidity-related routes on the provided router.
-func AddRoutes(r chi.Router, svc liquidity.Service) {
+// AddRoutes registers liquidity-related routes on the provided router. The stream route is
+// registered only when hub is non-nil.
+func AddRoutes(r chi.Router, svc liquidity.Service, hub *stream.Hub) {
 	r.Post("/liquidity/distribution", httpwrap.Handler(getDistribution(svc)))
+
+	if hub != nil {
+		r.Get("/liquidity/stream", streamPool(svc, hub))
+	}
 }
 
 // PoolKeyRequest represents the pool key in the API request.
@@ -26,11 +35,24 @@ type PoolKeyRequest struct {
 	Hooks       string `json:"hooks"`       // Hooks contract address (0x0 if none)
 }
 
-// DistributionRequest is the API request for liquidity distribution.
+// DistributionRequest is the API request for liquidity distribution. The pool is given by
+// poolKey, poolId or both; a bare poolId is resolved from the pool registry.
 type DistributionRequest struct {
-	PoolKey      PoolKeyRequest `json:"poolKey"`      // Uniswap v4 pool key (PoolId computed server-side)
-	BinSizeTicks int32          `json:"binSizeTicks"` // Size of each bin in ticks
-	TickRange    int32          `json:"tickRange"`    // Range of ticks to scan (±tickRange from current tick)
+	PoolKey      PoolKeyRequest `json:"poolKey"`               // Uniswap v4 pool key (PoolId computed server-side)
+	PoolID       string         `json:"poolId,omitempty"`      // 0x-prefixed 32-byte pool ID
+	BinSizeTicks int32          `json:"binSizeTicks"`          // Size of each bin in ticks
+	TickRange    int32          `json:"tickRange"`             // Range of ticks to scan (±tickRange from current tick)
+	BlockNumber  uint64         `json:"blockNumber,omitempty"` // Block to read at (default latest)
+	Timestamp    int64          `json:"timestamp,omitempty"`   // Unix seconds; read at the last block at or before it
+}
+
+// PoolKeyResponse is the canonical pool key in the API response.
+type PoolKeyResponse struct {
+	Currency0   string `json:"currency0"`
+	Currency1   string `json:"currency1"`
+	Fee         uint32 `json:"fee"`
+	TickSpacing int32  `json:"tickSpacing"`
+	Hooks       string `json:"hooks"`
 }
 
 // TickInfoResponse represents tick information in the API response.
@@ -42,20 +64,34 @@ type TickInfoResponse struct {
 
 // BinResponse represents a liquidity bin in the API response.
 type BinResponse struct {
-	TickLower       int32  `json:"tickLower"`
-	TickUpper       int32  `json:"tickUpper"`
-	ActiveLiquidity string `json:"activeLiquidity"`
-	PriceLower      string `json:"priceLower,omitempty"`
-	PriceUpper      string `json:"priceUpper,omitempty"`
+	TickLower         int32  `json:"tickLower"`
+	TickUpper         int32  `json:"tickUpper"`
+	ActiveLiquidity   string `json:"activeLiquidity"`
+	PriceLower        string `json:"priceLower,omitempty"`        // token1 per token0 at tickLower
+	PriceUpper        string `json:"priceUpper,omitempty"`        // token1 per token0 at tickUpper
+	InversePriceLower string `json:"inversePriceLower,omitempty"` // token0 per token1 at tickUpper
+	InversePriceUpper string `json:"inversePriceUpper,omitempty"` // token0 per token1 at tickLower
+}
+
+// TokenResponse represents pool token metadata in the API response.
+type TokenResponse struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
 }
 
 // DistributionResponse is the API response for liquidity distribution.
 type DistributionResponse struct {
+	PoolID           string             `json:"poolId"`
+	PoolKey          PoolKeyResponse    `json:"poolKey"`
+	BlockNumber      uint64             `json:"blockNumber,omitempty"` // Block the distribution was read at
 	CurrentTick      int32              `json:"currentTick"`
 	SqrtPriceX96     string             `json:"sqrtPriceX96"`
 	Liquidity        string             `json:"liquidity"` // Pool total liquidity L
 	InitializedTicks []TickInfoResponse `json:"initializedTicks"`
 	Bins             []BinResponse      `json:"bins"`
+	Token0           *TokenResponse     `json:"token0,omitempty"` // Omitted when token metadata is unavailable
+	Token1           *TokenResponse     `json:"token1,omitempty"`
 }
 
 // getDistribution returns a handler that fetches liquidity distribution for a pool.
@@ -83,6 +119,24 @@ func getDistribution(svc liquidity.Service) func(*http.Request) (*httpwrap.Respo
 			},
 			BinSizeTicks: req.BinSizeTicks,
 			TickRange:    req.TickRange,
+			BlockNumber:  req.BlockNumber,
+		}
+
+		if req.PoolID != "" {
+			id, err := poolid.ParsePoolID(req.PoolID)
+			if err != nil {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusBadRequest,
+					ErrorMsg:   "invalid param: poolId",
+					Err:        err,
+				}
+			}
+
+			params.PoolID = id
+		}
+
+		if req.Timestamp != 0 {
+			params.Timestamp = time.Unix(req.Timestamp, 0)
 		}
 
 		dist, err := svc.GetDistribution(ctx, params)
@@ -91,10 +145,11 @@ func getDistribution(svc liquidity.Service) func(*http.Request) (*httpwrap.Respo
 				slog.String("error", err.Error()),
 				slog.String("currency0", req.PoolKey.Currency0),
 				slog.String("currency1", req.PoolKey.Currency1),
+				slog.String("pool_id", req.PoolID),
 			)
 
 			return nil, &httpwrap.ErrorResponse{
-				StatusCode: http.StatusInternalServerError,
+				StatusCode: errorStatus(err),
 				ErrorMsg:   err.Error(),
 				Err:        err,
 			}
@@ -113,23 +168,62 @@ func getDistribution(svc liquidity.Service) func(*http.Request) (*httpwrap.Respo
 		bins := make([]BinResponse, len(dist.Bins))
 		for i, bin := range dist.Bins {
 			bins[i] = BinResponse{
-				TickLower:       bin.TickLower,
-				TickUpper:       bin.TickUpper,
-				ActiveLiquidity: bin.ActiveLiquidity.String(),
-				PriceLower:      bin.PriceLower,
-				PriceUpper:      bin.PriceUpper,
+				TickLower:         bin.TickLower,
+				TickUpper:         bin.TickUpper,
+				ActiveLiquidity:   bin.ActiveLiquidity.String(),
+				PriceLower:        bin.PriceLower,
+				PriceUpper:        bin.PriceUpper,
+				InversePriceLower: bin.InversePriceLower,
+				InversePriceUpper: bin.InversePriceUpper,
 			}
 		}
 
 		return &httpwrap.Response{
 			StatusCode: http.StatusOK,
 			Body: &DistributionResponse{
+				PoolID: dist.PoolID,
+				PoolKey: PoolKeyResponse{
+					Currency0:   dist.PoolKey.Currency0,
+					Currency1:   dist.PoolKey.Currency1,
+					Fee:         dist.PoolKey.Fee,
+					TickSpacing: dist.PoolKey.TickSpacing,
+					Hooks:       dist.PoolKey.Hooks,
+				},
+				BlockNumber:      dist.BlockNumber,
 				CurrentTick:      dist.CurrentTick,
 				SqrtPriceX96:     dist.SqrtPriceX96,
 				Liquidity:        dist.Liquidity,
 				InitializedTicks: ticks,
 				Bins:             bins,
+				Token0:           toTokenResponse(dist.Token0),
+				Token1:           toTokenResponse(dist.Token1),
 			},
 		}, nil
 	}
 }
+
+// errorStatus maps a service error to the status it is reported with.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, liquidity.ErrUnknownPool):
+		return http.StatusNotFound
+	case errors.Is(err, liquidity.ErrInvalidBlock),
+		errors.Is(err, liquidity.ErrPoolIDMismatch),
+		errors.Is(err, poolid.ErrInvalidPoolKey):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func toTokenResponse(t *token.Token) *TokenResponse {
+	if t == nil {
+		return nil
+	}
+
+	return &TokenResponse{
+		Address:  t.Address.Hex(),
+		Symbol:   t.Symbol,
+		Decimals: t.Decimals,
+	}
+}
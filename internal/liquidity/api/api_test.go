@@ -0,0 +1,39 @@
+package api
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"remora/internal/httpwrap"
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/mocks"
+	liquiditysvc "remora/internal/liquidity/service"
+)
+
+func TestGetDistribution_BlockAboveHead(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	repo.EXPECT().GetSlot0(gomock.Any(), gomock.Any(), big.NewInt(999_999_999)).
+		Return(nil, fmt.Errorf("get slot0: %w: block 999999999: header not found", liquidity.ErrInvalidBlock))
+
+	handler := httpwrap.Handler(getDistribution(liquiditysvc.New(repo, nil, nil)))
+
+	body := `{"poolKey":{"currency0":"0x0000000000000000000000000000000000000000",` +
+		`"currency1":"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48","fee":3000,"tickSpacing":60,` +
+		`"hooks":"0x0000000000000000000000000000000000000000"},"binSizeTicks":60,"tickRange":600,"blockNumber":999999999}`
+
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodPost, "/v1/liquidity/distribution", strings.NewReader(body)))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d; body = %s", rec.Code, http.StatusBadRequest, rec.Body)
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"remora/internal/api/middleware"
+	"remora/internal/httpwrap"
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/liquidity/stream"
+)
+
+// keepAliveInterval spaces SSE comments that keep idle connections open through proxies.
+const keepAliveInterval = 15 * time.Second
+
+// StreamEventResponse is the data of a server-sent event. Ticks is set on snapshot and ticks
+// events only.
+type StreamEventResponse struct {
+	BlockNumber  uint64             `json:"blockNumber"`
+	Tick         int32              `json:"tick"`
+	SqrtPriceX96 string             `json:"sqrtPriceX96"`
+	Liquidity    string             `json:"liquidity"`
+	Ticks        []TickInfoResponse `json:"ticks,omitempty"`
+}
+
+// streamPool returns a handler that streams a pool's updates as server-sent events. The pool
+// is given by the poolId query parameter, or by its key in the currency0, currency1, fee,
+// tickSpacing and hooks query parameters.
+func streamPool(svc liquidity.Service, hub *stream.Hub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		key, err := streamPoolKey(r, svc)
+		if err != nil {
+			if errors.Is(err, liquidity.ErrUnknownPool) {
+				httpwrap.NewNotFoundError(err).Render(w, r)
+			} else {
+				httpwrap.NewBadRequestError(err).Render(w, r)
+			}
+
+			return
+		}
+
+		sub, err := hub.Subscribe(ctx, key, middleware.Subject(r))
+		if err != nil {
+			switch {
+			case errors.Is(err, poolid.ErrInvalidPoolKey):
+				httpwrap.NewBadRequestError(err).Render(w, r)
+			case errors.Is(err, liquidity.ErrUnknownPool):
+				httpwrap.NewNotFoundError(err).Render(w, r)
+			case errors.Is(err, stream.ErrTooManySubscriptions):
+				httpwrap.NewTooManyRequestsError(err).Render(w, r)
+			case errors.Is(err, stream.ErrClosed), errors.Is(err, stream.ErrTooManyPools):
+				httpwrap.NewServiceUnavailableError(err).Render(w, r)
+			default:
+				httpwrap.NewInternalServerError(err).Render(w, r)
+			}
+
+			return
+		}
+		defer sub.Close()
+
+		// Streams outlive the server's write timeout.
+		rc := http.NewResponseController(w)
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		if err := rc.Flush(); err != nil {
+			slog.ErrorContext(ctx, "stream not supported", slog.Any("error", err)) //nolint:sloglint // handler error logging, logger not injected in API layer
+
+			return
+		}
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-keepAlive.C:
+				if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+					return
+				}
+			case ev, ok := <-sub.C:
+				if !ok {
+					return
+				}
+
+				if err := writeEvent(w, ev); err != nil {
+					return
+				}
+			}
+
+			if err := rc.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, ev stream.Event) error {
+	resp := StreamEventResponse{
+		BlockNumber:  ev.BlockNumber,
+		Tick:         ev.Tick,
+		SqrtPriceX96: ev.SqrtPriceX96,
+		Liquidity:    ev.Liquidity,
+	}
+
+	if ev.Ticks != nil {
+		resp.Ticks = make([]TickInfoResponse, len(ev.Ticks))
+		for i, t := range ev.Ticks {
+			resp.Ticks[i] = TickInfoResponse{
+				Tick:           t.Tick,
+				LiquidityGross: t.LiquidityGross.String(),
+				LiquidityNet:   t.LiquidityNet.String(),
+			}
+		}
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", ev.BlockNumber, ev.Type, data); err != nil {
+		return fmt.Errorf("write event: %w", err)
+	}
+
+	return nil
+}
+
+// streamPoolKey returns the key of the pool a stream request names, resolving a poolId.
+func streamPoolKey(r *http.Request, svc liquidity.Service) (poolid.PoolKey, error) {
+	raw := r.URL.Query().Get("poolId")
+	if raw == "" {
+		return poolKeyFromQuery(r)
+	}
+
+	id, err := poolid.ParsePoolID(raw)
+	if err != nil {
+		return poolid.PoolKey{}, fmt.Errorf("invalid param: poolId: %w", err)
+	}
+
+	key, err := svc.ResolvePoolKey(r.Context(), id)
+	if err != nil {
+		return poolid.PoolKey{}, fmt.Errorf("resolve pool: %w", err)
+	}
+
+	return *key, nil
+}
+
+func poolKeyFromQuery(r *http.Request) (poolid.PoolKey, error) {
+	q := r.URL.Query()
+
+	fee, err := strconv.ParseUint(q.Get("fee"), 10, 32)
+	if err != nil {
+		return poolid.PoolKey{}, fmt.Errorf("invalid param: fee: %w", err)
+	}
+
+	tickSpacing, err := strconv.ParseInt(q.Get("tickSpacing"), 10, 32)
+	if err != nil {
+		return poolid.PoolKey{}, fmt.Errorf("invalid param: tickSpacing: %w", err)
+	}
+
+	return poolid.PoolKey{
+		Currency0:   q.Get("currency0"),
+		Currency1:   q.Get("currency1"),
+		Fee:         uint32(fee),        //nolint:gosec // parsed as 32 bits
+		TickSpacing: int32(tickSpacing), //nolint:gosec // parsed as 32 bits
+		Hooks:       q.Get("hooks"),
+	}, nil
+}
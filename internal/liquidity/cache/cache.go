@@ -0,0 +1,241 @@
+// Package cache caches liquidity distributions in Redis, keyed by block so entries never go stale.
+package cache
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+)
+
+const (
+	// computeTimeout bounds a shared computation, which outlives the request that started it.
+	computeTimeout = 30 * time.Second
+	// openPeriod is how long requests skip Redis after it fails, so an outage does not cost
+	// every request a Get and a Set timeout.
+	openPeriod = 5 * time.Second
+)
+
+// BlockSource returns the latest block number and resolves timestamps to blocks.
+type BlockSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	BlockNumberAt(ctx context.Context, t time.Time) (uint64, error)
+}
+
+// Service wraps a liquidity.Service and caches GetDistribution results per pool, bin size,
+// tick range and block. Requests are pinned to the block they are cached under, whether the
+// latest, the requested one or the one a timestamp resolves to. Concurrent identical requests
+// share one computation. Redis errors are logged and the request is computed directly; the
+// circuit then opens and requests skip Redis for openPeriod, until a single request probes it.
+type Service struct {
+	next   liquidity.Service
+	blocks BlockSource
+	client redis.Cmdable
+	prefix string
+	ttl    time.Duration
+	logger *slog.Logger
+	group  singleflight.Group
+	now    func() time.Time
+	// openUntil is when the open circuit next lets a probe through, in Unix nanoseconds; 0 while closed.
+	openUntil atomic.Int64
+	// probing is set while a probe of Redis is in flight.
+	probing atomic.Bool
+}
+
+var _ liquidity.Service = (*Service)(nil)
+
+// New returns a caching service. ttl bounds how long entries for past blocks stay in Redis.
+func New(next liquidity.Service, blocks BlockSource, client redis.Cmdable, prefix string, ttl time.Duration, logger *slog.Logger) *Service {
+	return &Service{
+		next:   next,
+		blocks: blocks,
+		client: client,
+		prefix: prefix,
+		ttl:    ttl,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+func (s *Service) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*liquidity.Slot0, error) {
+	return s.next.GetSlot0(ctx, poolKey) //nolint:wrapcheck // pass-through
+}
+
+func (s *Service) ResolvePoolKey(ctx context.Context, id common.Hash) (*poolid.PoolKey, error) {
+	return s.next.ResolvePoolKey(ctx, id) //nolint:wrapcheck // pass-through
+}
+
+func (s *Service) GetDistribution(ctx context.Context, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	pinned := *params
+
+	// Entries are keyed by the ID computed from the key, so a bare pool ID is resolved first.
+	if pinned.PoolKey == (poolid.PoolKey{}) && pinned.PoolID != (common.Hash{}) {
+		key, err := s.next.ResolvePoolKey(ctx, pinned.PoolID)
+		if err != nil {
+			return nil, err //nolint:wrapcheck // pass-through
+		}
+
+		pinned.PoolKey = *key
+	}
+
+	if err := poolid.ValidatePoolKey(&pinned.PoolKey); err != nil {
+		return nil, fmt.Errorf("validate pool key: %w", err)
+	}
+
+	// A mismatched ID must fail here rather than share the key's entry or flight.
+	if pinned.PoolID != (common.Hash{}) && common.Hash(poolid.CalculatePoolID(&pinned.PoolKey)) != pinned.PoolID {
+		return nil, fmt.Errorf("%w: %s", liquidity.ErrPoolIDMismatch, pinned.PoolID.Hex())
+	}
+
+	pinned.PoolID = common.Hash{}
+
+	block, err := s.block(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned.BlockNumber = block
+	pinned.Timestamp = time.Time{}
+
+	key := s.key(&pinned, block)
+
+	ch := s.group.DoChan(key, func() (any, error) {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), computeTimeout)
+		defer cancel()
+
+		return s.load(ctx, key, &pinned)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("get distribution: %w", ctx.Err())
+	case res := <-ch:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+
+		dist, _ := res.Val.(*liquidity.Distribution)
+
+		return dist, nil
+	}
+}
+
+// block returns the block params ask for: the requested one, the last one at or before the
+// requested timestamp, or the latest.
+func (s *Service) block(ctx context.Context, params *liquidity.DistributionParams) (uint64, error) {
+	switch {
+	case params.BlockNumber != 0 && !params.Timestamp.IsZero():
+		return 0, fmt.Errorf("%w: set either block number or timestamp", liquidity.ErrInvalidBlock)
+	case params.BlockNumber != 0:
+		return params.BlockNumber, nil
+	case !params.Timestamp.IsZero():
+		block, err := s.blocks.BlockNumberAt(ctx, params.Timestamp)
+		if err != nil {
+			return 0, fmt.Errorf("resolve timestamp: %w", err)
+		}
+
+		return block, nil
+	default:
+		block, err := s.blocks.BlockNumber(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("get block number: %w", err)
+		}
+
+		return block, nil
+	}
+}
+
+// load returns the cached distribution for key, computing and storing it on a miss. Redis is
+// skipped while the circuit is open.
+func (s *Service) load(ctx context.Context, key string, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	skip, probe := s.circuit()
+	if probe {
+		defer s.probing.Store(false)
+	}
+
+	if !skip {
+		cached, err := s.client.Get(ctx, key).Bytes()
+
+		switch {
+		case err == nil:
+			s.openUntil.Store(0)
+
+			var dist liquidity.Distribution
+			if err := json.Unmarshal(cached, &dist); err == nil {
+				return &dist, nil
+			}
+
+			s.logger.WarnContext(ctx, "liquidity cache entry corrupt", slog.String("key", key))
+		case errors.Is(err, redis.Nil):
+			s.openUntil.Store(0)
+		default:
+			s.open()
+			skip = true
+
+			s.logger.WarnContext(ctx, "liquidity cache get failed", slog.String("key", key), slog.Any("error", err))
+		}
+	}
+
+	dist, err := s.next.GetDistribution(ctx, params)
+	if err != nil {
+		return nil, err //nolint:wrapcheck // pass-through
+	}
+
+	if skip {
+		return dist, nil
+	}
+
+	data, err := json.Marshal(dist)
+	if err != nil {
+		return nil, fmt.Errorf("marshal distribution: %w", err)
+	}
+
+	if err := s.client.Set(ctx, key, data, s.ttl).Err(); err != nil {
+		s.open()
+		s.logger.WarnContext(ctx, "liquidity cache set failed", slog.String("key", key), slog.Any("error", err))
+	}
+
+	return dist, nil
+}
+
+// open opens the circuit for openPeriod.
+func (s *Service) open() {
+	s.openUntil.Store(s.now().Add(openPeriod).UnixNano())
+}
+
+// circuit reports whether a request should skip Redis, and whether it is the probe that decides
+// if the open circuit closes.
+func (s *Service) circuit() (skip, probe bool) {
+	until := s.openUntil.Load()
+	if until == 0 {
+		return false, false
+	}
+
+	if s.now().UnixNano() < until || !s.probing.CompareAndSwap(false, true) {
+		return true, false
+	}
+
+	return false, true
+}
+
+// key is <prefix><pool id>:<bin size>:<tick range>:<block>.
+func (s *Service) key(params *liquidity.DistributionParams, block uint64) string {
+	poolID := poolid.CalculatePoolID(&params.PoolKey)
+
+	return s.prefix + hex.EncodeToString(poolID[:]) +
+		":" + strconv.Itoa(int(params.BinSizeTicks)) +
+		":" + strconv.Itoa(int(params.TickRange)) +
+		":" + strconv.FormatUint(block, 10)
+}
@@ -0,0 +1,353 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/redis/go-redis/v9"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+)
+
+type fakeService struct {
+	calls     atomic.Int32
+	release   chan struct{}
+	lastBlock atomic.Uint64
+}
+
+func (f *fakeService) GetSlot0(context.Context, *poolid.PoolKey) (*liquidity.Slot0, error) {
+	return &liquidity.Slot0{}, nil
+}
+
+// ResolvePoolKey knows only the testParams pool.
+func (f *fakeService) ResolvePoolKey(_ context.Context, id common.Hash) (*poolid.PoolKey, error) {
+	key := testParams().PoolKey
+	if common.Hash(poolid.CalculatePoolID(&key)) != id {
+		return nil, liquidity.ErrUnknownPool
+	}
+
+	return &key, nil
+}
+
+func (f *fakeService) GetDistribution(_ context.Context, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	n := f.calls.Add(1)
+	f.lastBlock.Store(params.BlockNumber)
+
+	if f.release != nil {
+		<-f.release
+	}
+
+	return &liquidity.Distribution{
+		CurrentTick: params.TickRange,
+		Liquidity:   big.NewInt(int64(n)).String(),
+		Bins:        []liquidity.Bin{{TickLower: -60, TickUpper: 60, ActiveLiquidity: big.NewInt(42)}},
+	}, nil
+}
+
+type fakeBlocks struct{ block atomic.Uint64 }
+
+func (f *fakeBlocks) BlockNumber(context.Context) (uint64, error) {
+	return f.block.Load(), nil
+}
+
+// BlockNumberAt resolves every timestamp to block 90.
+func (f *fakeBlocks) BlockNumberAt(context.Context, time.Time) (uint64, error) {
+	return 90, nil
+}
+
+func newTestService(t *testing.T, next liquidity.Service) (*Service, *fakeBlocks, *miniredis.Miniredis) {
+	t.Helper()
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	blocks := &fakeBlocks{}
+	blocks.block.Store(100)
+
+	return New(next, blocks, client, "test:", time.Minute, slog.New(slog.NewTextHandler(io.Discard, nil))), blocks, srv
+}
+
+func testParams() *liquidity.DistributionParams {
+	return &liquidity.DistributionParams{
+		PoolKey: poolid.PoolKey{
+			Currency0:   "0x0000000000000000000000000000000000000000",
+			Currency1:   "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+			Fee:         3000,
+			TickSpacing: 60,
+			Hooks:       "0x0000000000000000000000000000000000000000",
+		},
+		BinSizeTicks: 100,
+		TickRange:    1000,
+	}
+}
+
+func TestService_GetDistribution_CachesPerBlock(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, blocks, srv := newTestService(t, next)
+
+	first, err := svc.GetDistribution(t.Context(), testParams())
+	if err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	second, err := svc.GetDistribution(t.Context(), testParams())
+	if err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if next.calls.Load() != 1 {
+		t.Fatalf("calls = %d, want 1", next.calls.Load())
+	}
+
+	if second.Liquidity != first.Liquidity || second.Bins[0].ActiveLiquidity.Int64() != 42 {
+		t.Errorf("cached distribution = %+v, want %+v", second, first)
+	}
+
+	other := testParams()
+	other.TickRange = 2000
+
+	if _, err := svc.GetDistribution(t.Context(), other); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	blocks.block.Store(101)
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if next.calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3 (new tick range and new block miss)", next.calls.Load())
+	}
+
+	if len(srv.Keys()) != 3 {
+		t.Errorf("keys = %v, want 3", srv.Keys())
+	}
+}
+
+func TestService_GetDistribution_SharesEntryByPoolID(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, _, srv := newTestService(t, next)
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	byID := testParams()
+	byID.PoolID = poolid.CalculatePoolID(&byID.PoolKey)
+	byID.PoolKey = poolid.PoolKey{}
+
+	if _, err := svc.GetDistribution(t.Context(), byID); err != nil {
+		t.Fatalf("GetDistribution() by pool ID error = %v", err)
+	}
+
+	if next.calls.Load() != 1 || len(srv.Keys()) != 1 {
+		t.Errorf("calls = %d, keys = %v, want one shared entry", next.calls.Load(), srv.Keys())
+	}
+
+	unknown := testParams()
+	unknown.PoolID = common.Hash{1}
+	unknown.PoolKey = poolid.PoolKey{}
+
+	if _, err := svc.GetDistribution(t.Context(), unknown); !errors.Is(err, liquidity.ErrUnknownPool) {
+		t.Errorf("GetDistribution() unknown pool error = %v, want %v", err, liquidity.ErrUnknownPool)
+	}
+}
+
+func TestService_GetDistribution_RejectsMismatchedPoolID(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, _, srv := newTestService(t, next)
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	mismatched := testParams()
+	mismatched.PoolID = common.Hash{1}
+
+	if _, err := svc.GetDistribution(t.Context(), mismatched); !errors.Is(err, liquidity.ErrPoolIDMismatch) {
+		t.Errorf("GetDistribution() error = %v, want %v", err, liquidity.ErrPoolIDMismatch)
+	}
+
+	if next.calls.Load() != 1 || len(srv.Keys()) != 1 {
+		t.Errorf("calls = %d, keys = %v, want only the valid entry", next.calls.Load(), srv.Keys())
+	}
+}
+
+func TestService_GetDistribution_PinsBlock(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, _, _ := newTestService(t, next)
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if next.lastBlock.Load() != 100 {
+		t.Errorf("latest read at block %d, want 100", next.lastBlock.Load())
+	}
+
+	byTime := testParams()
+	byTime.Timestamp = time.Unix(1_700_000_000, 0)
+
+	if _, err := svc.GetDistribution(t.Context(), byTime); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if next.lastBlock.Load() != 90 {
+		t.Errorf("timestamp read at block %d, want 90", next.lastBlock.Load())
+	}
+
+	byBlock := testParams()
+	byBlock.BlockNumber = 90
+
+	if _, err := svc.GetDistribution(t.Context(), byBlock); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if next.calls.Load() != 2 {
+		t.Errorf("calls = %d, want 2 (block 90 cached by the timestamp request)", next.calls.Load())
+	}
+
+	byBlock.Timestamp = byTime.Timestamp
+	if _, err := svc.GetDistribution(t.Context(), byBlock); !errors.Is(err, liquidity.ErrInvalidBlock) {
+		t.Errorf("block and timestamp error = %v, want %v", err, liquidity.ErrInvalidBlock)
+	}
+}
+
+func TestService_GetDistribution_SingleFlight(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{release: make(chan struct{})}
+	svc, _, _ := newTestService(t, next)
+
+	const callers = 5
+
+	var wg sync.WaitGroup
+
+	for range callers {
+		wg.Go(func() {
+			if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+				t.Errorf("GetDistribution() error = %v", err)
+			}
+		})
+	}
+
+	// Let the callers join the in-flight computation before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	if next.calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", next.calls.Load())
+	}
+}
+
+func TestService_GetDistribution_HeadMovesDuringCompute(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{release: make(chan struct{})}
+	svc, blocks, srv := newTestService(t, next)
+
+	done := make(chan error, 1)
+
+	go func() {
+		_, err := svc.GetDistribution(t.Context(), testParams())
+		done <- err
+	}()
+
+	// The head moves while block 100 is being computed; the entry must still hold block 100.
+	for next.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	blocks.block.Store(101)
+	close(next.release)
+
+	if err := <-done; err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	keys := srv.Keys()
+	if next.lastBlock.Load() != 100 || len(keys) != 1 || !strings.HasSuffix(keys[0], ":100") {
+		t.Errorf("computed at block %d and stored under %v, want block 100 under its key", next.lastBlock.Load(), keys)
+	}
+}
+
+func TestService_GetDistribution_RedisDown(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, _, srv := newTestService(t, next)
+	srv.Close()
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v, want computed result", err)
+	}
+
+	if next.calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", next.calls.Load())
+	}
+}
+
+func TestService_GetDistribution_SkipsRedisWhileOpen(t *testing.T) {
+	t.Parallel()
+
+	next := &fakeService{}
+	svc, _, srv := newTestService(t, next)
+
+	now := time.Unix(1000, 0)
+	svc.now = func() time.Time { return now }
+
+	srv.Close()
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v, want computed result", err)
+	}
+
+	// Redis is back, but the open circuit skips it until openPeriod passes.
+	if err := srv.Restart(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if keys := srv.Keys(); len(keys) != 0 {
+		t.Fatalf("stored %v while the circuit is open, want nothing", keys)
+	}
+
+	now = now.Add(openPeriod)
+
+	if _, err := svc.GetDistribution(t.Context(), testParams()); err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if keys := srv.Keys(); len(keys) != 1 {
+		t.Errorf("stored %v after the probe, want one entry", keys)
+	}
+
+	if next.calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", next.calls.Load())
+	}
+}
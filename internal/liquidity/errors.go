@@ -9,6 +9,15 @@ var (
 	// ErrInvalidTickRange is returned when tick range is invalid.
 	ErrInvalidTickRange = errors.New("invalid tick range")
 
+	// ErrInvalidBlock is returned when the requested block or timestamp cannot be read.
+	ErrInvalidBlock = errors.New("invalid block")
+
+	// ErrUnknownPool is returned when a pool ID cannot be resolved to a pool key.
+	ErrUnknownPool = errors.New("unknown pool")
+
+	// ErrPoolIDMismatch is returned when a pool ID and pool key given together disagree.
+	ErrPoolIDMismatch = errors.New("pool id does not match pool key")
+
 	// ErrContractCall is returned when contract call fails.
 	ErrContractCall = errors.New("contract call failed")
 
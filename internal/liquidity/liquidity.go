@@ -5,8 +5,12 @@ package liquidity
 import (
 	"context"
 	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
 
 	"remora/internal/liquidity/poolid"
+	"remora/internal/token"
 )
 
 // Slot0 contains the current state of the pool.
@@ -23,28 +27,43 @@ type TickInfo struct {
 }
 
 // Bin represents a discretized liquidity range.
+// Prices are token1 per token0; inverse prices are token0 per token1, so InversePriceLower
+// corresponds to TickUpper. Prices are empty when token metadata is unavailable.
 type Bin struct {
-	TickLower       int32    `json:"tickLower"`
-	TickUpper       int32    `json:"tickUpper"`
-	ActiveLiquidity *big.Int `json:"activeLiquidity"`
-	PriceLower      string   `json:"priceLower,omitempty"`
-	PriceUpper      string   `json:"priceUpper,omitempty"`
+	TickLower         int32    `json:"tickLower"`
+	TickUpper         int32    `json:"tickUpper"`
+	ActiveLiquidity   *big.Int `json:"activeLiquidity"`
+	PriceLower        string   `json:"priceLower,omitempty"`
+	PriceUpper        string   `json:"priceUpper,omitempty"`
+	InversePriceLower string   `json:"inversePriceLower,omitempty"`
+	InversePriceUpper string   `json:"inversePriceUpper,omitempty"`
 }
 
 // DistributionParams contains parameters for liquidity distribution query.
+// The pool is given by PoolKey, PoolID or both; a bare PoolID is resolved to its key through the
+// pool registry. At most one of BlockNumber and Timestamp may be set; with neither, the latest
+// block is read.
 type DistributionParams struct {
-	PoolKey      poolid.PoolKey `json:"poolKey"`      // Uniswap v4 pool key (PoolId is computed from this)
-	BinSizeTicks int32          `json:"binSizeTicks"` // Size of each bin in ticks
-	TickRange    int32          `json:"tickRange"`    // Range of ticks to scan (±tickRange from current tick)
+	PoolKey      poolid.PoolKey `json:"poolKey"`               // Uniswap v4 pool key (PoolId is computed from this)
+	PoolID       common.Hash    `json:"poolId,omitzero"`       // Must match PoolKey when both are set
+	BinSizeTicks int32          `json:"binSizeTicks"`          // Size of each bin in ticks
+	TickRange    int32          `json:"tickRange"`             // Range of ticks to scan (±tickRange from current tick)
+	BlockNumber  uint64         `json:"blockNumber,omitempty"` // Block to read at
+	Timestamp    time.Time      `json:"timestamp,omitzero"`    // Read at the last block at or before this time
 }
 
 // Distribution contains the liquidity distribution result.
 type Distribution struct {
-	CurrentTick      int32      `json:"currentTick"`
-	SqrtPriceX96     string     `json:"sqrtPriceX96"`
-	Liquidity        string     `json:"liquidity"` // Pool total liquidity L from StateView getLiquidity(poolId)
-	InitializedTicks []TickInfo `json:"initializedTicks"`
-	Bins             []Bin      `json:"bins"`
+	PoolID           string         `json:"poolId"`
+	PoolKey          poolid.PoolKey `json:"poolKey"`               // Canonical key, addresses checksummed
+	BlockNumber      uint64         `json:"blockNumber,omitempty"` // Block the distribution was read at; 0 for latest
+	CurrentTick      int32          `json:"currentTick"`
+	SqrtPriceX96     string         `json:"sqrtPriceX96"`
+	Liquidity        string         `json:"liquidity"` // Pool total liquidity L from StateView getLiquidity(poolId)
+	InitializedTicks []TickInfo     `json:"initializedTicks"`
+	Bins             []Bin          `json:"bins"`
+	Token0           *token.Token   `json:"token0,omitempty"`
+	Token1           *token.Token   `json:"token1,omitempty"`
 }
 
 // Service defines the use cases for liquidity distribution.
@@ -54,22 +73,40 @@ type Service interface {
 
 	// GetSlot0 returns the current pool state (sqrtPriceX96 and tick) for the given pool key.
 	GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*Slot0, error)
+
+	// ResolvePoolKey returns the canonical key of a pool ID, or ErrUnknownPool.
+	ResolvePoolKey(ctx context.Context, id common.Hash) (*poolid.PoolKey, error)
+}
+
+// PoolRegistry maps pool IDs to keys for pools seen on chain.
+type PoolRegistry interface {
+	// PoolKey returns ErrUnknownPool for a pool the registry has not seen.
+	PoolKey(ctx context.Context, id common.Hash) (*poolid.PoolKey, error)
 }
 
 // Repository abstracts blockchain interaction for liquidity data.
+// Reads take the block to read at; nil means the latest block.
 type Repository interface {
-	// GetSlot0 retrieves current pool state (tick and sqrtPrice).
-	GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*Slot0, error)
+	// BlockNumberAt returns the last block with a timestamp at or before t.
+	BlockNumberAt(ctx context.Context, t time.Time) (uint64, error)
+
+	// GetSlot0 retrieves pool state (tick and sqrtPrice). A block the node does not have yet
+	// fails with ErrInvalidBlock.
+	GetSlot0(ctx context.Context, poolKey *poolid.PoolKey, blockNumber *big.Int) (*Slot0, error)
 
 	// GetLiquidity retrieves the pool total liquidity L.
-	GetLiquidity(ctx context.Context, poolKey *poolid.PoolKey) (*big.Int, error)
+	GetLiquidity(ctx context.Context, poolKey *poolid.PoolKey, blockNumber *big.Int) (*big.Int, error)
 
 	// GetTickBitmap retrieves the tick bitmap for a word position.
-	GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey, wordPos int16) (*big.Int, error)
+	GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey, wordPos int16, blockNumber *big.Int) (*big.Int, error)
+
+	// GetTickBitmapBatch retrieves the tick bitmaps for many word positions in batched calls,
+	// keyed by word position. All-zero words are omitted.
+	GetTickBitmapBatch(ctx context.Context, poolKey *poolid.PoolKey, wordPositions []int16, blockNumber *big.Int) (map[int16]*big.Int, error)
 
 	// GetTickInfo retrieves liquidity info for a specific tick.
-	GetTickInfo(ctx context.Context, poolKey *poolid.PoolKey, tick int32) (*TickInfo, error)
+	GetTickInfo(ctx context.Context, poolKey *poolid.PoolKey, tick int32, blockNumber *big.Int) (*TickInfo, error)
 
 	// GetTickInfoBatch retrieves liquidity info for multiple ticks in one call.
-	GetTickInfoBatch(ctx context.Context, poolKey *poolid.PoolKey, ticks []int32) ([]TickInfo, error)
+	GetTickInfoBatch(ctx context.Context, poolKey *poolid.PoolKey, ticks []int32, blockNumber *big.Int) ([]TickInfo, error)
 }
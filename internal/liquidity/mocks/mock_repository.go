@@ -13,9 +13,9 @@ import (
 	context "context"
 	big "math/big"
 	reflect "reflect"
-
 	liquidity "remora/internal/liquidity"
-	"remora/internal/liquidity/poolid"
+	poolid "remora/internal/liquidity/poolid"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -43,77 +43,107 @@ func (m *MockRepository) EXPECT() *MockRepositoryMockRecorder {
 	return m.recorder
 }
 
-// GetSlot0 mocks base method.
-func (m *MockRepository) GetSlot0(arg0 context.Context, arg1 *poolid.PoolKey) (*liquidity.Slot0, error) {
+// BlockNumberAt mocks base method.
+func (m *MockRepository) BlockNumberAt(arg0 context.Context, arg1 time.Time) (uint64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetSlot0", arg0, arg1)
-	ret0, _ := ret[0].(*liquidity.Slot0)
+	ret := m.ctrl.Call(m, "BlockNumberAt", arg0, arg1)
+	ret0, _ := ret[0].(uint64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetSlot0 indicates an expected call of GetSlot0.
-func (mr *MockRepositoryMockRecorder) GetSlot0(arg0, arg1 any) *gomock.Call {
+// BlockNumberAt indicates an expected call of BlockNumberAt.
+func (mr *MockRepositoryMockRecorder) BlockNumberAt(arg0, arg1 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlot0", reflect.TypeOf((*MockRepository)(nil).GetSlot0), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockNumberAt", reflect.TypeOf((*MockRepository)(nil).BlockNumberAt), arg0, arg1)
 }
 
 // GetLiquidity mocks base method.
-func (m *MockRepository) GetLiquidity(arg0 context.Context, arg1 *poolid.PoolKey) (*big.Int, error) {
+func (m *MockRepository) GetLiquidity(arg0 context.Context, arg1 *poolid.PoolKey, arg2 *big.Int) (*big.Int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetLiquidity", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetLiquidity", arg0, arg1, arg2)
 	ret0, _ := ret[0].(*big.Int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetLiquidity indicates an expected call of GetLiquidity.
-func (mr *MockRepositoryMockRecorder) GetLiquidity(arg0, arg1 any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetLiquidity(arg0, arg1, arg2 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiquidity", reflect.TypeOf((*MockRepository)(nil).GetLiquidity), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiquidity", reflect.TypeOf((*MockRepository)(nil).GetLiquidity), arg0, arg1, arg2)
+}
+
+// GetSlot0 mocks base method.
+func (m *MockRepository) GetSlot0(arg0 context.Context, arg1 *poolid.PoolKey, arg2 *big.Int) (*liquidity.Slot0, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSlot0", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*liquidity.Slot0)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSlot0 indicates an expected call of GetSlot0.
+func (mr *MockRepositoryMockRecorder) GetSlot0(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSlot0", reflect.TypeOf((*MockRepository)(nil).GetSlot0), arg0, arg1, arg2)
 }
 
 // GetTickBitmap mocks base method.
-func (m *MockRepository) GetTickBitmap(arg0 context.Context, arg1 *poolid.PoolKey, arg2 int16) (*big.Int, error) {
+func (m *MockRepository) GetTickBitmap(arg0 context.Context, arg1 *poolid.PoolKey, arg2 int16, arg3 *big.Int) (*big.Int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTickBitmap", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetTickBitmap", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*big.Int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTickBitmap indicates an expected call of GetTickBitmap.
-func (mr *MockRepositoryMockRecorder) GetTickBitmap(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetTickBitmap(arg0, arg1, arg2, arg3 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickBitmap", reflect.TypeOf((*MockRepository)(nil).GetTickBitmap), arg0, arg1, arg2, arg3)
+}
+
+// GetTickBitmapBatch mocks base method.
+func (m *MockRepository) GetTickBitmapBatch(arg0 context.Context, arg1 *poolid.PoolKey, arg2 []int16, arg3 *big.Int) (map[int16]*big.Int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTickBitmapBatch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[int16]*big.Int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTickBitmapBatch indicates an expected call of GetTickBitmapBatch.
+func (mr *MockRepositoryMockRecorder) GetTickBitmapBatch(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickBitmap", reflect.TypeOf((*MockRepository)(nil).GetTickBitmap), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickBitmapBatch", reflect.TypeOf((*MockRepository)(nil).GetTickBitmapBatch), arg0, arg1, arg2, arg3)
 }
 
 // GetTickInfo mocks base method.
-func (m *MockRepository) GetTickInfo(arg0 context.Context, arg1 *poolid.PoolKey, arg2 int32) (*liquidity.TickInfo, error) {
+func (m *MockRepository) GetTickInfo(arg0 context.Context, arg1 *poolid.PoolKey, arg2 int32, arg3 *big.Int) (*liquidity.TickInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTickInfo", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetTickInfo", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(*liquidity.TickInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTickInfo indicates an expected call of GetTickInfo.
-func (mr *MockRepositoryMockRecorder) GetTickInfo(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetTickInfo(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickInfo", reflect.TypeOf((*MockRepository)(nil).GetTickInfo), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickInfo", reflect.TypeOf((*MockRepository)(nil).GetTickInfo), arg0, arg1, arg2, arg3)
 }
 
 // GetTickInfoBatch mocks base method.
-func (m *MockRepository) GetTickInfoBatch(arg0 context.Context, arg1 *poolid.PoolKey, arg2 []int32) ([]liquidity.TickInfo, error) {
+func (m *MockRepository) GetTickInfoBatch(arg0 context.Context, arg1 *poolid.PoolKey, arg2 []int32, arg3 *big.Int) ([]liquidity.TickInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTickInfoBatch", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetTickInfoBatch", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].([]liquidity.TickInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTickInfoBatch indicates an expected call of GetTickInfoBatch.
-func (mr *MockRepositoryMockRecorder) GetTickInfoBatch(arg0, arg1, arg2 any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) GetTickInfoBatch(arg0, arg1, arg2, arg3 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickInfoBatch", reflect.TypeOf((*MockRepository)(nil).GetTickInfoBatch), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTickInfoBatch", reflect.TypeOf((*MockRepository)(nil).GetTickInfoBatch), arg0, arg1, arg2, arg3)
 }
@@ -1,14 +1,22 @@
 package poolid
 
 import (
+	"encoding/hex"
 	"errors"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-// ErrInvalidPoolKey is returned when pool key is invalid.
-var ErrInvalidPoolKey = errors.New("invalid pool key")
+var (
+	// ErrInvalidPoolKey is returned when pool key is invalid.
+	ErrInvalidPoolKey = errors.New("invalid pool key")
+
+	// ErrInvalidPoolID is returned when a pool ID is not 32 hex-encoded bytes.
+	ErrInvalidPoolID = errors.New("invalid pool id")
+)
+
+const poolIDHexLen = 2 + 2*common.HashLength
 
 // PoolKey identifies a Uniswap v4 pool.
 // Must have currency0 < currency1 (by address).
@@ -32,6 +40,17 @@ func ValidatePoolKey(key *PoolKey) error {
 	return nil
 }
 
+// Canonical returns key with checksummed addresses, the form responses report it in.
+func Canonical(key *PoolKey) PoolKey {
+	return PoolKey{
+		Currency0:   common.HexToAddress(key.Currency0).Hex(),
+		Currency1:   common.HexToAddress(key.Currency1).Hex(),
+		Fee:         key.Fee,
+		TickSpacing: key.TickSpacing,
+		Hooks:       common.HexToAddress(key.Hooks).Hex(),
+	}
+}
+
 // CalculatePoolID computes the PoolId from a PoolKey per Uniswap v4 spec.
 // PoolId = keccak256(abi.encode(poolKey)).
 // See: https://github.com/Uniswap/v4-core/blob/main/src/types/PoolId.sol
@@ -96,3 +115,21 @@ func CalculatePoolID(key *PoolKey) [32]byte {
 
 	return [32]byte(hash)
 }
+
+// ParsePoolID parses a 0x-prefixed, 32-byte hex pool ID.
+func ParsePoolID(s string) (common.Hash, error) {
+	if len(s) != poolIDHexLen || !has0xPrefix(s) {
+		return common.Hash{}, ErrInvalidPoolID
+	}
+
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return common.Hash{}, ErrInvalidPoolID
+	}
+
+	return common.BytesToHash(b), nil
+}
+
+func has0xPrefix(s string) bool {
+	return s[0] == '0' && (s[1] == 'x' || s[1] == 'X')
+}
@@ -0,0 +1,23 @@
+package liquidity
+
+import (
+	"math"
+	"strconv"
+)
+
+const (
+	tickBase = 1.0001
+
+	// priceDigits is the number of significant digits in formatted prices.
+	priceDigits = 10
+)
+
+// TickPrice returns the price of token0 in token1 at tick, adjusted for token decimals.
+func TickPrice(tick int32, decimals0, decimals1 uint8) float64 {
+	return math.Pow(tickBase, float64(tick)) * math.Pow10(int(decimals0)-int(decimals1))
+}
+
+// FormatPrice formats a price for API responses.
+func FormatPrice(p float64) string {
+	return strconv.FormatFloat(p, 'g', priceDigits, 64)
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity"
+)
+
+// HeaderSource reads block headers.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// BlockNumberAt returns the last block with a timestamp at or before t.
+func (r *Repository) BlockNumberAt(ctx context.Context, t time.Time) (uint64, error) {
+	// Mock mode for testing
+	if r.client == nil {
+		return 0, nil
+	}
+
+	return blockNumberAt(ctx, r.client, t)
+}
+
+// missingBlockErrors are node errors for a block above its head.
+var missingBlockErrors = []string{"header not found", "block not found", "unknown block"}
+
+// blockError wraps err in ErrInvalidBlock when the node does not have the requested block, so
+// a client asking for a future block gets a 400 rather than a 500.
+func blockError(err error, blockNumber *big.Int) error {
+	if blockNumber == nil {
+		return err
+	}
+
+	message := strings.ToLower(err.Error())
+
+	for _, s := range missingBlockErrors {
+		if strings.Contains(message, s) {
+			return fmt.Errorf("%w: block %s: %w", liquidity.ErrInvalidBlock, blockNumber, err)
+		}
+	}
+
+	return err
+}
+
+// blockNumberAt binary searches headers between genesis and the latest block, so it costs
+// about log2(height) header reads.
+func blockNumberAt(ctx context.Context, headers HeaderSource, t time.Time) (uint64, error) {
+	if t.After(time.Now()) {
+		return 0, fmt.Errorf("%w: %s is in the future", liquidity.ErrInvalidBlock, t.UTC().Format(time.RFC3339))
+	}
+
+	target := uint64(t.Unix()) //nolint:gosec // t is after the epoch for any chain
+
+	latest, err := headers.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("get latest header: %w", err)
+	}
+
+	if latest.Time <= target {
+		return latest.Number.Uint64(), nil
+	}
+
+	genesis, err := headers.HeaderByNumber(ctx, new(big.Int))
+	if err != nil {
+		return 0, fmt.Errorf("get genesis header: %w", err)
+	}
+
+	if genesis.Time > target {
+		return 0, fmt.Errorf("%w: %s is before genesis", liquidity.ErrInvalidBlock, t.UTC().Format(time.RFC3339))
+	}
+
+	// Invariant: header(lo).Time <= target < header(hi).Time.
+	lo, hi := uint64(0), latest.Number.Uint64()
+
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+
+		h, err := headers.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return 0, fmt.Errorf("get header %d: %w", mid, err)
+		}
+
+		if h.Time <= target {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo, nil
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity"
+)
+
+// fakeHeaders is a chain of 1000 blocks, two seconds apart from genesisTime.
+type fakeHeaders struct{ reads int }
+
+const (
+	fakeHeight  = 999
+	genesisTime = 1_700_000_000
+)
+
+func (f *fakeHeaders) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	f.reads++
+
+	n := uint64(fakeHeight)
+	if number != nil {
+		n = number.Uint64()
+	}
+
+	return &types.Header{Number: new(big.Int).SetUint64(n), Time: genesisTime + 2*n}, nil
+}
+
+func TestBlockNumberAt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		at   int64
+		want uint64
+	}{
+		{"genesis", genesisTime, 0},
+		{"exact block", genesisTime + 2*500, 500},
+		{"between blocks", genesisTime + 2*500 + 1, 500},
+		{"after latest", genesisTime + 2*fakeHeight + 60, fakeHeight},
+	}
+
+	for _, tt := range tests {
+		headers := &fakeHeaders{}
+
+		got, err := blockNumberAt(t.Context(), headers, time.Unix(tt.at, 0))
+		if err != nil || got != tt.want {
+			t.Errorf("%s: blockNumberAt() = %d, %v, want %d", tt.name, got, err, tt.want)
+		}
+
+		if headers.reads > 14 {
+			t.Errorf("%s: %d header reads, want a binary search", tt.name, headers.reads)
+		}
+	}
+
+	if _, err := blockNumberAt(t.Context(), &fakeHeaders{}, time.Unix(genesisTime-1, 0)); !errors.Is(err, liquidity.ErrInvalidBlock) {
+		t.Errorf("before genesis error = %v, want %v", err, liquidity.ErrInvalidBlock)
+	}
+
+	if _, err := blockNumberAt(t.Context(), &fakeHeaders{}, time.Now().Add(time.Hour)); !errors.Is(err, liquidity.ErrInvalidBlock) {
+		t.Errorf("future error = %v, want %v", err, liquidity.ErrInvalidBlock)
+	}
+}
+
+func TestBlockError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		err     error
+		block   *big.Int
+		invalid bool
+	}{
+		{name: "header not found", err: errors.New("header not found"), block: big.NewInt(200), invalid: true},
+		{name: "unknown block", err: errors.New("Unknown block"), block: big.NewInt(200), invalid: true},
+		{name: "latest", err: errors.New("header not found"), block: nil},
+		{name: "other error", err: errors.New("execution reverted"), block: big.NewInt(200)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := blockError(tt.err, tt.block)
+			if got := errors.Is(err, liquidity.ErrInvalidBlock); got != tt.invalid {
+				t.Errorf("blockError() = %v, invalid = %v, want %v", err, got, tt.invalid)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Errorf("blockError() = %v, want it to wrap %v", err, tt.err)
+			}
+		})
+	}
+}
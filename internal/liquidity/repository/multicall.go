@@ -7,46 +7,35 @@ import (
 	"strings"
 	"sync"
 
-	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
-	"github.com/ethereum/go-ethereum/common"
 
 	"remora/internal/liquidity"
 	"remora/internal/liquidity/poolid"
+	"remora/internal/multicall"
 )
 
-const multicallChunkSize = 30 // ticks per multicall batch
-
-// Multicall3 is deployed at a deterministic address on all EVM chains.
-var multicall3Addr = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
-
-// Minimal ABIs for encoding/decoding.
-var (
-	multicall3ABI abi.ABI
-	stateViewABI  abi.ABI
+const (
+	multicallChunkSize = 30  // ticks per multicall batch
+	bitmapChunkSize    = 100 // bitmap words per multicall batch; each is a single storage read
 )
 
+// Minimal ABI for encoding/decoding.
+var stateViewABI abi.ABI
+
 func init() {
 	var err error
 
-	multicall3ABI, err = abi.JSON(strings.NewReader(`[{
-		"name":"aggregate3",
-		"type":"function",
-		"inputs":[{"name":"calls","type":"tuple[]","components":[
-			{"name":"target","type":"address"},
-			{"name":"allowFailure","type":"bool"},
-			{"name":"callData","type":"bytes"}
-		]}],
-		"outputs":[{"name":"returnData","type":"tuple[]","components":[
-			{"name":"success","type":"bool"},
-			{"name":"returnData","type":"bytes"}
-		]}]
-	}]`))
-	if err != nil {
-		panic("parse multicall3 abi: " + err.Error())
-	}
-
 	stateViewABI, err = abi.JSON(strings.NewReader(`[{
+		"name":"getTickBitmap",
+		"type":"function",
+		"inputs":[
+			{"name":"poolId","type":"bytes32"},
+			{"name":"tick","type":"int16"}
+		],
+		"outputs":[
+			{"name":"tickBitmap","type":"uint256"}
+		]
+	},{
 		"name":"getTickInfo",
 		"type":"function",
 		"inputs":[
@@ -65,17 +54,10 @@ func init() {
 	}
 }
 
-// multicall3Call matches the Multicall3.Call3 struct layout for ABI encoding.
-type multicall3Call struct {
-	Target       common.Address
-	AllowFailure bool
-	CallData     []byte
-}
-
 // GetTickInfoBatch fetches tick info for multiple ticks using Multicall3.
 // Ticks are split into chunks and fetched in parallel to avoid slow single-call
 // execution on forked nodes where each storage read hits the remote RPC.
-func (r *Repository) GetTickInfoBatch(ctx context.Context, poolKey *poolid.PoolKey, ticks []int32) ([]liquidity.TickInfo, error) {
+func (r *Repository) GetTickInfoBatch(ctx context.Context, poolKey *poolid.PoolKey, ticks []int32, blockNumber *big.Int) ([]liquidity.TickInfo, error) {
 	if len(ticks) == 0 {
 		return nil, nil
 	}
@@ -96,94 +78,83 @@ func (r *Repository) GetTickInfoBatch(ctx context.Context, poolKey *poolid.PoolK
 
 	poolID := poolid.CalculatePoolID(poolKey)
 
-	// Split into chunks and fetch in parallel.
-	type chunkResult struct {
-		index int
-		infos []liquidity.TickInfo
-		err   error
-	}
-
-	chunks := chunkSlice(ticks, multicallChunkSize)
-	results := make(chan chunkResult, len(chunks))
-
-	var wg sync.WaitGroup
-	for i, chunk := range chunks {
-		wg.Add(1)
+	return fetchChunks(ticks, multicallChunkSize, func(chunk []int32) ([]liquidity.TickInfo, error) {
+		return r.fetchTickInfoChunk(ctx, poolID, chunk, blockNumber)
+	})
+}
 
-		go func(idx int, tickChunk []int32) {
-			defer wg.Done()
+// GetTickBitmapBatch fetches the tick bitmap words at wordPositions using Multicall3, in
+// parallel chunks like GetTickInfoBatch. All-zero words hold no initialized ticks and are
+// left out of the result.
+func (r *Repository) GetTickBitmapBatch(ctx context.Context, poolKey *poolid.PoolKey, wordPositions []int16, blockNumber *big.Int) (map[int16]*big.Int, error) {
+	bitmaps := make(map[int16]*big.Int)
 
-			infos, err := r.fetchTickInfoChunk(ctx, poolID, tickChunk)
-			results <- chunkResult{index: idx, infos: infos, err: err}
-		}(i, chunk)
+	// Mock mode for testing
+	if r.contract == nil || len(wordPositions) == 0 {
+		return bitmaps, nil
 	}
 
-	wg.Wait()
-	close(results)
-
-	// Reassemble in order.
-	ordered := make([][]liquidity.TickInfo, len(chunks))
-
-	for res := range results {
-		if res.err != nil {
-			return nil, res.err
-		}
+	poolID := poolid.CalculatePoolID(poolKey)
 
-		ordered[res.index] = res.infos
+	words, err := fetchChunks(wordPositions, bitmapChunkSize, func(chunk []int16) ([]*big.Int, error) {
+		return r.fetchTickBitmapChunk(ctx, poolID, chunk, blockNumber)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	tickInfos := make([]liquidity.TickInfo, 0, len(ticks))
-	for _, infos := range ordered {
-		tickInfos = append(tickInfos, infos...)
+	for i, word := range words {
+		if word.Sign() != 0 {
+			bitmaps[wordPositions[i]] = word
+		}
 	}
 
-	return tickInfos, nil
+	return bitmaps, nil
 }
 
-// fetchTickInfoChunk executes a single multicall3 batch for a chunk of ticks.
-func (r *Repository) fetchTickInfoChunk(ctx context.Context, poolID [32]byte, ticks []int32) ([]liquidity.TickInfo, error) {
-	calls := make([]multicall3Call, len(ticks))
-	for i, tick := range ticks {
-		callData, err := stateViewABI.Pack("getTickInfo", poolID, big.NewInt(int64(tick)))
+// fetchTickBitmapChunk executes a single multicall3 batch for a chunk of word positions.
+func (r *Repository) fetchTickBitmapChunk(ctx context.Context, poolID [32]byte, wordPositions []int16, blockNumber *big.Int) ([]*big.Int, error) {
+	calls := make([]multicall.Call, len(wordPositions))
+	for i, wordPos := range wordPositions {
+		call, err := multicall.NewCall(&stateViewABI, r.stateViewAddr, "getTickBitmap", poolID, wordPos)
 		if err != nil {
-			return nil, fmt.Errorf("pack getTickInfo for tick %d: %w", tick, err)
+			return nil, fmt.Errorf("word %d: %w", wordPos, err)
 		}
 
-		calls[i] = multicall3Call{
-			Target:       r.stateViewAddr,
-			AllowFailure: false,
-			CallData:     callData,
-		}
+		calls[i] = call
 	}
 
-	input, err := multicall3ABI.Pack("aggregate3", calls)
+	resultsRaw, err := multicall.Aggregate3(ctx, r.client, calls, blockNumber)
 	if err != nil {
-		return nil, fmt.Errorf("pack aggregate3: %w", err)
+		return nil, err
 	}
 
-	output, err := r.client.CallContract(ctx, ethereum.CallMsg{
-		To:   &multicall3Addr,
-		Data: input,
-	}, nil)
-	if err != nil {
-		return nil, fmt.Errorf("multicall3 aggregate3: %w", err)
-	}
+	words := make([]*big.Int, len(wordPositions))
 
-	decoded, err := multicall3ABI.Unpack("aggregate3", output)
-	if err != nil {
-		return nil, fmt.Errorf("unpack aggregate3: %w", err)
+	for i, res := range resultsRaw {
+		if err := multicall.Decode(&stateViewABI, "getTickBitmap", res, &words[i]); err != nil {
+			return nil, fmt.Errorf("word %d: %w", wordPositions[i], err)
+		}
 	}
 
-	resultsRaw, ok := decoded[0].([]struct {
-		Success    bool   `json:"success"`
-		ReturnData []byte `json:"returnData"`
-	})
-	if !ok {
-		return nil, fmt.Errorf("unexpected aggregate3 result type: %T", decoded[0])
+	return words, nil
+}
+
+// fetchTickInfoChunk executes a single multicall3 batch for a chunk of ticks.
+func (r *Repository) fetchTickInfoChunk(ctx context.Context, poolID [32]byte, ticks []int32, blockNumber *big.Int) ([]liquidity.TickInfo, error) {
+	calls := make([]multicall.Call, len(ticks))
+	for i, tick := range ticks {
+		call, err := multicall.NewCall(&stateViewABI, r.stateViewAddr, "getTickInfo", poolID, big.NewInt(int64(tick)))
+		if err != nil {
+			return nil, fmt.Errorf("tick %d: %w", tick, err)
+		}
+
+		calls[i] = call
 	}
 
-	if len(resultsRaw) != len(ticks) {
-		return nil, fmt.Errorf("multicall3 returned %d results, expected %d", len(resultsRaw), len(ticks))
+	resultsRaw, err := multicall.Aggregate3(ctx, r.client, calls, blockNumber)
+	if err != nil {
+		return nil, err
 	}
 
 	tickInfos := make([]liquidity.TickInfo, len(ticks))
@@ -211,17 +182,55 @@ func (r *Repository) fetchTickInfoChunk(ctx context.Context, poolID [32]byte, ti
 	return tickInfos, nil
 }
 
-// chunkSlice splits a slice into chunks of the given size.
-func chunkSlice(ticks []int32, size int) [][]int32 {
-	var chunks [][]int32
+// fetchChunks splits items into chunks of size, fetches them in parallel and returns the
+// results in input order. Each chunk must return one result per item.
+func fetchChunks[T, R any](items []T, size int, fetch func(chunk []T) ([]R, error)) ([]R, error) {
+	type chunkResult struct {
+		index   int
+		results []R
+		err     error
+	}
+
+	chunks := chunkSlice(items, size)
+	results := make(chan chunkResult, len(chunks))
 
-	for i := 0; i < len(ticks); i += size {
-		end := i + size
-		if end > len(ticks) {
-			end = len(ticks)
+	var wg sync.WaitGroup
+	for i, chunk := range chunks {
+		wg.Go(func() {
+			res, err := fetch(chunk)
+			results <- chunkResult{index: i, results: res, err: err}
+		})
+	}
+
+	wg.Wait()
+	close(results)
+
+	// Reassemble in order.
+	ordered := make([][]R, len(chunks))
+
+	for res := range results {
+		if res.err != nil {
+			return nil, res.err
 		}
 
-		chunks = append(chunks, ticks[i:end])
+		ordered[res.index] = res.results
+	}
+
+	out := make([]R, 0, len(items))
+	for _, res := range ordered {
+		out = append(out, res...)
+	}
+
+	return out, nil
+}
+
+// chunkSlice splits a slice into chunks of the given size.
+func chunkSlice[T any](items []T, size int) [][]T {
+	var chunks [][]T
+
+	for i := 0; i < len(items); i += size {
+		end := min(i+size, len(items))
+		chunks = append(chunks, items[i:end])
 	}
 
 	return chunks
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFetchChunks(t *testing.T) {
+	t.Parallel()
+
+	items := make([]int16, 250)
+	for i := range items {
+		items[i] = int16(i)
+	}
+
+	var sizes []int
+
+	got, err := fetchChunks(items, 100, func(chunk []int16) ([]int, error) {
+		out := make([]int, len(chunk))
+		for i, v := range chunk {
+			out[i] = int(v) * 2
+		}
+
+		return out, nil
+	})
+	if err != nil {
+		t.Fatalf("fetchChunks() error = %v", err)
+	}
+
+	for i, v := range got {
+		if v != i*2 {
+			t.Fatalf("result %d = %d, want %d (order not preserved)", i, v, i*2)
+		}
+	}
+
+	for _, c := range chunkSlice(items, 100) {
+		sizes = append(sizes, len(c))
+	}
+
+	if !slices.Equal(sizes, []int{100, 100, 50}) {
+		t.Errorf("chunk sizes = %v, want [100 100 50]", sizes)
+	}
+
+	errChunk := errors.New("chunk failed")
+
+	_, err = fetchChunks(items, 100, func(chunk []int16) ([]int, error) {
+		if chunk[0] == 100 {
+			return nil, errChunk
+		}
+
+		return make([]int, len(chunk)), nil
+	})
+	if !errors.Is(err, errChunk) {
+		t.Errorf("fetchChunks() error = %v, want %v", err, errChunk)
+	}
+}
@@ -23,23 +23,16 @@ type Repository struct {
 
 // Config contains configuration for repository.
 type Config struct {
-	RPCURL          string
 	ContractAddress string
 }
 
-// New creates a new liquidity repository with ethereum client.
-func New(cfg Config) (*Repository, error) {
-	client, err := ethclient.Dial(cfg.RPCURL)
-	if err != nil {
-		return nil, fmt.Errorf("dial rpc: %w", err)
-	}
-
+// New creates a new liquidity repository reading through client. The client is shared and
+// stays owned by the caller.
+func New(client *ethclient.Client, cfg Config) (*Repository, error) {
 	contractAddr := common.HexToAddress(cfg.ContractAddress)
 
 	contract, err := contracts.NewStateView(contractAddr, client)
 	if err != nil {
-		client.Close()
-
 		return nil, fmt.Errorf("create contract: %w", err)
 	}
 
@@ -58,18 +51,31 @@ func NewMock() *Repository {
 	}
 }
 
-// Close closes the repository and its underlying connections.
-func (r *Repository) Close() {
-	if r.client != nil {
-		r.client.Close()
-	}
-}
-
 // Ensure Repository implements liquidity.Repository.
 var _ liquidity.Repository = (*Repository)(nil)
 
-// GetSlot0 retrieves current pool state (tick and sqrtPrice).
-func (r *Repository) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*liquidity.Slot0, error) {
+// callOpts reads at blockNumber, or the latest block when nil.
+func callOpts(ctx context.Context, blockNumber *big.Int) *bind.CallOpts {
+	return &bind.CallOpts{Context: ctx, BlockNumber: blockNumber}
+}
+
+// BlockNumber returns the latest block number.
+func (r *Repository) BlockNumber(ctx context.Context) (uint64, error) {
+	// Mock mode for testing
+	if r.client == nil {
+		return 0, nil
+	}
+
+	block, err := r.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get block number: %w", err)
+	}
+
+	return block, nil
+}
+
+// GetSlot0 retrieves pool state (tick and sqrtPrice).
+func (r *Repository) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey, blockNumber *big.Int) (*liquidity.Slot0, error) {
 	// Mock mode for testing
 	if r.contract == nil {
 		return &liquidity.Slot0{
@@ -80,9 +86,10 @@ func (r *Repository) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*li
 
 	poolID := poolid.CalculatePoolID(poolKey)
 
-	result, err := r.contract.GetSlot0(&bind.CallOpts{Context: ctx}, poolID)
+	// Slot0 is the first read of a distribution, so it is where a block past the head surfaces.
+	result, err := r.contract.GetSlot0(callOpts(ctx, blockNumber), poolID)
 	if err != nil {
-		return nil, fmt.Errorf("get slot0: %w", err)
+		return nil, fmt.Errorf("get slot0: %w", blockError(err, blockNumber))
 	}
 
 	//nolint:gosec // G115: Tick is int24 in Solidity, safe to convert to int32
@@ -93,14 +100,14 @@ func (r *Repository) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*li
 }
 
 // GetLiquidity retrieves the pool total liquidity L.
-func (r *Repository) GetLiquidity(ctx context.Context, poolKey *poolid.PoolKey) (*big.Int, error) {
+func (r *Repository) GetLiquidity(ctx context.Context, poolKey *poolid.PoolKey, blockNumber *big.Int) (*big.Int, error) {
 	if r.contract == nil {
 		return big.NewInt(0), nil
 	}
 
 	poolID := poolid.CalculatePoolID(poolKey)
 
-	liquidity, err := r.contract.GetLiquidity(&bind.CallOpts{Context: ctx}, poolID)
+	liquidity, err := r.contract.GetLiquidity(callOpts(ctx, blockNumber), poolID)
 	if err != nil {
 		return nil, fmt.Errorf("get liquidity: %w", err)
 	}
@@ -109,7 +116,7 @@ func (r *Repository) GetLiquidity(ctx context.Context, poolKey *poolid.PoolKey)
 }
 
 // GetTickBitmap retrieves the tick bitmap for a word position.
-func (r *Repository) GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey, wordPos int16) (*big.Int, error) {
+func (r *Repository) GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey, wordPos int16, blockNumber *big.Int) (*big.Int, error) {
 	// Mock mode for testing
 	if r.contract == nil {
 		return big.NewInt(0), nil
@@ -117,7 +124,7 @@ func (r *Repository) GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey,
 
 	poolID := poolid.CalculatePoolID(poolKey)
 
-	bitmap, err := r.contract.GetTickBitmap(&bind.CallOpts{Context: ctx}, poolID, wordPos)
+	bitmap, err := r.contract.GetTickBitmap(callOpts(ctx, blockNumber), poolID, wordPos)
 	if err != nil {
 		return nil, fmt.Errorf("get tick bitmap: %w", err)
 	}
@@ -126,7 +133,7 @@ func (r *Repository) GetTickBitmap(ctx context.Context, poolKey *poolid.PoolKey,
 }
 
 // GetTickInfo retrieves liquidity info for a specific tick.
-func (r *Repository) GetTickInfo(ctx context.Context, poolKey *poolid.PoolKey, tick int32) (*liquidity.TickInfo, error) {
+func (r *Repository) GetTickInfo(ctx context.Context, poolKey *poolid.PoolKey, tick int32, blockNumber *big.Int) (*liquidity.TickInfo, error) {
 	// Mock mode for testing
 	if r.contract == nil {
 		return &liquidity.TickInfo{
@@ -138,7 +145,7 @@ func (r *Repository) GetTickInfo(ctx context.Context, poolKey *poolid.PoolKey, t
 
 	poolID := poolid.CalculatePoolID(poolKey)
 
-	result, err := r.contract.GetTickInfo(&bind.CallOpts{Context: ctx}, poolID, big.NewInt(int64(tick)))
+	result, err := r.contract.GetTickInfo(callOpts(ctx, blockNumber), poolID, big.NewInt(int64(tick)))
 	if err != nil {
 		return nil, fmt.Errorf("get tick info: %w", err)
 	}
@@ -7,19 +7,27 @@ import (
 	"math/big"
 	"sort"
 
+	"github.com/ethereum/go-ethereum/common"
+
 	"remora/internal/liquidity"
 	"remora/internal/liquidity/poolid"
+	"remora/internal/token"
 )
 
 // Service implements liquidity.Service.
 type Service struct {
-	repo liquidity.Repository
+	repo   liquidity.Repository
+	tokens token.Service
+	pools  liquidity.PoolRegistry
 }
 
-// New creates a new liquidity service.
-func New(repo liquidity.Repository) *Service {
+// New creates a new liquidity service. tokens may be nil, in which case bins carry no prices;
+// pools may be nil, in which case requests must carry the full pool key.
+func New(repo liquidity.Repository, tokens token.Service, pools liquidity.PoolRegistry) *Service {
 	return &Service{
-		repo: repo,
+		repo:   repo,
+		tokens: tokens,
+		pools:  pools,
 	}
 }
 
@@ -28,7 +36,23 @@ var _ liquidity.Service = (*Service)(nil)
 
 // GetSlot0 returns the current pool state for the given pool key.
 func (s *Service) GetSlot0(ctx context.Context, poolKey *poolid.PoolKey) (*liquidity.Slot0, error) {
-	return s.repo.GetSlot0(ctx, poolKey)
+	return s.repo.GetSlot0(ctx, poolKey, nil)
+}
+
+// ResolvePoolKey returns the canonical key of a pool ID from the pool registry.
+func (s *Service) ResolvePoolKey(ctx context.Context, id common.Hash) (*poolid.PoolKey, error) {
+	if s.pools == nil {
+		return nil, fmt.Errorf("%w: %s: no pool registry", liquidity.ErrUnknownPool, id.Hex())
+	}
+
+	key, err := s.pools.PoolKey(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("resolve pool %s: %w", id.Hex(), err)
+	}
+
+	canonical := poolid.Canonical(key)
+
+	return &canonical, nil
 }
 
 // GetDistribution returns the liquidity distribution for a pool.
@@ -37,9 +61,16 @@ func (s *Service) GetDistribution(ctx context.Context, params *liquidity.Distrib
 		return nil, err
 	}
 
-	poolKey := &params.PoolKey
+	key, err := s.poolKey(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	poolKey := &key
+	poolID := common.Hash(poolid.CalculatePoolID(poolKey))
 
 	slog.Info("liquidity distribution start",
+		slog.String("pool_id", poolID.Hex()),
 		slog.String("currency0", poolKey.Currency0),
 		slog.String("currency1", poolKey.Currency1),
 		slog.Int("tick_spacing", int(poolKey.TickSpacing)),
@@ -47,8 +78,14 @@ func (s *Service) GetDistribution(ctx context.Context, params *liquidity.Distrib
 		slog.Int("tick_range", int(params.TickRange)),
 	)
 
+	// Step 0: Resolve the block to read at; every read below uses it so the result is consistent.
+	block, err := s.resolveBlock(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
 	// Step 1: Get current pool state (tick and sqrtPrice)
-	slot0, err := s.repo.GetSlot0(ctx, poolKey)
+	slot0, err := s.repo.GetSlot0(ctx, poolKey, block)
 	if err != nil {
 		return nil, fmt.Errorf("get slot0: %w", err)
 	}
@@ -59,7 +96,7 @@ func (s *Service) GetDistribution(ctx context.Context, params *liquidity.Distrib
 	)
 
 	// Step 1b: Get pool total liquidity L
-	poolLiquidity, err := s.repo.GetLiquidity(ctx, poolKey)
+	poolLiquidity, err := s.repo.GetLiquidity(ctx, poolKey, block)
 	if err != nil {
 		return nil, fmt.Errorf("get liquidity: %w", err)
 	}
@@ -67,7 +104,7 @@ func (s *Service) GetDistribution(ctx context.Context, params *liquidity.Distrib
 	slog.Info("liquidity pool total", slog.String("liquidity", poolLiquidity.String()))
 
 	// Step 2: Read initialized ticks in the specified range
-	ticks, err := s.getInitializedTicks(ctx, poolKey, slot0.Tick, params.TickRange, poolKey.TickSpacing)
+	ticks, err := s.getInitializedTicks(ctx, poolKey, slot0.Tick, params.TickRange, poolKey.TickSpacing, block)
 	if err != nil {
 		return nil, fmt.Errorf("get initialized ticks: %w", err)
 	}
@@ -82,13 +119,56 @@ func (s *Service) GetDistribution(ctx context.Context, params *liquidity.Distrib
 
 	slog.Info("liquidity bins aggregated", slog.Int("count", len(bins)))
 
-	return &liquidity.Distribution{
+	dist := &liquidity.Distribution{
+		PoolID:           poolID.Hex(),
+		PoolKey:          key,
+		BlockNumber:      blockNumber(block),
 		CurrentTick:      slot0.Tick,
 		SqrtPriceX96:     slot0.SqrtPriceX96.String(),
 		Liquidity:        poolLiquidity.String(),
 		InitializedTicks: ticks,
 		Bins:             bins,
-	}, nil
+	}
+
+	// Step 5: Price the bins. Prices are informational, so a metadata failure only drops them.
+	if err := s.fillPrices(ctx, dist, poolKey); err != nil {
+		slog.WarnContext(ctx, "liquidity bin prices unavailable", slog.Any("error", err))
+	}
+
+	return dist, nil
+}
+
+// fillPrices resolves the pool tokens and sets decimal-adjusted prices on every bin.
+func (s *Service) fillPrices(ctx context.Context, dist *liquidity.Distribution, poolKey *poolid.PoolKey) error {
+	if s.tokens == nil {
+		return nil
+	}
+
+	token0, err := s.tokens.Get(ctx, common.HexToAddress(poolKey.Currency0))
+	if err != nil {
+		return fmt.Errorf("get token0: %w", err)
+	}
+
+	token1, err := s.tokens.Get(ctx, common.HexToAddress(poolKey.Currency1))
+	if err != nil {
+		return fmt.Errorf("get token1: %w", err)
+	}
+
+	dist.Token0 = token0
+	dist.Token1 = token1
+
+	for i := range dist.Bins {
+		b := &dist.Bins[i]
+		lower := liquidity.TickPrice(b.TickLower, token0.Decimals, token1.Decimals)
+		upper := liquidity.TickPrice(b.TickUpper, token0.Decimals, token1.Decimals)
+
+		b.PriceLower = liquidity.FormatPrice(lower)
+		b.PriceUpper = liquidity.FormatPrice(upper)
+		b.InversePriceLower = liquidity.FormatPrice(1 / upper)
+		b.InversePriceUpper = liquidity.FormatPrice(1 / lower)
+	}
+
+	return nil
 }
 
 // validateParams validates distribution parameters.
@@ -101,15 +181,67 @@ func validateParams(params *liquidity.DistributionParams) error {
 		return liquidity.ErrInvalidTickRange
 	}
 
-	if err := poolid.ValidatePoolKey(&params.PoolKey); err != nil {
-		return fmt.Errorf("validate pool key: %w", err)
+	if params.BlockNumber != 0 && !params.Timestamp.IsZero() {
+		return fmt.Errorf("%w: set either block number or timestamp", liquidity.ErrInvalidBlock)
 	}
 
 	return nil
 }
 
+// poolKey returns the canonical key of the pool params name. A bare pool ID is resolved through
+// the registry; a pool ID given alongside a key must match it.
+func (s *Service) poolKey(ctx context.Context, params *liquidity.DistributionParams) (poolid.PoolKey, error) {
+	if params.PoolKey == (poolid.PoolKey{}) && params.PoolID != (common.Hash{}) {
+		key, err := s.ResolvePoolKey(ctx, params.PoolID)
+		if err != nil {
+			return poolid.PoolKey{}, err
+		}
+
+		return *key, nil
+	}
+
+	if err := poolid.ValidatePoolKey(&params.PoolKey); err != nil {
+		return poolid.PoolKey{}, fmt.Errorf("validate pool key: %w", err)
+	}
+
+	if params.PoolID != (common.Hash{}) && common.Hash(poolid.CalculatePoolID(&params.PoolKey)) != params.PoolID {
+		return poolid.PoolKey{}, fmt.Errorf("%w: %s", liquidity.ErrPoolIDMismatch, params.PoolID.Hex())
+	}
+
+	return poolid.Canonical(&params.PoolKey), nil
+}
+
+// resolveBlock returns the block params ask for, resolving a timestamp to the last block at or
+// before it. It returns nil for the latest block.
+func (s *Service) resolveBlock(ctx context.Context, params *liquidity.DistributionParams) (*big.Int, error) {
+	switch {
+	case params.BlockNumber != 0:
+		return new(big.Int).SetUint64(params.BlockNumber), nil
+	case !params.Timestamp.IsZero():
+		n, err := s.repo.BlockNumberAt(ctx, params.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("resolve timestamp: %w", err)
+		}
+
+		slog.Info("liquidity timestamp resolved", slog.Time("timestamp", params.Timestamp), slog.Uint64("block", n))
+
+		return new(big.Int).SetUint64(n), nil
+	default:
+		return nil, nil
+	}
+}
+
+// blockNumber returns block as a number, 0 for the latest block.
+func blockNumber(block *big.Int) uint64 {
+	if block == nil {
+		return 0
+	}
+
+	return block.Uint64()
+}
+
 // getInitializedTicks retrieves all initialized ticks in the range [currentTick - tickRange, currentTick + tickRange].
-func (s *Service) getInitializedTicks(ctx context.Context, poolKey *poolid.PoolKey, currentTick, tickRange, tickSpacing int32) ([]liquidity.TickInfo, error) {
+func (s *Service) getInitializedTicks(ctx context.Context, poolKey *poolid.PoolKey, currentTick, tickRange, tickSpacing int32, block *big.Int) ([]liquidity.TickInfo, error) {
 	// Calculate the range of word positions to scan
 	tickLower := currentTick - tickRange
 	tickUpper := currentTick + tickRange
@@ -118,13 +250,24 @@ func (s *Service) getInitializedTicks(ctx context.Context, poolKey *poolid.PoolK
 	wordPosLower := s.getWordPos(tickLower, tickSpacing)
 	wordPosUpper := s.getWordPos(tickUpper, tickSpacing)
 
-	// Phase 1: Collect all initialized tick indices from bitmaps.
+	// Phase 1: Collect all initialized tick indices from bitmaps, read in one batched call.
+	wordPositions := make([]int16, 0, int(wordPosUpper)-int(wordPosLower)+1)
+	for wordPos := int(wordPosLower); wordPos <= int(wordPosUpper); wordPos++ {
+		wordPositions = append(wordPositions, int16(wordPos)) //nolint:gosec // within [wordPosLower, wordPosUpper]
+	}
+
+	bitmaps, err := s.repo.GetTickBitmapBatch(ctx, poolKey, wordPositions, block)
+	if err != nil {
+		return nil, fmt.Errorf("get tick bitmaps: %w", err)
+	}
+
 	var tickIndices []int32
 
-	for wordPos := wordPosLower; wordPos <= wordPosUpper; wordPos++ {
-		bitmap, err := s.repo.GetTickBitmap(ctx, poolKey, wordPos)
-		if err != nil {
-			return nil, fmt.Errorf("get tick bitmap for wordPos %d: %w", wordPos, err)
+	// Only words with initialized ticks are returned; walk them in order.
+	for _, wordPos := range wordPositions {
+		bitmap, ok := bitmaps[wordPos]
+		if !ok {
+			continue
 		}
 
 		initializedTicks := s.parseTickBitmap(bitmap, wordPos, tickSpacing)
@@ -136,7 +279,7 @@ func (s *Service) getInitializedTicks(ctx context.Context, poolKey *poolid.PoolK
 	}
 
 	// Phase 2: Fetch all tick info in a single batched call (via Multicall3).
-	ticks, err := s.repo.GetTickInfoBatch(ctx, poolKey, tickIndices)
+	ticks, err := s.repo.GetTickInfoBatch(ctx, poolKey, tickIndices, block)
 	if err != nil {
 		return nil, fmt.Errorf("get tick info batch: %w", err)
 	}
@@ -0,0 +1,220 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/mocks"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/token"
+	tokenmocks "remora/internal/token/mocks"
+)
+
+var usdc = &token.Token{Address: common.HexToAddress("0x036CbD53842c5426634e7929541eC2318f3dCF7e"), Symbol: "USDC", Decimals: 6}
+
+func assertPrice(t *testing.T, name, got string, want float64) {
+	t.Helper()
+
+	p, err := strconv.ParseFloat(got, 64)
+	if err != nil || math.Abs(p-want)/want > 1e-9 {
+		t.Errorf("%s = %q, want %g", name, got, want)
+	}
+}
+
+func TestService_FillPrices(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	tokens := tokenmocks.NewMockService(ctrl)
+	svc := New(nil, tokens, nil)
+
+	tokens.EXPECT().Get(gomock.Any(), common.Address{}).Return(token.Native(), nil)
+	tokens.EXPECT().Get(gomock.Any(), usdc.Address).Return(usdc, nil)
+
+	poolKey := &poolid.PoolKey{Currency0: "0x0000000000000000000000000000000000000000", Currency1: usdc.Address.Hex()}
+	dist := &liquidity.Distribution{Bins: []liquidity.Bin{
+		{TickLower: -200000, TickUpper: -199990, ActiveLiquidity: big.NewInt(1)},
+	}}
+
+	if err := svc.fillPrices(t.Context(), dist, poolKey); err != nil {
+		t.Fatalf("fillPrices() error = %v", err)
+	}
+
+	if dist.Token0.Symbol != token.NativeSymbol || dist.Token1.Symbol != "USDC" {
+		t.Errorf("tokens = %+v, %+v", dist.Token0, dist.Token1)
+	}
+
+	// The raw price 1.0001^tick is USDC units per wei; scale by 10^(18-6).
+	lower := math.Pow(1.0001, -200000) * 1e12
+	upper := math.Pow(1.0001, -199990) * 1e12
+
+	b := dist.Bins[0]
+	assertPrice(t, "PriceLower", b.PriceLower, lower)
+	assertPrice(t, "PriceUpper", b.PriceUpper, upper)
+	assertPrice(t, "InversePriceLower", b.InversePriceLower, 1/upper)
+	assertPrice(t, "InversePriceUpper", b.InversePriceUpper, 1/lower)
+}
+
+func TestService_FillPricesWithoutMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	tokens := tokenmocks.NewMockService(ctrl)
+	svc := New(nil, tokens, nil)
+
+	tokens.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, errors.New("rpc down"))
+
+	dist := &liquidity.Distribution{Bins: []liquidity.Bin{{TickLower: 0, TickUpper: 10}}}
+	if err := svc.fillPrices(t.Context(), dist, &poolid.PoolKey{}); err == nil {
+		t.Fatal("fillPrices() succeeded without token metadata")
+	}
+
+	if dist.Bins[0].PriceLower != "" || dist.Token0 != nil {
+		t.Errorf("bins priced without metadata: %+v", dist.Bins[0])
+	}
+}
+
+func TestService_GetDistributionAtTimestamp(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	svc := New(repo, nil, nil)
+
+	at := time.Unix(1_700_000_000, 0)
+	block := big.NewInt(123)
+
+	repo.EXPECT().BlockNumberAt(gomock.Any(), at).Return(uint64(123), nil)
+	repo.EXPECT().GetSlot0(gomock.Any(), gomock.Any(), block).Return(&liquidity.Slot0{SqrtPriceX96: big.NewInt(1)}, nil)
+	repo.EXPECT().GetLiquidity(gomock.Any(), gomock.Any(), block).Return(big.NewInt(0), nil)
+	repo.EXPECT().GetTickBitmapBatch(gomock.Any(), gomock.Any(), gomock.Any(), block).Return(map[int16]*big.Int{}, nil)
+	repo.EXPECT().GetTickInfoBatch(gomock.Any(), gomock.Any(), gomock.Any(), block).Return(nil, nil)
+
+	dist, err := svc.GetDistribution(t.Context(), &liquidity.DistributionParams{
+		PoolKey: poolid.PoolKey{
+			Currency0:   "0x0000000000000000000000000000000000000000",
+			Currency1:   usdc.Address.Hex(),
+			Fee:         3000,
+			TickSpacing: 60,
+			Hooks:       "0x0000000000000000000000000000000000000000",
+		},
+		BinSizeTicks: 60,
+		TickRange:    600,
+		Timestamp:    at,
+	})
+	if err != nil {
+		t.Fatalf("GetDistribution() error = %v", err)
+	}
+
+	if dist.BlockNumber != 123 {
+		t.Errorf("BlockNumber = %d, want 123", dist.BlockNumber)
+	}
+}
+
+func TestService_GetInitializedTicksBatchesBitmaps(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	svc := New(repo, nil, nil)
+
+	// Spacing 10, range ±5120 around tick 0 spans words -2..2. Only word -1 has bits set:
+	// bit 255 is tick -10 and bit 0 is tick -2560.
+	word := new(big.Int).SetBit(new(big.Int).SetBit(new(big.Int), 255, 1), 0, 1)
+
+	repo.EXPECT().GetTickBitmapBatch(gomock.Any(), gomock.Any(), []int16{-2, -1, 0, 1, 2}, nil).
+		Return(map[int16]*big.Int{-1: word}, nil)
+	repo.EXPECT().GetTickInfoBatch(gomock.Any(), gomock.Any(), gomock.Any(), nil).
+		DoAndReturn(func(_ any, _ any, ticks []int32, _ any) ([]liquidity.TickInfo, error) {
+			infos := make([]liquidity.TickInfo, len(ticks))
+			for i, tick := range ticks {
+				infos[i] = liquidity.TickInfo{Tick: tick, LiquidityGross: big.NewInt(1), LiquidityNet: big.NewInt(1)}
+			}
+
+			return infos, nil
+		})
+
+	ticks, err := svc.getInitializedTicks(t.Context(), &poolid.PoolKey{}, 0, 5120, 10, nil)
+	if err != nil {
+		t.Fatalf("getInitializedTicks() error = %v", err)
+	}
+
+	if len(ticks) != 2 || ticks[0].Tick != -2560 || ticks[1].Tick != -10 {
+		t.Errorf("ticks = %+v, want [-2560 -10]", ticks)
+	}
+}
+
+type fakeRegistry map[common.Hash]poolid.PoolKey
+
+func (f fakeRegistry) PoolKey(_ context.Context, id common.Hash) (*poolid.PoolKey, error) {
+	key, ok := f[id]
+	if !ok {
+		return nil, liquidity.ErrUnknownPool
+	}
+
+	return &key, nil
+}
+
+func TestService_PoolKey(t *testing.T) {
+	t.Parallel()
+
+	key := poolid.PoolKey{
+		Currency0:   "0x0000000000000000000000000000000000000000",
+		Currency1:   strings.ToLower(usdc.Address.Hex()),
+		Fee:         3000,
+		TickSpacing: 60,
+		Hooks:       "0x0000000000000000000000000000000000000000",
+	}
+	id := common.Hash(poolid.CalculatePoolID(&key))
+	canonical := poolid.Canonical(&key)
+
+	tests := []struct {
+		name    string
+		pools   liquidity.PoolRegistry
+		params  liquidity.DistributionParams
+		wantErr error
+	}{
+		{name: "key", params: liquidity.DistributionParams{PoolKey: key}},
+		{name: "key and matching id", params: liquidity.DistributionParams{PoolKey: key, PoolID: id}},
+		{name: "id from registry", pools: fakeRegistry{id: key}, params: liquidity.DistributionParams{PoolID: id}},
+		{name: "id without registry", params: liquidity.DistributionParams{PoolID: id}, wantErr: liquidity.ErrUnknownPool},
+		{name: "unknown id", pools: fakeRegistry{}, params: liquidity.DistributionParams{PoolID: id}, wantErr: liquidity.ErrUnknownPool},
+		{name: "mismatched id", params: liquidity.DistributionParams{PoolKey: key, PoolID: common.Hash{1}}, wantErr: liquidity.ErrPoolIDMismatch},
+		{name: "no pool", params: liquidity.DistributionParams{}, wantErr: poolid.ErrInvalidPoolKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			svc := New(nil, nil, tt.pools)
+
+			got, err := svc.poolKey(t.Context(), &tt.params)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("poolKey() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("poolKey() error = %v", err)
+			}
+
+			if got != canonical {
+				t.Errorf("poolKey() = %+v, want %+v", got, canonical)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+
+	"remora/internal/liquidity"
+)
+
+// codecVersion prefixes encoded ticks so the layout can change later.
+const codecVersion = 1
+
+// maxLiquidityBytes bounds decoded liquidity values; on-chain they are 128-bit.
+const maxLiquidityBytes = 16
+
+// EncodeTicks packs ascending ticks compactly: each tick index as a varint delta from the
+// previous one, liquidityGross as length-prefixed big-endian bytes, and liquidityNet the
+// same with the sign folded into the length.
+func EncodeTicks(ticks []liquidity.TickInfo) []byte {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64+len(ticks)*(2+2*maxLiquidityBytes))
+	buf = append(buf, codecVersion)
+	buf = binary.AppendUvarint(buf, uint64(len(ticks)))
+
+	prev := int64(0)
+	for _, t := range ticks {
+		buf = binary.AppendVarint(buf, int64(t.Tick)-prev)
+		prev = int64(t.Tick)
+
+		gross := t.LiquidityGross.Bytes()
+		buf = binary.AppendUvarint(buf, uint64(len(gross)))
+		buf = append(buf, gross...)
+
+		net := t.LiquidityNet.Bytes()
+		header := uint64(len(net)) << 1
+		if t.LiquidityNet.Sign() < 0 {
+			header |= 1
+		}
+
+		buf = binary.AppendUvarint(buf, header)
+		buf = append(buf, net...)
+	}
+
+	return buf
+}
+
+// DecodeTicks unpacks ticks encoded by EncodeTicks.
+func DecodeTicks(data []byte) ([]liquidity.TickInfo, error) {
+	r := bytes.NewReader(data)
+
+	version, err := r.ReadByte()
+	if err != nil || version != codecVersion {
+		return nil, fmt.Errorf("%w: unknown version", ErrCorruptTicks)
+	}
+
+	n, err := binary.ReadUvarint(r)
+	if err != nil || n > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: bad count", ErrCorruptTicks)
+	}
+
+	ticks := make([]liquidity.TickInfo, n)
+	prev := int64(0)
+
+	for i := range ticks {
+		delta, err := binary.ReadVarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tick %d: %w", ErrCorruptTicks, i, err)
+		}
+
+		prev += delta
+
+		grossLen, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tick %d gross: %w", ErrCorruptTicks, i, err)
+		}
+
+		gross, err := readUint(r, grossLen)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tick %d gross: %w", ErrCorruptTicks, i, err)
+		}
+
+		header, err := binary.ReadUvarint(r)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tick %d net: %w", ErrCorruptTicks, i, err)
+		}
+
+		net, err := readUint(r, header>>1)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tick %d net: %w", ErrCorruptTicks, i, err)
+		}
+
+		if header&1 == 1 {
+			net.Neg(net)
+		}
+
+		ticks[i] = liquidity.TickInfo{
+			Tick:           int32(prev), //nolint:gosec // encoded from int32 ticks
+			LiquidityGross: gross,
+			LiquidityNet:   net,
+		}
+	}
+
+	if r.Len() != 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", ErrCorruptTicks, r.Len())
+	}
+
+	return ticks, nil
+}
+
+// readUint reads an n-byte big-endian unsigned integer.
+func readUint(r *bytes.Reader, n uint64) (*big.Int, error) {
+	if n > maxLiquidityBytes {
+		return nil, fmt.Errorf("length %d too large", n)
+	}
+
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
@@ -0,0 +1,14 @@
+package snapshot
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when no snapshot is stored at or before a block.
+	ErrNotFound = errors.New("snapshot not found")
+
+	// ErrNoKeyframe is returned when stored records cannot be replayed from a keyframe.
+	ErrNoKeyframe = errors.New("snapshot replay has no keyframe")
+
+	// ErrCorruptTicks is returned when encoded ticks cannot be decoded.
+	ErrCorruptTicks = errors.New("corrupt encoded ticks")
+)
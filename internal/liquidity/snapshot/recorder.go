@@ -0,0 +1,233 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+)
+
+const (
+	defaultEveryBlocks   = 300 // about an hour on mainnet
+	defaultTickRange     = 20000
+	defaultConfirmations = 12
+	defaultInterval      = time.Minute
+	defaultKeyframeEvery = 24
+)
+
+// ChainClient is the subset of an Ethereum client needed to pick and timestamp blocks.
+type ChainClient interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config configures the recorder. Zero values fall back to defaults.
+type Config struct {
+	// Pools are the pools to record.
+	Pools []poolid.PoolKey
+	// EveryBlocks is the cadence: snapshots are taken at block numbers divisible by it.
+	EveryBlocks uint64
+	// TickRange is the range of ticks recorded around the current tick.
+	TickRange int32
+	// Confirmations is how many blocks behind head recording stops, so snapshots are not reorged.
+	Confirmations uint64
+	// Interval is the delay between passes.
+	Interval time.Duration
+	// KeyframeEvery is how often, in records, a full keyframe is stored. It bounds the deltas
+	// replayed to read a snapshot.
+	KeyframeEvery int
+}
+
+// Recorder snapshots the configured pools at every EveryBlocks-th confirmed block. A missed
+// cadence block (e.g. while the recorder was down) is skipped rather than backfilled.
+type Recorder struct {
+	client    ChainClient
+	liquidity liquidity.Service
+	repo      Repository
+	cfg       Config
+	logger    *slog.Logger
+
+	mu sync.Mutex
+	// last holds each pool's last saved ticks so the next record can be a delta. It is empty
+	// after a restart, so the first record of each pool is a keyframe.
+	last map[common.Hash]*poolState
+}
+
+type poolState struct {
+	block         uint64
+	ticks         []liquidity.TickInfo
+	sinceKeyframe int
+}
+
+// NewRecorder creates a recorder that reads pools through svc.
+func NewRecorder(client ChainClient, svc liquidity.Service, repo Repository, cfg Config, logger *slog.Logger) *Recorder {
+	if cfg.EveryBlocks == 0 {
+		cfg.EveryBlocks = defaultEveryBlocks
+	}
+
+	if cfg.TickRange <= 0 {
+		cfg.TickRange = defaultTickRange
+	}
+
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = defaultConfirmations
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+
+	if cfg.KeyframeEvery <= 0 {
+		cfg.KeyframeEvery = defaultKeyframeEvery
+	}
+
+	return &Recorder{
+		client:    client,
+		liquidity: svc,
+		repo:      repo,
+		cfg:       cfg,
+		logger:    logger,
+		last:      make(map[common.Hash]*poolState),
+	}
+}
+
+// Run records every Interval until ctx is cancelled. Errors are logged and retried on the next pass.
+func (r *Recorder) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.Sync(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			r.logger.ErrorContext(ctx, "liquidity snapshot sync failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sync records each pool at the latest confirmed cadence block, unless already recorded.
+func (r *Recorder) Sync(ctx context.Context) error {
+	head, err := r.client.BlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("block number: %w", err)
+	}
+
+	if head < r.cfg.Confirmations+r.cfg.EveryBlocks {
+		return nil
+	}
+
+	safe := head - r.cfg.Confirmations
+	block := safe - safe%r.cfg.EveryBlocks
+
+	var errs []error
+
+	for i := range r.cfg.Pools {
+		if err := r.syncPool(ctx, &r.cfg.Pools[i], block); err != nil {
+			errs = append(errs, fmt.Errorf("pool %s: %w", common.Hash(poolid.CalculatePoolID(&r.cfg.Pools[i])).Hex(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (r *Recorder) syncPool(ctx context.Context, key *poolid.PoolKey, block uint64) error {
+	poolID := common.Hash(poolid.CalculatePoolID(key))
+
+	latest, err := r.repo.LatestBlock(ctx, poolID)
+	if err != nil {
+		return err
+	}
+
+	if latest >= block {
+		return nil
+	}
+
+	return r.record(ctx, key, poolID, block, latest)
+}
+
+// record reads and stores the pool's liquidity at block. latest is the pool's latest stored block.
+func (r *Recorder) record(ctx context.Context, key *poolid.PoolKey, poolID common.Hash, block, latest uint64) error {
+	dist, err := r.liquidity.GetDistribution(ctx, &liquidity.DistributionParams{
+		PoolKey: *key,
+		// Bins are not stored; one bin keeps their aggregation cheap.
+		BinSizeTicks: 2 * r.cfg.TickRange,
+		TickRange:    r.cfg.TickRange,
+		BlockNumber:  block,
+	})
+	if err != nil {
+		return fmt.Errorf("get distribution: %w", err)
+	}
+
+	header, err := r.client.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return fmt.Errorf("header %d: %w", block, err)
+	}
+
+	sqrtPriceX96, ok := new(big.Int).SetString(dist.SqrtPriceX96, 10)
+	if !ok {
+		return fmt.Errorf("parse sqrtPriceX96 %q", dist.SqrtPriceX96)
+	}
+
+	poolLiquidity, ok := new(big.Int).SetString(dist.Liquidity, 10)
+	if !ok {
+		return fmt.Errorf("parse liquidity %q", dist.Liquidity)
+	}
+
+	rec := &Record{
+		Snapshot: Snapshot{
+			PoolID:       poolID,
+			BlockNumber:  block,
+			BlockTime:    time.Unix(int64(header.Time), 0).UTC(), //nolint:gosec // block times fit in int64
+			SqrtPriceX96: sqrtPriceX96,
+			Tick:         dist.CurrentTick,
+			Liquidity:    poolLiquidity,
+			Ticks:        dist.InitializedTicks,
+		},
+	}
+
+	r.mu.Lock()
+	prev := r.last[poolID]
+	r.mu.Unlock()
+
+	// A delta is only valid against the record stored right before it.
+	if prev == nil || prev.block != latest || prev.sinceKeyframe+1 >= r.cfg.KeyframeEvery {
+		rec.Keyframe = true
+	} else {
+		rec.Ticks = Delta(prev.ticks, dist.InitializedTicks)
+	}
+
+	if err := r.repo.Save(ctx, rec); err != nil {
+		return err
+	}
+
+	next := &poolState{block: block, ticks: dist.InitializedTicks}
+	if !rec.Keyframe {
+		next.sinceKeyframe = prev.sinceKeyframe + 1
+	}
+
+	r.mu.Lock()
+	r.last[poolID] = next
+	r.mu.Unlock()
+
+	r.logger.InfoContext(ctx, "liquidity snapshot recorded",
+		slog.String("pool_id", poolID.Hex()),
+		slog.Uint64("block", block),
+		slog.Bool("keyframe", rec.Keyframe),
+		slog.Int("ticks", len(rec.Ticks)),
+	)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/shopspring/decimal"
+
+	"remora/internal/db"
+	"remora/internal/liquidity/snapshot"
+)
+
+// Repository stores liquidity snapshot records in Postgres.
+type Repository struct {
+	q *db.Queries
+}
+
+var _ snapshot.Repository = (*Repository)(nil)
+
+func New(q *db.Queries) *Repository {
+	return &Repository{q: q}
+}
+
+func (r *Repository) LatestBlock(ctx context.Context, poolID common.Hash) (uint64, error) {
+	block, err := r.q.GetLatestLiquiditySnapshotBlock(ctx, poolID.Hex())
+	if err != nil {
+		return 0, fmt.Errorf("get latest liquidity snapshot block: %w", err)
+	}
+
+	return uint64(block), nil //nolint:gosec // block numbers are non-negative
+}
+
+func (r *Repository) Save(ctx context.Context, rec *snapshot.Record) error {
+	if rec.BlockNumber > math.MaxInt64 {
+		return fmt.Errorf("block number %d out of range", rec.BlockNumber)
+	}
+
+	err := r.q.InsertLiquiditySnapshot(ctx, db.InsertLiquiditySnapshotParams{
+		PoolID:       rec.PoolID.Hex(),
+		BlockNumber:  int64(rec.BlockNumber),
+		BlockTime:    rec.BlockTime,
+		SqrtPriceX96: decimal.NewFromBigInt(rec.SqrtPriceX96, 0),
+		Tick:         int(rec.Tick),
+		Liquidity:    decimal.NewFromBigInt(rec.Liquidity, 0),
+		Keyframe:     rec.Keyframe,
+		Ticks:        snapshot.EncodeTicks(rec.Ticks),
+		CreatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("insert liquidity snapshot: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ListForReplay(ctx context.Context, poolID common.Hash, block uint64) ([]snapshot.Record, error) {
+	if block > math.MaxInt64 {
+		block = math.MaxInt64
+	}
+
+	rows, err := r.q.ListLiquiditySnapshotsForReplay(ctx, db.ListLiquiditySnapshotsForReplayParams{
+		PoolID:      poolID.Hex(),
+		BlockNumber: int64(block),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list liquidity snapshots: %w", err)
+	}
+
+	records := make([]snapshot.Record, len(rows))
+
+	for i, row := range rows {
+		ticks, err := snapshot.DecodeTicks(row.Ticks)
+		if err != nil {
+			return nil, fmt.Errorf("decode snapshot at block %d: %w", row.BlockNumber, err)
+		}
+
+		records[i] = snapshot.Record{
+			Snapshot: snapshot.Snapshot{
+				PoolID:       common.HexToHash(row.PoolID),
+				BlockNumber:  uint64(row.BlockNumber), //nolint:gosec // stored from a uint64
+				BlockTime:    row.BlockTime,
+				SqrtPriceX96: row.SqrtPriceX96.BigInt(),
+				Tick:         int32(row.Tick), //nolint:gosec // stored from an int32
+				Liquidity:    row.Liquidity.BigInt(),
+				Ticks:        ticks,
+			},
+			Keyframe: row.Keyframe,
+		}
+	}
+
+	return records, nil
+}
@@ -0,0 +1,139 @@
+// Package snapshot records pool liquidity at a fixed block cadence and rebuilds it later.
+//
+// Records are stored as keyframes, holding every initialized tick in range, followed by
+// deltas holding only the ticks that changed since the previous record. Reading a snapshot
+// replays the deltas since the latest keyframe at or before the requested block.
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity"
+)
+
+// Snapshot is a pool's liquidity at a block.
+type Snapshot struct {
+	PoolID       common.Hash          `json:"poolId"`
+	BlockNumber  uint64               `json:"blockNumber"`
+	BlockTime    time.Time            `json:"blockTime"`
+	SqrtPriceX96 *big.Int             `json:"sqrtPriceX96"`
+	Tick         int32                `json:"tick"`
+	Liquidity    *big.Int             `json:"liquidity"`
+	Ticks        []liquidity.TickInfo `json:"ticks"` // Initialized ticks in range, ascending
+}
+
+// Record is a snapshot as stored. Unless Keyframe is set, Ticks holds only the ticks that
+// changed since the previous record; a removed tick has zero liquidity.
+type Record struct {
+	Snapshot
+
+	Keyframe bool
+}
+
+// Repository stores records.
+type Repository interface {
+	// LatestBlock returns the block of the pool's latest record, or 0 when there is none.
+	LatestBlock(ctx context.Context, poolID common.Hash) (uint64, error)
+
+	// Save stores r. Saving a block that is already stored is a no-op.
+	Save(ctx context.Context, r *Record) error
+
+	// ListForReplay returns the latest keyframe at or before block and every record after it
+	// up to block, ascending.
+	ListForReplay(ctx context.Context, poolID common.Hash, block uint64) ([]Record, error)
+}
+
+// At returns the pool's latest snapshot at or before block.
+func At(ctx context.Context, repo Repository, poolID common.Hash, block uint64) (*Snapshot, error) {
+	records, err := repo.ListForReplay(ctx, poolID, block)
+	if err != nil {
+		return nil, err
+	}
+
+	return Replay(records)
+}
+
+// Replay rebuilds the snapshot of the last record by applying records to the first, which
+// must be a keyframe.
+func Replay(records []Record) (*Snapshot, error) {
+	if len(records) == 0 {
+		return nil, ErrNotFound
+	}
+
+	if !records[0].Keyframe {
+		return nil, fmt.Errorf("%w: replay starts at block %d", ErrNoKeyframe, records[0].BlockNumber)
+	}
+
+	ticks := records[0].Ticks
+	for _, r := range records[1:] {
+		ticks = ApplyDelta(ticks, r.Ticks)
+	}
+
+	s := records[len(records)-1].Snapshot
+	s.Ticks = ticks
+
+	return &s, nil
+}
+
+// Delta returns the ticks of next that differ from prev, plus ticks of prev missing from next
+// with zero liquidity. Both must be ascending.
+func Delta(prev, next []liquidity.TickInfo) []liquidity.TickInfo {
+	var delta []liquidity.TickInfo
+
+	i, j := 0, 0
+	for i < len(prev) || j < len(next) {
+		switch {
+		case j == len(next) || (i < len(prev) && prev[i].Tick < next[j].Tick):
+			delta = append(delta, removed(prev[i].Tick))
+			i++
+		case i == len(prev) || next[j].Tick < prev[i].Tick:
+			delta = append(delta, next[j])
+			j++
+		default:
+			if prev[i].LiquidityGross.Cmp(next[j].LiquidityGross) != 0 || prev[i].LiquidityNet.Cmp(next[j].LiquidityNet) != 0 {
+				delta = append(delta, next[j])
+			}
+
+			i++
+			j++
+		}
+	}
+
+	return delta
+}
+
+// ApplyDelta returns base with delta applied, dropping ticks whose liquidity became zero.
+func ApplyDelta(base, delta []liquidity.TickInfo) []liquidity.TickInfo {
+	byTick := make(map[int32]liquidity.TickInfo, len(base)+len(delta))
+	for _, t := range base {
+		byTick[t.Tick] = t
+	}
+
+	for _, t := range delta {
+		if t.LiquidityGross.Sign() == 0 {
+			delete(byTick, t.Tick)
+			continue
+		}
+
+		byTick[t.Tick] = t
+	}
+
+	ticks := make([]liquidity.TickInfo, 0, len(byTick))
+	for _, t := range byTick {
+		ticks = append(ticks, t)
+	}
+
+	sort.Slice(ticks, func(i, j int) bool { return ticks[i].Tick < ticks[j].Tick })
+
+	return ticks
+}
+
+func removed(tick int32) liquidity.TickInfo {
+	return liquidity.TickInfo{Tick: tick, LiquidityGross: new(big.Int), LiquidityNet: new(big.Int)}
+}
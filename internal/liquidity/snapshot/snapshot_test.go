@@ -0,0 +1,253 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+)
+
+func tick(t int32, gross, net int64) liquidity.TickInfo {
+	return liquidity.TickInfo{Tick: t, LiquidityGross: big.NewInt(gross), LiquidityNet: big.NewInt(net)}
+}
+
+func equalTicks(a, b []liquidity.TickInfo) bool {
+	return slices.EqualFunc(a, b, func(x, y liquidity.TickInfo) bool {
+		return x.Tick == y.Tick && x.LiquidityGross.Cmp(y.LiquidityGross) == 0 && x.LiquidityNet.Cmp(y.LiquidityNet) == 0
+	})
+}
+
+func TestEncodeTicks_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	maxLiquidity, _ := new(big.Int).SetString("340282366920938463463374607431768211455", 10)
+
+	ticks := []liquidity.TickInfo{
+		tick(-887220, 5, 5),
+		tick(-60, 0, 0),
+		{Tick: 0, LiquidityGross: maxLiquidity, LiquidityNet: new(big.Int).Neg(big.NewInt(1 << 40))},
+		tick(887220, 5, -5),
+	}
+
+	got, err := DecodeTicks(EncodeTicks(ticks))
+	if err != nil {
+		t.Fatalf("DecodeTicks() error = %v", err)
+	}
+
+	if !equalTicks(got, ticks) {
+		t.Errorf("round trip = %+v, want %+v", got, ticks)
+	}
+
+	if empty, err := DecodeTicks(EncodeTicks(nil)); err != nil || len(empty) != 0 {
+		t.Errorf("empty round trip = %v, %v", empty, err)
+	}
+
+	encoded := EncodeTicks(ticks)
+	if _, err := DecodeTicks(encoded[:len(encoded)-1]); !errors.Is(err, ErrCorruptTicks) {
+		t.Errorf("truncated error = %v, want %v", err, ErrCorruptTicks)
+	}
+}
+
+func TestDeltaApply(t *testing.T) {
+	t.Parallel()
+
+	prev := []liquidity.TickInfo{tick(-120, 10, 10), tick(0, 5, 5), tick(120, 15, -15)}
+	next := []liquidity.TickInfo{tick(-120, 10, 10), tick(60, 7, 7), tick(120, 22, -22)}
+
+	delta := Delta(prev, next)
+
+	// Tick 0 removed, 60 added, 120 changed; -120 unchanged is left out.
+	want := []liquidity.TickInfo{tick(0, 0, 0), tick(60, 7, 7), tick(120, 22, -22)}
+	if !equalTicks(delta, want) {
+		t.Errorf("Delta() = %+v, want %+v", delta, want)
+	}
+
+	if got := ApplyDelta(prev, delta); !equalTicks(got, next) {
+		t.Errorf("ApplyDelta() = %+v, want %+v", got, next)
+	}
+}
+
+// memRepo is an in-memory Repository.
+type memRepo struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+func (m *memRepo) LatestBlock(_ context.Context, poolID common.Hash) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var latest uint64
+
+	for _, r := range m.records {
+		if r.PoolID == poolID {
+			latest = max(latest, r.BlockNumber)
+		}
+	}
+
+	return latest, nil
+}
+
+func (m *memRepo) Save(_ context.Context, r *Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Store through the codec, as the Postgres repository does.
+	ticks, err := DecodeTicks(EncodeTicks(r.Ticks))
+	if err != nil {
+		return err
+	}
+
+	stored := *r
+	stored.Ticks = ticks
+	m.records = append(m.records, stored)
+
+	return nil
+}
+
+func (m *memRepo) ListForReplay(_ context.Context, poolID common.Hash, block uint64) ([]Record, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	start := -1
+
+	for i, r := range m.records {
+		if r.PoolID == poolID && r.BlockNumber <= block && r.Keyframe {
+			start = i
+		}
+	}
+
+	if start < 0 {
+		return nil, nil
+	}
+
+	var out []Record
+
+	for _, r := range m.records[start:] {
+		if r.PoolID == poolID && r.BlockNumber <= block {
+			out = append(out, r)
+		}
+	}
+
+	return out, nil
+}
+
+type fakeChain struct{ head uint64 }
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) HeaderByNumber(_ context.Context, n *big.Int) (*types.Header, error) {
+	return &types.Header{Number: n, Time: 1_700_000_000 + 12*n.Uint64()}, nil
+}
+
+// fakeLiquidity returns ticks[block] as the initialized ticks at each block.
+type fakeLiquidity struct {
+	ticks map[uint64][]liquidity.TickInfo
+}
+
+func (f *fakeLiquidity) GetSlot0(context.Context, *poolid.PoolKey) (*liquidity.Slot0, error) {
+	return &liquidity.Slot0{}, nil
+}
+
+func (f *fakeLiquidity) ResolvePoolKey(context.Context, common.Hash) (*poolid.PoolKey, error) {
+	return nil, liquidity.ErrUnknownPool
+}
+
+func (f *fakeLiquidity) GetDistribution(_ context.Context, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	return &liquidity.Distribution{
+		BlockNumber:      params.BlockNumber,
+		CurrentTick:      int32(params.BlockNumber), //nolint:gosec // test blocks are small
+		SqrtPriceX96:     "79228162514264337593543950336",
+		Liquidity:        "1000",
+		InitializedTicks: f.ticks[params.BlockNumber],
+	}, nil
+}
+
+func TestRecorder_Sync(t *testing.T) {
+	t.Parallel()
+
+	key := poolid.PoolKey{
+		Currency0:   "0x0000000000000000000000000000000000000000",
+		Currency1:   "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Fee:         3000,
+		TickSpacing: 60,
+		Hooks:       "0x0000000000000000000000000000000000000000",
+	}
+	poolID := common.Hash(poolid.CalculatePoolID(&key))
+
+	ticks := map[uint64][]liquidity.TickInfo{
+		100: {tick(-60, 10, 10), tick(60, 10, -10)},
+		200: {tick(-60, 10, 10), tick(0, 4, 4), tick(60, 14, -14)},
+		300: {tick(0, 4, 4), tick(60, 4, -4)},
+	}
+
+	chain := &fakeChain{}
+	repo := &memRepo{}
+	rec := NewRecorder(chain, &fakeLiquidity{ticks: ticks}, repo, Config{
+		Pools:         []poolid.PoolKey{key},
+		EveryBlocks:   100,
+		Confirmations: 5,
+		KeyframeEvery: 3,
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	for _, head := range []uint64{104, 105, 150, 205, 305} {
+		chain.head = head
+		if err := rec.Sync(t.Context()); err != nil {
+			t.Fatalf("Sync() at head %d error = %v", head, err)
+		}
+	}
+
+	var blocks []uint64
+	for _, r := range repo.records {
+		blocks = append(blocks, r.BlockNumber)
+	}
+
+	if !slices.Equal(blocks, []uint64{100, 200, 300}) {
+		t.Fatalf("recorded blocks = %v, want [100 200 300]", blocks)
+	}
+
+	if !repo.records[0].Keyframe || repo.records[1].Keyframe || repo.records[2].Keyframe {
+		t.Errorf("keyframes = %v %v %v, want true false false", repo.records[0].Keyframe, repo.records[1].Keyframe, repo.records[2].Keyframe)
+	}
+
+	if len(repo.records[1].Ticks) != 2 {
+		t.Errorf("delta at 200 has %d ticks, want 2 (added 0, changed 60)", len(repo.records[1].Ticks))
+	}
+
+	for block, want := range ticks {
+		s, err := At(t.Context(), repo, poolID, block+50)
+		if err != nil {
+			t.Fatalf("At(%d) error = %v", block+50, err)
+		}
+
+		if s.BlockNumber != block || !equalTicks(s.Ticks, want) {
+			t.Errorf("At(%d) = block %d ticks %+v, want block %d ticks %+v", block+50, s.BlockNumber, s.Ticks, block, want)
+		}
+	}
+
+	if _, err := At(t.Context(), repo, poolID, 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("At(99) error = %v, want %v", err, ErrNotFound)
+	}
+
+	// The fourth record reaches KeyframeEvery.
+	ticks[400] = ticks[300]
+	chain.head = 405
+
+	if err := rec.Sync(t.Context()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if last := repo.records[len(repo.records)-1]; last.BlockNumber != 400 || !last.Keyframe {
+		t.Errorf("record at 400 keyframe = %v, want true", last.Keyframe)
+	}
+}
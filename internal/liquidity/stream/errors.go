@@ -0,0 +1,14 @@
+package stream
+
+import "errors"
+
+var (
+	// ErrClosed is returned when subscribing to a hub that has shut down.
+	ErrClosed = errors.New("stream hub closed")
+
+	// ErrTooManyPools is returned when a new pool would exceed the hub's MaxPools.
+	ErrTooManyPools = errors.New("too many streamed pools")
+
+	// ErrTooManySubscriptions is returned when a client already holds MaxClient subscriptions.
+	ErrTooManySubscriptions = errors.New("too many stream subscriptions")
+)
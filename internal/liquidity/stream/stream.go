@@ -0,0 +1,346 @@
+// Package stream pushes live pool state to subscribers. Each pool with at least one subscriber
+// has a single poller that reads the pool once per new block and fans the result out, so any
+// number of subscribers cost one set of RPC calls.
+package stream
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/liquidity/snapshot"
+)
+
+const (
+	defaultInterval   = 2 * time.Second
+	defaultTickRange  = 20000
+	defaultBufferSize = 64
+	defaultMaxPools   = 100
+	defaultMaxClient  = 5
+)
+
+// EventType identifies what an Event carries.
+type EventType string
+
+const (
+	// EventSnapshot carries the pool state and every initialized tick in range. It is the first
+	// event of each subscription.
+	EventSnapshot EventType = "snapshot"
+
+	// EventSlot0 carries the pool state at a new block.
+	EventSlot0 EventType = "slot0"
+
+	// EventTicks carries the initialized ticks that changed at a new block. A removed tick,
+	// or one that left the scanned range, has zero liquidity.
+	EventTicks EventType = "ticks"
+)
+
+// Event is a single update for a pool.
+type Event struct {
+	Type         EventType
+	BlockNumber  uint64
+	Tick         int32
+	SqrtPriceX96 string
+	Liquidity    string
+	Ticks        []liquidity.TickInfo // Snapshot and ticks events only
+}
+
+// BlockSource returns the latest block number.
+type BlockSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// Config configures a Hub.
+type Config struct {
+	Interval   time.Duration // How often pollers check for a new block (default 2s)
+	TickRange  int32         // Ticks scanned either side of the current tick (default 20000)
+	BufferSize int           // Events buffered per subscriber before it is dropped (default 64)
+	MaxPools   int           // Pools polled at once across all subscribers (default 100)
+	MaxClient  int           // Subscriptions one client may hold at once (default 5)
+}
+
+// Hub runs the per-pool pollers and tracks their subscribers.
+type Hub struct {
+	source liquidity.Service
+	blocks BlockSource
+	cfg    Config
+	logger *slog.Logger
+
+	mu      sync.Mutex
+	pools   map[common.Hash]*poller
+	clients map[string]int // Open subscriptions per client
+	closed  bool
+}
+
+// Subscription receives the events of one pool. C is closed when the subscriber falls too far
+// behind or the hub shuts down.
+type Subscription struct {
+	C <-chan Event
+
+	c      chan Event
+	poolID common.Hash
+	client string
+	hub    *Hub
+}
+
+type poller struct {
+	key    poolid.PoolKey
+	cancel context.CancelFunc
+	subs   map[*Subscription]struct{}
+	state  *Event // Last snapshot, kept current; nil until the first read
+}
+
+func NewHub(source liquidity.Service, blocks BlockSource, cfg Config, logger *slog.Logger) *Hub {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+
+	if cfg.TickRange <= 0 {
+		cfg.TickRange = defaultTickRange
+	}
+
+	if cfg.BufferSize <= 0 {
+		cfg.BufferSize = defaultBufferSize
+	}
+
+	if cfg.MaxPools <= 0 {
+		cfg.MaxPools = defaultMaxPools
+	}
+
+	if cfg.MaxClient <= 0 {
+		cfg.MaxClient = defaultMaxClient
+	}
+
+	return &Hub{
+		source:  source,
+		blocks:  blocks,
+		cfg:     cfg,
+		logger:  logger,
+		pools:   make(map[common.Hash]*poller),
+		clients: make(map[string]int),
+	}
+}
+
+// Subscribe starts receiving the events of the pool with key for client, starting its poller if
+// this is the first subscriber. A new poller is only started for an initialized pool and while
+// fewer than MaxPools are polled. Callers must Close the subscription when done.
+func (h *Hub) Subscribe(ctx context.Context, key poolid.PoolKey, client string) (*Subscription, error) {
+	if err := poolid.ValidatePoolKey(&key); err != nil {
+		return nil, fmt.Errorf("validate pool key: %w", err)
+	}
+
+	id := common.Hash(poolid.CalculatePoolID(&key))
+
+	h.mu.Lock()
+	_, polled := h.pools[id]
+	h.mu.Unlock()
+
+	// A pool that was never initialized would fail every poll, so it gets no poller.
+	if !polled {
+		slot0, err := h.source.GetSlot0(ctx, &key)
+		if err != nil {
+			return nil, fmt.Errorf("get slot0: %w", err)
+		}
+
+		if slot0.SqrtPriceX96 == nil || slot0.SqrtPriceX96.Sign() == 0 {
+			return nil, fmt.Errorf("%w: %s is not initialized", liquidity.ErrUnknownPool, id.Hex())
+		}
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, ErrClosed
+	}
+
+	if h.clients[client] >= h.cfg.MaxClient {
+		return nil, ErrTooManySubscriptions
+	}
+
+	p, ok := h.pools[id]
+	if !ok && len(h.pools) >= h.cfg.MaxPools {
+		return nil, ErrTooManyPools
+	}
+
+	c := make(chan Event, h.cfg.BufferSize)
+	sub := &Subscription{C: c, c: c, poolID: id, client: client, hub: h}
+
+	if !ok {
+		// The poller outlives the request that starts it.
+		pollCtx, cancel := context.WithCancel(context.Background())
+		p = &poller{key: key, cancel: cancel, subs: make(map[*Subscription]struct{})}
+		h.pools[id] = p
+
+		go h.run(pollCtx, id, p)
+	}
+
+	p.subs[sub] = struct{}{}
+	h.clients[client]++
+
+	if p.state != nil {
+		c <- *p.state
+	}
+
+	return sub, nil
+}
+
+// Close stops the subscription, and the pool's poller if it was the last subscriber.
+func (s *Subscription) Close() {
+	h := s.hub
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	p, ok := h.pools[s.poolID]
+	if !ok {
+		return
+	}
+
+	if _, ok := p.subs[s]; !ok {
+		return
+	}
+
+	delete(p.subs, s)
+	h.release(s)
+
+	if len(p.subs) == 0 {
+		p.cancel()
+		delete(h.pools, s.poolID)
+	}
+}
+
+// Close stops every poller and closes every subscription.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+
+	for id, p := range h.pools {
+		p.cancel()
+
+		for sub := range p.subs {
+			h.release(sub)
+		}
+
+		delete(h.pools, id)
+	}
+}
+
+// release closes sub and frees its client slot. Callers hold h.mu and have removed sub from its
+// poller.
+func (h *Hub) release(sub *Subscription) {
+	close(sub.c)
+
+	if h.clients[sub.client]--; h.clients[sub.client] <= 0 {
+		delete(h.clients, sub.client)
+	}
+}
+
+func (h *Hub) run(ctx context.Context, id common.Hash, p *poller) {
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+
+	var lastBlock uint64
+
+	for {
+		block, err := h.poll(ctx, id, p, lastBlock)
+		if err != nil && ctx.Err() == nil {
+			h.logger.WarnContext(ctx, "poll pool failed", slog.String("pool_id", id.Hex()), slog.Any("error", err))
+		}
+
+		lastBlock = block
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reads the pool at the latest block if it is newer than lastBlock and publishes the
+// changes. It returns the last block read.
+func (h *Hub) poll(ctx context.Context, id common.Hash, p *poller, lastBlock uint64) (uint64, error) {
+	block, err := h.blocks.BlockNumber(ctx)
+	if err != nil {
+		return lastBlock, fmt.Errorf("get block number: %w", err)
+	}
+
+	if block <= lastBlock {
+		return lastBlock, nil
+	}
+
+	// Only the ticks are published, so a single bin keeps binning and the cached payload small.
+	dist, err := h.source.GetDistribution(ctx, &liquidity.DistributionParams{
+		PoolKey:      p.key,
+		BinSizeTicks: 2 * h.cfg.TickRange,
+		TickRange:    h.cfg.TickRange,
+		BlockNumber:  block,
+	})
+	if err != nil {
+		return lastBlock, fmt.Errorf("get distribution: %w", err)
+	}
+
+	next := Event{
+		Type:         EventSnapshot,
+		BlockNumber:  block,
+		Tick:         dist.CurrentTick,
+		SqrtPriceX96: dist.SqrtPriceX96,
+		Liquidity:    dist.Liquidity,
+		Ticks:        dist.InitializedTicks,
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	prev := p.state
+	p.state = &next
+
+	if prev == nil {
+		h.publish(id, p, next)
+
+		return block, nil
+	}
+
+	slot0 := next
+	slot0.Type = EventSlot0
+	slot0.Ticks = nil
+	h.publish(id, p, slot0)
+
+	if delta := snapshot.Delta(prev.Ticks, next.Ticks); len(delta) > 0 {
+		ticks := slot0
+		ticks.Type = EventTicks
+		ticks.Ticks = delta
+		h.publish(id, p, ticks)
+	}
+
+	return block, nil
+}
+
+// publish sends ev to every subscriber of p, dropping those whose buffer is full so one slow
+// client cannot stall the others. Callers hold h.mu.
+func (h *Hub) publish(id common.Hash, p *poller, ev Event) {
+	for sub := range p.subs {
+		select {
+		case sub.c <- ev:
+		default:
+			h.logger.Warn("dropping slow stream subscriber", slog.String("pool_id", id.Hex()))
+
+			delete(p.subs, sub)
+			h.release(sub)
+		}
+	}
+
+	if len(p.subs) == 0 && h.pools[id] == p {
+		p.cancel()
+		delete(h.pools, id)
+	}
+}
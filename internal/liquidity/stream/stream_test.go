@@ -0,0 +1,239 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+)
+
+var testKey = poolid.PoolKey{
+	Currency0:   "0x0000000000000000000000000000000000000000",
+	Currency1:   "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+	Fee:         3000,
+	TickSpacing: 60,
+	Hooks:       "0x0000000000000000000000000000000000000000",
+}
+
+// fakeChain serves a distribution per block and counts reads. Its pools are initialized unless
+// uninitialized is set.
+type fakeChain struct {
+	block         atomic.Uint64
+	reads         atomic.Int64
+	uninitialized bool
+
+	mu    sync.Mutex
+	dists map[uint64]*liquidity.Distribution
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.block.Load(), nil }
+
+func (f *fakeChain) GetDistribution(_ context.Context, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	f.reads.Add(1)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	dist, ok := f.dists[params.BlockNumber]
+	if !ok {
+		return nil, liquidity.ErrInvalidBlock
+	}
+
+	return dist, nil
+}
+
+func (f *fakeChain) GetSlot0(context.Context, *poolid.PoolKey) (*liquidity.Slot0, error) {
+	if f.uninitialized {
+		return &liquidity.Slot0{SqrtPriceX96: new(big.Int)}, nil
+	}
+
+	return &liquidity.Slot0{SqrtPriceX96: big.NewInt(1)}, nil
+}
+
+func (f *fakeChain) ResolvePoolKey(context.Context, common.Hash) (*poolid.PoolKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeChain) set(block uint64, dist *liquidity.Distribution) {
+	f.mu.Lock()
+	f.dists[block] = dist
+	f.mu.Unlock()
+	f.block.Store(block)
+}
+
+func dist(tick int32, ticks ...liquidity.TickInfo) *liquidity.Distribution {
+	return &liquidity.Distribution{CurrentTick: tick, SqrtPriceX96: "1", Liquidity: "1", InitializedTicks: ticks}
+}
+
+func tickInfo(tick int32, l int64) liquidity.TickInfo {
+	return liquidity.TickInfo{Tick: tick, LiquidityGross: big.NewInt(l), LiquidityNet: big.NewInt(l)}
+}
+
+func next(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+
+	select {
+	case ev, ok := <-sub.C:
+		if !ok {
+			t.Fatal("subscription closed")
+		}
+
+		return ev
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+
+	return Event{}
+}
+
+func TestHub_SharedPoller(t *testing.T) {
+	chain := &fakeChain{dists: map[uint64]*liquidity.Distribution{}}
+	chain.set(10, dist(5, tickInfo(-60, 100), tickInfo(60, 100)))
+
+	hub := NewHub(chain, chain, Config{Interval: 5 * time.Millisecond}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(hub.Close)
+
+	a, err := hub.Subscribe(t.Context(), testKey, "client")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if ev := next(t, a); ev.Type != EventSnapshot || ev.BlockNumber != 10 || len(ev.Ticks) != 2 {
+		t.Fatalf("first event = %+v, want snapshot at 10 with 2 ticks", ev)
+	}
+
+	// A late subscriber starts from the current snapshot without another read.
+	b, err := hub.Subscribe(t.Context(), testKey, "client")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if ev := next(t, b); ev.Type != EventSnapshot || ev.BlockNumber != 10 {
+		t.Fatalf("late subscriber first event = %+v, want snapshot at 10", ev)
+	}
+
+	// Same ticks: slot0 only.
+	chain.set(11, dist(7, tickInfo(-60, 100), tickInfo(60, 100)))
+
+	for _, sub := range []*Subscription{a, b} {
+		if ev := next(t, sub); ev.Type != EventSlot0 || ev.BlockNumber != 11 || ev.Tick != 7 || ev.Ticks != nil {
+			t.Fatalf("event = %+v, want slot0 at 11", ev)
+		}
+	}
+
+	// One tick changed and one removed.
+	chain.set(12, dist(7, tickInfo(-60, 300)))
+
+	for _, sub := range []*Subscription{a, b} {
+		if ev := next(t, sub); ev.Type != EventSlot0 {
+			t.Fatalf("event = %+v, want slot0", ev)
+		}
+
+		ev := next(t, sub)
+		if ev.Type != EventTicks || len(ev.Ticks) != 2 {
+			t.Fatalf("event = %+v, want ticks with 2 changes", ev)
+		}
+
+		if ev.Ticks[0].LiquidityGross.Int64() != 300 || ev.Ticks[1].LiquidityGross.Sign() != 0 {
+			t.Errorf("ticks = %+v, want -60 updated and 60 removed", ev.Ticks)
+		}
+	}
+
+	if reads := chain.reads.Load(); reads != 3 {
+		t.Errorf("reads = %d, want one per block", reads)
+	}
+
+	a.Close()
+	b.Close()
+
+	hub.mu.Lock()
+	pools := len(hub.pools)
+	hub.mu.Unlock()
+
+	if pools != 0 {
+		t.Errorf("pollers = %d after last subscriber left, want 0", pools)
+	}
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	chain := &fakeChain{dists: map[uint64]*liquidity.Distribution{}}
+	hub := NewHub(chain, chain, Config{Interval: time.Hour}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	sub, err := hub.Subscribe(t.Context(), testKey, "client")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	hub.Close()
+
+	if _, ok := <-sub.C; ok {
+		t.Error("subscription still open after hub close")
+	}
+
+	sub.Close()
+
+	if _, err := hub.Subscribe(t.Context(), testKey, "client"); !errors.Is(err, ErrClosed) {
+		t.Errorf("Subscribe() after close error = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestHub_Limits(t *testing.T) {
+	chain := &fakeChain{dists: map[uint64]*liquidity.Distribution{}}
+	hub := NewHub(chain, chain, Config{Interval: time.Hour, MaxPools: 1, MaxClient: 2}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(hub.Close)
+
+	a, err := hub.Subscribe(t.Context(), testKey, "a")
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+
+	if _, err := hub.Subscribe(t.Context(), testKey, "a"); err != nil {
+		t.Fatalf("second Subscribe() error = %v", err)
+	}
+
+	if _, err := hub.Subscribe(t.Context(), testKey, "a"); !errors.Is(err, ErrTooManySubscriptions) {
+		t.Errorf("Subscribe() over client limit error = %v, want %v", err, ErrTooManySubscriptions)
+	}
+
+	other := testKey
+	other.Fee = 500
+
+	if _, err := hub.Subscribe(t.Context(), other, "b"); !errors.Is(err, ErrTooManyPools) {
+		t.Errorf("Subscribe() over pool limit error = %v, want %v", err, ErrTooManyPools)
+	}
+
+	// Closing frees the client's slot.
+	a.Close()
+
+	if _, err := hub.Subscribe(t.Context(), testKey, "a"); err != nil {
+		t.Errorf("Subscribe() after close error = %v", err)
+	}
+}
+
+func TestHub_RejectsUninitializedPool(t *testing.T) {
+	chain := &fakeChain{dists: map[uint64]*liquidity.Distribution{}, uninitialized: true}
+	hub := NewHub(chain, chain, Config{Interval: time.Hour}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	t.Cleanup(hub.Close)
+
+	if _, err := hub.Subscribe(t.Context(), testKey, "client"); !errors.Is(err, liquidity.ErrUnknownPool) {
+		t.Errorf("Subscribe() error = %v, want %v", err, liquidity.ErrUnknownPool)
+	}
+
+	hub.mu.Lock()
+	pools := len(hub.pools)
+	hub.mu.Unlock()
+
+	if pools != 0 {
+		t.Errorf("pollers = %d, want none for an uninitialized pool", pools)
+	}
+}
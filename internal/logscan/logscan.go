@@ -0,0 +1,323 @@
+// Package logscan reads contract logs in block-range chunks up to a confirmed head. It is the
+// shared core of the event indexers: each one supplies its query, where it left off and how to
+// decode a log.
+package logscan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// maxConcurrentHeaders caps the header reads in flight while stamping a chunk of logs.
+const maxConcurrentHeaders = 8
+
+// ErrSkip is returned by a Decoder for a log that is not indexed.
+var ErrSkip = errors.New("skip log")
+
+// rangeErrors are provider messages for an eth_getLogs query over its block range or result
+// limit, e.g. "query returned more than 10000 results" or "block range is too wide".
+var rangeErrors = []string{
+	"block range",
+	"range too",
+	"range is too",
+	"more than",
+	"too many results",
+	"response size",
+	"limited to",
+	"max results",
+}
+
+// Client is the subset of an Ethereum client needed to scan logs.
+type Client interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// HeaderSource reads block headers, used to stamp logs with their block time.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// Config configures a Scanner. Zero values take the defaults given to New.
+type Config struct {
+	// StartBlock is the first block scanned when nothing has been indexed yet.
+	StartBlock uint64
+	// Confirmations is how many blocks behind head scanning stops, so reorged logs are never read.
+	Confirmations uint64
+	// BlockRange is the maximum number of blocks requested per eth_getLogs call.
+	BlockRange uint64
+	// Interval is the delay between Run passes.
+	Interval time.Duration
+}
+
+// Log is a log with the time of its block. BlockTime is zero when the scanner reads no headers.
+type Log struct {
+	types.Log
+
+	BlockTime time.Time
+}
+
+// Decoder decodes one log, returning ErrSkip for a log that is not indexed.
+type Decoder[T any] func(lg Log) (T, error)
+
+// Cursor is where a log stream was indexed up to, and where its decoded chunks are saved.
+type Cursor[T any] interface {
+	// IndexedBlock returns the last indexed block; ok is false before the first save.
+	IndexedBlock(ctx context.Context) (block uint64, ok bool, err error)
+
+	// Save stores the items of a chunk and advances the cursor to block in one step.
+	Save(ctx context.Context, items []T, block uint64) error
+}
+
+// Scanner reads logs for an indexer.
+type Scanner struct {
+	client  Client
+	headers HeaderSource
+	cfg     Config
+	logger  *slog.Logger
+}
+
+// New creates a scanner. headers may be nil when logs need no block time.
+func New(client Client, headers HeaderSource, cfg, defaults Config, logger *slog.Logger) *Scanner {
+	if cfg.Confirmations == 0 {
+		cfg.Confirmations = defaults.Confirmations
+	}
+
+	if cfg.BlockRange == 0 {
+		cfg.BlockRange = defaults.BlockRange
+	}
+
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaults.Interval
+	}
+
+	return &Scanner{client: client, headers: headers, cfg: cfg, logger: logger}
+}
+
+// Config returns the scanner's configuration with defaults applied.
+func (s *Scanner) Config() Config {
+	return s.cfg
+}
+
+// Run calls sync every Interval until ctx is cancelled. Errors are logged as "<name> sync failed"
+// and retried on the next pass.
+func (s *Scanner) Run(ctx context.Context, name string, sync func(ctx context.Context) error) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := sync(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			s.logger.ErrorContext(ctx, name+" sync failed", slog.Any("error", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SafeBlock returns head minus Confirmations. ok is false while the chain is shorter than that.
+func (s *Scanner) SafeBlock(ctx context.Context) (uint64, bool, error) {
+	head, err := s.client.BlockNumber(ctx)
+	if err != nil {
+		return 0, false, fmt.Errorf("block number: %w", err)
+	}
+
+	if head < s.cfg.Confirmations {
+		return 0, false, nil
+	}
+
+	return head - s.cfg.Confirmations, true, nil
+}
+
+// Scan reads the logs matching q in [from, to] chunk by chunk and passes each chunk, with the
+// last block it covers, to fn. Removed logs are dropped. The block range is halved while the
+// provider rejects a query for its range or result size; other errors are returned.
+func (s *Scanner) Scan(ctx context.Context, q ethereum.FilterQuery, from, to uint64, fn func(to uint64, logs []Log) error) error {
+	blockRange := s.cfg.BlockRange
+
+	for from <= to {
+		chunkTo := min(from+blockRange-1, to)
+
+		q.FromBlock = new(big.Int).SetUint64(from)
+		q.ToBlock = new(big.Int).SetUint64(chunkTo)
+
+		raw, err := s.client.FilterLogs(ctx, q)
+		if err != nil {
+			if blockRange > 1 && ctx.Err() == nil && IsRangeError(err) {
+				blockRange /= 2
+
+				continue
+			}
+
+			return fmt.Errorf("filter logs [%d, %d]: %w", from, chunkTo, err)
+		}
+
+		logs, err := s.stamp(ctx, raw)
+		if err != nil {
+			return err
+		}
+
+		if err := fn(chunkTo, logs); err != nil {
+			return err
+		}
+
+		from = chunkTo + 1
+	}
+
+	return nil
+}
+
+// stamp drops removed logs and sets the block time of the rest.
+func (s *Scanner) stamp(ctx context.Context, raw []types.Log) ([]Log, error) {
+	logs := make([]Log, 0, len(raw))
+
+	for _, lg := range raw {
+		if !lg.Removed {
+			logs = append(logs, Log{Log: lg})
+		}
+	}
+
+	if s.headers == nil || len(logs) == 0 {
+		return logs, nil
+	}
+
+	blockTimes, err := s.blockTimes(ctx, logs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range logs {
+		logs[i].BlockTime = blockTimes[logs[i].BlockNumber]
+	}
+
+	return logs, nil
+}
+
+// blockTimes reads the header of each distinct block of logs, up to maxConcurrentHeaders at a time.
+func (s *Scanner) blockTimes(ctx context.Context, logs []Log) (map[uint64]time.Time, error) {
+	seen := make(map[uint64]bool)
+
+	var blocks []uint64
+
+	for _, lg := range logs {
+		if !seen[lg.BlockNumber] {
+			seen[lg.BlockNumber] = true
+			blocks = append(blocks, lg.BlockNumber)
+		}
+	}
+
+	times := make([]time.Time, len(blocks))
+	errs := make([]error, len(blocks))
+	sem := make(chan struct{}, maxConcurrentHeaders)
+
+	var wg sync.WaitGroup
+
+	for i, block := range blocks {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			header, err := s.headers.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+			if err != nil {
+				errs[i] = fmt.Errorf("header %d: %w", block, err)
+
+				return
+			}
+
+			times[i] = time.Unix(int64(header.Time), 0).UTC() //nolint:gosec // block timestamps fit in int64
+		}()
+	}
+
+	wg.Wait()
+
+	blockTimes := make(map[uint64]time.Time, len(blocks))
+
+	for i, block := range blocks {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+
+		blockTimes[block] = times[i]
+	}
+
+	return blockTimes, nil
+}
+
+// Sync scans q from after the cursor, or from StartBlock, up to to. Each log is decoded and every
+// chunk saved through cursor, so progress survives a failure part way. It returns how many items
+// were saved.
+func Sync[T any](ctx context.Context, s *Scanner, q ethereum.FilterQuery, to uint64, cursor Cursor[T], decode Decoder[T]) (int, error) {
+	from := s.cfg.StartBlock
+
+	indexed, ok, err := cursor.IndexedBlock(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get indexed block: %w", err)
+	}
+
+	if ok {
+		from = max(from, indexed+1)
+	}
+
+	saved := 0
+
+	err = s.Scan(ctx, q, from, to, func(chunkTo uint64, logs []Log) error {
+		items := make([]T, 0, len(logs))
+
+		for _, lg := range logs {
+			item, err := decode(lg)
+			if errors.Is(err, ErrSkip) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			items = append(items, item)
+		}
+
+		if err := cursor.Save(ctx, items, chunkTo); err != nil {
+			return err
+		}
+
+		saved += len(items)
+
+		return nil
+	})
+
+	return saved, err
+}
+
+// IsRangeError reports whether err is a provider rejecting an eth_getLogs query for its block
+// range or result size, which a smaller range avoids.
+func IsRangeError(err error) bool {
+	message := strings.ToLower(err.Error())
+
+	if strings.Contains(message, "rate limit") {
+		return false
+	}
+
+	for _, s := range rangeErrors {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,166 @@
+package logscan
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeChain serves one log per listed block, fails every query with err when set, and rejects
+// ranges wider than maxRange.
+type fakeChain struct {
+	head     uint64
+	blocks   []uint64
+	maxRange uint64
+	err      error
+	calls    int
+	headers  atomic.Int32
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.calls++
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if f.maxRange > 0 && to-from+1 > f.maxRange {
+		return nil, errors.New("query returned more than 10000 results")
+	}
+
+	var out []types.Log
+
+	for _, b := range f.blocks {
+		if b >= from && b <= to {
+			out = append(out, types.Log{BlockNumber: b, Removed: b%10 == 9})
+		}
+	}
+
+	return out, nil
+}
+
+func (f *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	f.headers.Add(1)
+
+	return &types.Header{Number: number, Time: 1000 + number.Uint64()}, nil
+}
+
+// memCursor keeps the saved blocks and the cursor in memory.
+type memCursor struct {
+	indexed *uint64
+	saved   []uint64
+}
+
+func (c *memCursor) IndexedBlock(context.Context) (uint64, bool, error) {
+	if c.indexed == nil {
+		return 0, false, nil
+	}
+
+	return *c.indexed, true, nil
+}
+
+func (c *memCursor) Save(_ context.Context, blocks []uint64, block uint64) error {
+	c.saved = append(c.saved, blocks...)
+	c.indexed = &block
+
+	return nil
+}
+
+func newScanner(chain *fakeChain, cfg Config) *Scanner {
+	return New(chain, chain, cfg, Config{Confirmations: 1, BlockRange: 1000}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestSync(t *testing.T) {
+	t.Parallel()
+
+	// Block 109 is removed and block 112 is odd, which the decoder skips.
+	chain := &fakeChain{head: 130, blocks: []uint64{95, 104, 109, 112, 118, 125}, maxRange: 8}
+	s := newScanner(chain, Config{StartBlock: 100, Confirmations: 10, BlockRange: 50})
+
+	decode := func(lg Log) (uint64, error) {
+		if lg.BlockNumber%2 == 1 {
+			return 0, ErrSkip
+		}
+
+		if lg.BlockTime.Unix() != int64(1000+lg.BlockNumber) { //nolint:gosec // small test blocks
+			t.Errorf("block %d time = %v, want its header time", lg.BlockNumber, lg.BlockTime)
+		}
+
+		return lg.BlockNumber, nil
+	}
+
+	cursor := &memCursor{}
+
+	saved, err := Sync(t.Context(), s, ethereum.FilterQuery{}, 120, cursor, decode)
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if saved != 3 || len(cursor.saved) != 3 || *cursor.indexed != 120 {
+		t.Fatalf("saved %d blocks %v up to %v, want 104, 112 and 118 up to 120", saved, cursor.saved, *cursor.indexed)
+	}
+
+	// Each block with a live log is read once; the removed one is not.
+	if chain.headers.Load() != 3 {
+		t.Errorf("header reads = %d, want 3", chain.headers.Load())
+	}
+
+	// The next pass resumes after the cursor.
+	if _, err := Sync(t.Context(), s, ethereum.FilterQuery{}, 130, cursor, decode); err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+
+	if len(cursor.saved) != 3 || *cursor.indexed != 130 {
+		t.Errorf("saved %v up to %d, want no new blocks up to 130", cursor.saved, *cursor.indexed)
+	}
+}
+
+func TestScan_ShrinksOnlyOnRangeErrors(t *testing.T) {
+	t.Parallel()
+
+	chain := &fakeChain{err: errors.New("dial tcp: connection refused")}
+	s := newScanner(chain, Config{BlockRange: 4096})
+
+	err := s.Scan(t.Context(), ethereum.FilterQuery{}, 0, 10000, func(uint64, []Log) error { return nil })
+	if err == nil {
+		t.Fatal("Scan() succeeded against a failing provider")
+	}
+
+	if chain.calls != 1 {
+		t.Errorf("calls = %d, want 1: an outage must not shrink the range", chain.calls)
+	}
+}
+
+func TestIsRangeError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  string
+		want bool
+	}{
+		{err: "query returned more than 10000 results", want: true},
+		{err: "eth_getLogs is limited to a 10,000 block range", want: true},
+		{err: "block range is too wide", want: true},
+		{err: "Log response size exceeded.", want: true},
+		{err: "exceed maximum block range: 5000", want: true},
+		{err: "429 Too Many Requests: rate limit exceeded", want: false},
+		{err: "dial tcp: connection refused", want: false},
+		{err: "context deadline exceeded", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsRangeError(errors.New(tt.err)); got != tt.want {
+			t.Errorf("IsRangeError(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
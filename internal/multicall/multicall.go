@@ -0,0 +1,204 @@
+// Package multicall batches contract reads through Multicall3 so they execute
+// in a single eth_call, against a single block.
+package multicall
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Address is the Multicall3 deployment, identical on all EVM chains.
+var Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+var (
+	ErrCallFailed = errors.New("multicall: call failed")
+
+	multicall3ABI abi.ABI
+)
+
+func init() {
+	var err error
+
+	multicall3ABI, err = abi.JSON(strings.NewReader(`[{
+		"name":"aggregate3",
+		"type":"function",
+		"inputs":[{"name":"calls","type":"tuple[]","components":[
+			{"name":"target","type":"address"},
+			{"name":"allowFailure","type":"bool"},
+			{"name":"callData","type":"bytes"}
+		]}],
+		"outputs":[{"name":"returnData","type":"tuple[]","components":[
+			{"name":"success","type":"bool"},
+			{"name":"returnData","type":"bytes"}
+		]}]
+	},{
+		"name":"getBlockNumber",
+		"type":"function",
+		"inputs":[],
+		"outputs":[{"name":"blockNumber","type":"uint256"}]
+	}]`))
+	if err != nil {
+		panic("parse multicall3 abi: " + err.Error())
+	}
+}
+
+// Call is a single call in a batch. It matches the Multicall3.Call3 struct layout for ABI encoding.
+type Call struct {
+	Target       common.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+// Result is the outcome of a single call.
+type Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// NewCall packs method(args...) of contract into a Call that must succeed.
+func NewCall(contract *abi.ABI, target common.Address, method string, args ...any) (Call, error) {
+	data, err := contract.Pack(method, args...)
+	if err != nil {
+		return Call{}, fmt.Errorf("pack %s: %w", method, err)
+	}
+
+	return Call{Target: target, CallData: data}, nil
+}
+
+// BlockNumberCall returns a call to Multicall3.getBlockNumber, used to learn which block a batch ran against.
+func BlockNumberCall() Call {
+	data, _ := multicall3ABI.Pack("getBlockNumber") //nolint:errcheck // packing a no-arg method cannot fail
+
+	return Call{Target: Address, CallData: data}
+}
+
+// DecodeBlockNumber decodes the result of BlockNumberCall.
+func DecodeBlockNumber(r Result) (*big.Int, error) {
+	var n *big.Int
+	if err := Decode(&multicall3ABI, "getBlockNumber", r, &n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Aggregate3 executes calls in one eth_call at blockNumber (nil means latest).
+// A failed call with AllowFailure=false reverts the whole batch.
+func Aggregate3(ctx context.Context, caller ethereum.ContractCaller, calls []Call, blockNumber *big.Int) ([]Result, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	input, err := multicall3ABI.Pack("aggregate3", calls)
+	if err != nil {
+		return nil, fmt.Errorf("pack aggregate3: %w", err)
+	}
+
+	output, err := caller.CallContract(ctx, ethereum.CallMsg{
+		To:   &Address,
+		Data: input,
+	}, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("multicall3 aggregate3: %w", err)
+	}
+
+	decoded, err := multicall3ABI.Unpack("aggregate3", output)
+	if err != nil {
+		return nil, fmt.Errorf("unpack aggregate3: %w", err)
+	}
+
+	raw, ok := decoded[0].([]struct {
+		Success    bool   `json:"success"`
+		ReturnData []byte `json:"returnData"`
+	})
+	if !ok {
+		return nil, fmt.Errorf("unexpected aggregate3 result type: %T", decoded[0])
+	}
+
+	if len(raw) != len(calls) {
+		return nil, fmt.Errorf("multicall3 returned %d results, expected %d", len(raw), len(calls))
+	}
+
+	results := make([]Result, len(raw))
+	for i, r := range raw {
+		results[i] = Result{Success: r.Success, ReturnData: r.ReturnData}
+	}
+
+	return results, nil
+}
+
+// Decode unpacks the outputs of method from r into out (pointers, one per output).
+// Returns ErrCallFailed when the call did not succeed.
+func Decode(contract *abi.ABI, method string, r Result, out ...any) error {
+	if !r.Success {
+		return fmt.Errorf("%s: %w", method, ErrCallFailed)
+	}
+
+	m, ok := contract.Methods[method]
+	if !ok {
+		return fmt.Errorf("method %s not in abi", method)
+	}
+
+	values, err := m.Outputs.Unpack(r.ReturnData)
+	if err != nil {
+		return fmt.Errorf("unpack %s: %w", method, err)
+	}
+
+	if len(values) != len(out) {
+		return fmt.Errorf("%s returned %d values, expected %d", method, len(values), len(out))
+	}
+
+	for i, v := range values {
+		if err := assign(out[i], v); err != nil {
+			return fmt.Errorf("%s output %d: %w", method, i, err)
+		}
+	}
+
+	return nil
+}
+
+func assign(dst, v any) error {
+	switch d := dst.(type) {
+	case **big.Int:
+		n, ok := v.(*big.Int)
+		if !ok {
+			return fmt.Errorf("expected *big.Int, got %T", v)
+		}
+
+		*d = n
+	case *common.Address:
+		a, ok := v.(common.Address)
+		if !ok {
+			return fmt.Errorf("expected common.Address, got %T", v)
+		}
+
+		*d = a
+	case *bool:
+		b, ok := v.(bool)
+		if !ok {
+			return fmt.Errorf("expected bool, got %T", v)
+		}
+
+		*d = b
+	case *[32]byte:
+		b, ok := v.([32]byte)
+		if !ok {
+			return fmt.Errorf("expected [32]byte, got %T", v)
+		}
+
+		*d = b
+	case *any:
+		*d = v
+	default:
+		return fmt.Errorf("unsupported output type %T", dst)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package multicall
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var testABI = mustABI(`[{
+	"name":"balanceOf","type":"function",
+	"inputs":[{"name":"owner","type":"address"}],
+	"outputs":[{"name":"","type":"uint256"}]
+}]`)
+
+func mustABI(s string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(s))
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+// fakeMulticall answers aggregate3 by echoing each call's first argument word as a uint256,
+// failing calls whose target is the zero address.
+type fakeMulticall struct {
+	block *big.Int
+}
+
+func (f *fakeMulticall) CallContract(_ context.Context, msg ethereum.CallMsg, block *big.Int) ([]byte, error) {
+	f.block = block
+
+	args, err := multicall3ABI.Methods["aggregate3"].Inputs.Unpack(msg.Data[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	calls, ok := args[0].([]struct {
+		Target       common.Address `json:"target"`
+		AllowFailure bool           `json:"allowFailure"`
+		CallData     []byte         `json:"callData"`
+	})
+	if !ok {
+		return nil, errors.New("unexpected calls type")
+	}
+
+	type result struct {
+		Success    bool
+		ReturnData []byte
+	}
+
+	results := make([]result, len(calls))
+	for i, c := range calls {
+		if c.Target == (common.Address{}) {
+			results[i] = result{}
+			continue
+		}
+
+		results[i] = result{Success: true, ReturnData: c.CallData[4:36]}
+	}
+
+	return multicall3ABI.Methods["aggregate3"].Outputs.Pack(results)
+}
+
+func TestAggregate3(t *testing.T) {
+	t.Parallel()
+
+	target := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000ff")
+
+	ok, err := NewCall(&testABI, target, "balanceOf", owner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failing := ok
+	failing.Target = common.Address{}
+	failing.AllowFailure = true
+
+	caller := &fakeMulticall{}
+
+	results, err := Aggregate3(context.Background(), caller, []Call{ok, failing}, big.NewInt(42))
+	if err != nil {
+		t.Fatalf("aggregate3: %v", err)
+	}
+
+	if caller.block == nil || caller.block.Int64() != 42 {
+		t.Errorf("expected call pinned to block 42, got %v", caller.block)
+	}
+
+	var balance *big.Int
+	if err := Decode(&testABI, "balanceOf", results[0], &balance); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if balance.Cmp(new(big.Int).SetBytes(owner.Bytes())) != 0 {
+		t.Errorf("unexpected decoded value %s", balance)
+	}
+
+	if err := Decode(&testABI, "balanceOf", results[1], &balance); !errors.Is(err, ErrCallFailed) {
+		t.Errorf("expected ErrCallFailed, got %v", err)
+	}
+}
+
+func TestAggregate3_Empty(t *testing.T) {
+	t.Parallel()
+
+	results, err := Aggregate3(context.Background(), &fakeMulticall{}, nil, nil)
+	if err != nil || results != nil {
+		t.Errorf("expected no-op for empty batch, got %v, %v", results, err)
+	}
+}
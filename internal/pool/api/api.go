@@ -0,0 +1,175 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/httpwrap"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/pool"
+	"remora/internal/token"
+)
+
+const (
+	defaultPoolsLimit = 50
+	maxPoolsLimit     = 200
+)
+
+// AddRoutes registers pool registry routes on the provided router.
+func AddRoutes(r chi.Router, svc pool.Service) {
+	r.Get("/pools", httpwrap.Handler(listPools(svc)))
+	r.Get("/pools/{poolId}", httpwrap.Handler(getPool(svc)))
+}
+
+// PoolKeyResponse is a Uniswap v4 pool key, usable as-is in distribution requests.
+type PoolKeyResponse struct {
+	Currency0   string `json:"currency0"`
+	Currency1   string `json:"currency1"`
+	Fee         uint32 `json:"fee"`
+	TickSpacing int32  `json:"tickSpacing"`
+	Hooks       string `json:"hooks"`
+}
+
+// TokenResponse represents pool token metadata in the API response.
+type TokenResponse struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Decimals uint8  `json:"decimals"`
+}
+
+// PoolResponse is a registered pool.
+type PoolResponse struct {
+	PoolID           string          `json:"poolId"`
+	PoolKey          PoolKeyResponse `json:"poolKey"`
+	Token0           *TokenResponse  `json:"token0,omitempty"` // Omitted when token metadata is unavailable
+	Token1           *TokenResponse  `json:"token1,omitempty"`
+	InitBlock        uint64          `json:"initBlock"`
+	InitTxHash       string          `json:"initTxHash"`
+	InitSqrtPriceX96 string          `json:"initSqrtPriceX96"`
+	InitTick         int32           `json:"initTick"`
+}
+
+// PoolsResponse is a page of pools, newest first.
+type PoolsResponse struct {
+	Pools []PoolResponse `json:"pools"`
+}
+
+// listPools returns a handler that lists pools, optionally filtered by ?token=.
+func listPools(svc pool.Service) func(*http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		filter, errResp := parseFilter(r.URL.Query())
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		pools, err := svc.List(r.Context(), filter)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "list pools failed", slog.String("error", err.Error())) //nolint:sloglint // handler error logging, logger not injected in API layer
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "list pools failed",
+				Err:        err,
+			}
+		}
+
+		resp := &PoolsResponse{Pools: make([]PoolResponse, len(pools))}
+		for i := range pools {
+			resp.Pools[i] = toPoolResponse(&pools[i])
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusOK, Body: resp}, nil
+	}
+}
+
+// getPool returns a handler that looks up a pool by ID.
+func getPool(svc pool.Service) func(*http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		id, err := poolid.ParsePoolID(chi.URLParam(r, "poolId"))
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("poolId")
+		}
+
+		p, err := svc.Get(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, pool.ErrNotFound) {
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusNotFound,
+					ErrorMsg:   "pool not found",
+					Err:        err,
+				}
+			}
+
+			slog.ErrorContext(r.Context(), "get pool failed", slog.String("pool_id", id.Hex()), slog.String("error", err.Error())) //nolint:sloglint // handler error logging, logger not injected in API layer
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "get pool failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusOK, Body: toPoolResponse(p)}, nil
+	}
+}
+
+func parseFilter(q url.Values) (pool.Filter, *httpwrap.ErrorResponse) {
+	filter := pool.Filter{Limit: defaultPoolsLimit}
+
+	if raw := q.Get("token"); raw != "" {
+		if !common.IsHexAddress(raw) {
+			return pool.Filter{}, httpwrap.NewInvalidParamErrorResponse("token")
+		}
+
+		addr := common.HexToAddress(raw)
+		filter.Token = &addr
+	}
+
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit <= 0 || limit > maxPoolsLimit {
+			return pool.Filter{}, httpwrap.NewInvalidParamErrorResponse("limit")
+		}
+
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+func toPoolResponse(p *pool.Pool) PoolResponse {
+	return PoolResponse{
+		PoolID: p.ID.Hex(),
+		PoolKey: PoolKeyResponse{
+			Currency0:   p.Key.Currency0,
+			Currency1:   p.Key.Currency1,
+			Fee:         p.Key.Fee,
+			TickSpacing: p.Key.TickSpacing,
+			Hooks:       p.Key.Hooks,
+		},
+		Token0:           toTokenResponse(p.Token0),
+		Token1:           toTokenResponse(p.Token1),
+		InitBlock:        p.InitBlock,
+		InitTxHash:       p.InitTxHash.Hex(),
+		InitSqrtPriceX96: p.SqrtPriceX96.String(),
+		InitTick:         p.Tick,
+	}
+}
+
+func toTokenResponse(t *token.Token) *TokenResponse {
+	if t == nil {
+		return nil
+	}
+
+	return &TokenResponse{
+		Address:  t.Address.Hex(),
+		Symbol:   t.Symbol,
+		Decimals: t.Decimals,
+	}
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+
+	usdc := common.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+
+	tests := []struct {
+		query     string
+		wantErr   bool
+		wantToken *common.Address
+		wantLimit int
+	}{
+		{query: "", wantLimit: defaultPoolsLimit},
+		{query: "token=0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48&limit=10", wantToken: &usdc, wantLimit: 10},
+		{query: "token=usdc", wantErr: true},
+		{query: "limit=0", wantErr: true},
+		{query: "limit=1000", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		q, _ := url.ParseQuery(tt.query)
+
+		f, errResp := parseFilter(q)
+		if (errResp != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.query, errResp, tt.wantErr)
+
+			continue
+		}
+
+		if tt.wantErr {
+			continue
+		}
+
+		if f.Limit != tt.wantLimit || (f.Token == nil) != (tt.wantToken == nil) || (f.Token != nil && *f.Token != *tt.wantToken) {
+			t.Errorf("%q: filter = %+v", tt.query, f)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package pool
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a pool has not been indexed.
+	ErrNotFound = errors.New("pool not found")
+
+	// ErrNotIndexed is returned before the indexer has stored its first batch.
+	ErrNotIndexed = errors.New("pools not indexed")
+
+	// ErrInvalidEvent is returned for an Initialize log that cannot be decoded.
+	ErrInvalidEvent = errors.New("invalid initialize event")
+)
@@ -0,0 +1,88 @@
+package pool
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity/poolid"
+)
+
+const initializeABI = `[{"anonymous":false,"type":"event","name":"Initialize","inputs":[
+	{"indexed":true,"name":"id","type":"bytes32"},
+	{"indexed":true,"name":"currency0","type":"address"},
+	{"indexed":true,"name":"currency1","type":"address"},
+	{"indexed":false,"name":"fee","type":"uint24"},
+	{"indexed":false,"name":"tickSpacing","type":"int24"},
+	{"indexed":false,"name":"hooks","type":"address"},
+	{"indexed":false,"name":"sqrtPriceX96","type":"uint160"},
+	{"indexed":false,"name":"tick","type":"int24"}]}]`
+
+const initializeTopics = 4
+
+var initializeEvent = func() abi.Event {
+	parsed, err := abi.JSON(strings.NewReader(initializeABI))
+	if err != nil {
+		panic(fmt.Sprintf("parse initialize abi: %v", err))
+	}
+
+	return parsed.Events["Initialize"]
+}()
+
+// InitializeTopic is the topic of the PoolManager Initialize event.
+func InitializeTopic() common.Hash {
+	return initializeEvent.ID
+}
+
+// ParseInitialize decodes a PoolManager Initialize log. The pool ID in the log must match
+// the one computed from the decoded key.
+func ParseInitialize(lg types.Log) (Pool, error) {
+	if len(lg.Topics) != initializeTopics || lg.Topics[0] != initializeEvent.ID {
+		return Pool{}, fmt.Errorf("%w: unexpected topics", ErrInvalidEvent)
+	}
+
+	var data struct {
+		Fee          *big.Int
+		TickSpacing  *big.Int
+		Hooks        common.Address
+		SqrtPriceX96 *big.Int
+		Tick         *big.Int
+	}
+
+	args := initializeEvent.Inputs.NonIndexed()
+
+	values, err := args.Unpack(lg.Data)
+	if err != nil {
+		return Pool{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	if err := args.Copy(&data, values); err != nil {
+		return Pool{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	key := poolid.PoolKey{
+		Currency0:   common.BytesToAddress(lg.Topics[2].Bytes()).Hex(),
+		Currency1:   common.BytesToAddress(lg.Topics[3].Bytes()).Hex(),
+		Fee:         uint32(data.Fee.Uint64()),       //nolint:gosec // uint24
+		TickSpacing: int32(data.TickSpacing.Int64()), //nolint:gosec // int24
+		Hooks:       data.Hooks.Hex(),
+	}
+
+	id := lg.Topics[1]
+	if computed := common.Hash(poolid.CalculatePoolID(&key)); computed != id {
+		return Pool{}, fmt.Errorf("%w: pool id %s does not match key (%s)", ErrInvalidEvent, id.Hex(), computed.Hex())
+	}
+
+	return Pool{
+		ID:           id,
+		Key:          key,
+		InitBlock:    lg.BlockNumber,
+		InitTxHash:   lg.TxHash,
+		SqrtPriceX96: data.SqrtPriceX96,
+		Tick:         int32(data.Tick.Int64()), //nolint:gosec // int24
+	}, nil
+}
@@ -0,0 +1,74 @@
+package pool
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity/poolid"
+)
+
+// initializeLog builds the Initialize log PoolManager emits for key.
+func initializeLog(t *testing.T, key poolid.PoolKey, block uint64, tick int32) types.Log {
+	t.Helper()
+
+	data, err := initializeEvent.Inputs.NonIndexed().Pack(
+		big.NewInt(int64(key.Fee)),
+		big.NewInt(int64(key.TickSpacing)),
+		common.HexToAddress(key.Hooks),
+		new(big.Int).Lsh(big.NewInt(1), 96),
+		big.NewInt(int64(tick)),
+	)
+	if err != nil {
+		t.Fatalf("pack initialize data: %v", err)
+	}
+
+	return types.Log{
+		Topics: []common.Hash{
+			InitializeTopic(),
+			poolid.CalculatePoolID(&key),
+			common.BytesToHash(common.HexToAddress(key.Currency0).Bytes()),
+			common.BytesToHash(common.HexToAddress(key.Currency1).Bytes()),
+		},
+		Data:        data,
+		BlockNumber: block,
+		TxHash:      common.Hash{byte(block)},
+	}
+}
+
+func TestParseInitialize(t *testing.T) {
+	key := poolid.PoolKey{
+		Currency0:   "0x0000000000000000000000000000000000000000",
+		Currency1:   "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Fee:         3000,
+		TickSpacing: 60,
+		Hooks:       "0x0000000000000000000000000000000000000000",
+	}
+
+	p, err := ParseInitialize(initializeLog(t, key, 7, -195000))
+	if err != nil {
+		t.Fatalf("ParseInitialize() error = %v", err)
+	}
+
+	if p.Key != key {
+		t.Errorf("Key = %+v, want %+v", p.Key, key)
+	}
+
+	if p.ID != poolid.CalculatePoolID(&key) || p.InitBlock != 7 || p.Tick != -195000 {
+		t.Errorf("pool = %+v", p)
+	}
+
+	if p.SqrtPriceX96.Cmp(new(big.Int).Lsh(big.NewInt(1), 96)) != 0 {
+		t.Errorf("SqrtPriceX96 = %s", p.SqrtPriceX96)
+	}
+
+	lg := initializeLog(t, key, 7, 0)
+	lg.Topics[1] = common.Hash{1}
+
+	if _, err := ParseInitialize(lg); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("mismatched pool id error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
@@ -0,0 +1,134 @@
+// Package indexer registers Uniswap v4 pools from PoolManager Initialize events.
+//
+// Logs are scanned from StartBlock up to head minus Confirmations, so reorged pools are never
+// stored. Token metadata of each new pool is resolved as it is indexed, so lookups later do
+// not hit the chain.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/logscan"
+	"remora/internal/pool"
+	"remora/internal/token"
+)
+
+// defaults apply to zero scan settings; pools are few, so wide ranges rarely hit provider limits.
+var defaults = logscan.Config{Confirmations: 12, BlockRange: 10000, Interval: time.Minute} //nolint:gochecknoglobals // fixed defaults
+
+// Config configures the pool indexer.
+type Config struct {
+	logscan.Config
+
+	// PoolManager is the address of the Uniswap v4 PoolManager; StartBlock is its deploy block.
+	PoolManager common.Address
+}
+
+// Indexer indexes PoolManager Initialize events.
+type Indexer struct {
+	scanner     *logscan.Scanner
+	repo        pool.Repository
+	tokens      token.Service
+	poolManager common.Address
+	logger      *slog.Logger
+}
+
+// New creates an indexer. tokens may be nil to skip resolving token metadata.
+func New(client logscan.Client, repo pool.Repository, tokens token.Service, cfg Config, logger *slog.Logger) *Indexer {
+	return &Indexer{
+		scanner:     logscan.New(client, nil, cfg.Config, defaults, logger),
+		repo:        repo,
+		tokens:      tokens,
+		poolManager: cfg.PoolManager,
+		logger:      logger,
+	}
+}
+
+// Run keeps the pool registry current until ctx is cancelled.
+func (ix *Indexer) Run(ctx context.Context) {
+	ix.scanner.Run(ctx, "pool indexer", ix.Sync)
+}
+
+// Sync indexes Initialize events up to the latest confirmed block.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	safe, ok, err := ix.scanner.SafeBlock(ctx)
+	if err != nil || !ok {
+		return err //nolint:wrapcheck // already wrapped by the scanner
+	}
+
+	q := ethereum.FilterQuery{
+		Addresses: []common.Address{ix.poolManager},
+		Topics:    [][]common.Hash{{pool.InitializeTopic()}},
+	}
+
+	_, err = logscan.Sync(ctx, ix.scanner, q, safe, cursor{ix}, ix.decode)
+
+	return err //nolint:wrapcheck // already wrapped by the scanner
+}
+
+func (ix *Indexer) decode(lg logscan.Log) (pool.Pool, error) {
+	p, err := pool.ParseInitialize(lg.Log)
+	if err != nil {
+		ix.logger.Warn("skipping initialize log", slog.String("tx", lg.TxHash.Hex()), slog.Any("error", err))
+
+		return pool.Pool{}, logscan.ErrSkip
+	}
+
+	return p, nil
+}
+
+// cursor saves indexed pools to the registry and resolves their tokens.
+type cursor struct{ ix *Indexer }
+
+func (c cursor) IndexedBlock(ctx context.Context) (uint64, bool, error) {
+	block, err := c.ix.repo.IndexedBlock(ctx)
+	if errors.Is(err, pool.ErrNotIndexed) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err //nolint:wrapcheck // wrapped by logscan.Sync
+	}
+
+	return block, true, nil
+}
+
+func (c cursor) Save(ctx context.Context, pools []pool.Pool, block uint64) error {
+	if err := c.ix.repo.SaveBatch(ctx, pools, block); err != nil {
+		return fmt.Errorf("save pools: %w", err)
+	}
+
+	for _, p := range pools {
+		c.ix.resolveTokens(ctx, p)
+	}
+
+	if len(pools) > 0 {
+		c.ix.logger.InfoContext(ctx, "pools indexed", slog.Int("pools", len(pools)), slog.Uint64("to_block", block))
+	}
+
+	return nil
+}
+
+// resolveTokens stores the token metadata of p through the token service. Failures are
+// logged only; currencies that are not ERC20 tokens are expected.
+func (ix *Indexer) resolveTokens(ctx context.Context, p pool.Pool) {
+	if ix.tokens == nil {
+		return
+	}
+
+	for _, addr := range []string{p.Key.Currency0, p.Key.Currency1} {
+		if _, err := ix.tokens.Get(ctx, common.HexToAddress(addr)); err != nil {
+			ix.logger.WarnContext(ctx, "resolve pool token failed",
+				slog.String("pool_id", p.ID.Hex()),
+				slog.String("token", addr),
+				slog.Any("error", err))
+		}
+	}
+}
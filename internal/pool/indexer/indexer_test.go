@@ -0,0 +1,184 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/liquidity/poolid"
+	"remora/internal/logscan"
+	"remora/internal/pool"
+	"remora/internal/token"
+)
+
+var poolManager = common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90")
+
+func initializeLog(t *testing.T, key poolid.PoolKey, block uint64) types.Log {
+	t.Helper()
+
+	newType := func(name string) abi.Type {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return typ
+	}
+
+	args := abi.Arguments{
+		{Type: newType("uint24")}, {Type: newType("int24")}, {Type: newType("address")},
+		{Type: newType("uint160")}, {Type: newType("int24")},
+	}
+
+	data, err := args.Pack(big.NewInt(int64(key.Fee)), big.NewInt(int64(key.TickSpacing)),
+		common.HexToAddress(key.Hooks), big.NewInt(1), big.NewInt(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return types.Log{
+		Address: poolManager,
+		Topics: []common.Hash{
+			pool.InitializeTopic(),
+			poolid.CalculatePoolID(&key),
+			common.BytesToHash(common.HexToAddress(key.Currency0).Bytes()),
+			common.BytesToHash(common.HexToAddress(key.Currency1).Bytes()),
+		},
+		Data:        data,
+		BlockNumber: block,
+	}
+}
+
+// fakeChain serves logs by block and rejects ranges wider than maxRange.
+type fakeChain struct {
+	head     uint64
+	logs     []types.Log
+	maxRange uint64
+	queries  int
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	f.queries++
+
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if f.maxRange > 0 && to-from+1 > f.maxRange {
+		return nil, errors.New("block range too large")
+	}
+
+	var out []types.Log
+
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= from && lg.BlockNumber <= to {
+			out = append(out, lg)
+		}
+	}
+
+	return out, nil
+}
+
+type memRepo struct {
+	pools   map[common.Hash]pool.Pool
+	indexed *uint64
+}
+
+func (m *memRepo) Get(_ context.Context, id common.Hash) (*pool.Pool, error) {
+	p, ok := m.pools[id]
+	if !ok {
+		return nil, pool.ErrNotFound
+	}
+
+	return &p, nil
+}
+
+func (m *memRepo) List(context.Context, pool.Filter) ([]pool.Pool, error) { return nil, nil }
+
+func (m *memRepo) IndexedBlock(context.Context) (uint64, error) {
+	if m.indexed == nil {
+		return 0, pool.ErrNotIndexed
+	}
+
+	return *m.indexed, nil
+}
+
+func (m *memRepo) SaveBatch(_ context.Context, pools []pool.Pool, indexedBlock uint64) error {
+	for _, p := range pools {
+		m.pools[p.ID] = p
+	}
+
+	m.indexed = &indexedBlock
+
+	return nil
+}
+
+type countingTokens map[common.Address]int
+
+func (c countingTokens) Get(_ context.Context, addr common.Address) (*token.Token, error) {
+	c[addr]++
+
+	return &token.Token{Address: addr}, nil
+}
+
+func (c countingTokens) GetMany(ctx context.Context, addrs []common.Address) (map[common.Address]*token.Token, error) {
+	tokens := make(map[common.Address]*token.Token, len(addrs))
+	for _, addr := range addrs {
+		tokens[addr], _ = c.Get(ctx, addr)
+	}
+
+	return tokens, nil
+}
+
+func TestIndexer_Sync(t *testing.T) {
+	ethUSDC := poolid.PoolKey{
+		Currency0: "0x0000000000000000000000000000000000000000", Currency1: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Fee: 500, TickSpacing: 10, Hooks: "0x0000000000000000000000000000000000000000",
+	}
+	usdcUSDT := poolid.PoolKey{
+		Currency0: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Currency1: "0xdAC17F958D2ee523a2206206994597C13D831ec7",
+		Fee: 100, TickSpacing: 1, Hooks: "0x0000000000000000000000000000000000000000",
+	}
+
+	chain := &fakeChain{head: 130, maxRange: 50, logs: []types.Log{initializeLog(t, ethUSDC, 105)}}
+	repo := &memRepo{pools: make(map[common.Hash]pool.Pool)}
+	tokens := countingTokens{}
+
+	cfg := Config{Config: logscan.Config{StartBlock: 100, Confirmations: 10, BlockRange: 200}, PoolManager: poolManager}
+	ix := New(chain, repo, tokens, cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := ix.Sync(t.Context()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if repo.indexed == nil || *repo.indexed != 120 {
+		t.Fatalf("indexed block = %v, want 120", repo.indexed)
+	}
+
+	if _, ok := repo.pools[poolid.CalculatePoolID(&ethUSDC)]; !ok {
+		t.Error("ETH/USDC pool not stored")
+	}
+
+	if tokens[common.HexToAddress(ethUSDC.Currency1)] != 1 {
+		t.Errorf("token lookups = %v, want USDC resolved once", tokens)
+	}
+
+	// The next pass resumes after the cursor and ignores blocks already indexed.
+	chain.head = 160
+	chain.logs = append(chain.logs, initializeLog(t, usdcUSDT, 121))
+
+	if err := ix.Sync(t.Context()); err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+
+	if *repo.indexed != 150 || len(repo.pools) != 2 {
+		t.Errorf("indexed = %d with %d pools, want 150 and 2", *repo.indexed, len(repo.pools))
+	}
+}
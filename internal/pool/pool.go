@@ -0,0 +1,51 @@
+// Package pool is the registry of Uniswap v4 pools discovered from PoolManager Initialize
+// events, so clients can find a pool by token or resolve a pool ID to its key.
+package pool
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity/poolid"
+	"remora/internal/token"
+)
+
+// Pool is an initialized Uniswap v4 pool.
+type Pool struct {
+	ID           common.Hash
+	Key          poolid.PoolKey
+	InitBlock    uint64
+	InitTxHash   common.Hash
+	SqrtPriceX96 *big.Int     // Price the pool was initialized at
+	Tick         int32        // Tick the pool was initialized at
+	Token0       *token.Token // Filled by the service; nil when metadata is unavailable
+	Token1       *token.Token
+}
+
+// Filter selects pools, newest first.
+type Filter struct {
+	Token *common.Address // Pools with this currency on either side; nil for all pools
+	Limit int
+}
+
+// Service looks up registered pools.
+type Service interface {
+	// Get returns ErrNotFound for a pool that has not been indexed.
+	Get(ctx context.Context, id common.Hash) (*Pool, error)
+	List(ctx context.Context, filter Filter) ([]Pool, error)
+}
+
+// Repository stores registered pools and the indexer's progress.
+type Repository interface {
+	// Get returns ErrNotFound for a pool that has not been stored.
+	Get(ctx context.Context, id common.Hash) (*Pool, error)
+	List(ctx context.Context, filter Filter) ([]Pool, error)
+
+	// IndexedBlock returns the last block indexed, or ErrNotIndexed before the first batch.
+	IndexedBlock(ctx context.Context) (uint64, error)
+
+	// SaveBatch stores pools and advances the indexed block in one transaction.
+	SaveBatch(ctx context.Context, pools []Pool, indexedBlock uint64) error
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"remora/internal/db"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/pool"
+)
+
+// cursorName is the indexer_cursor row of the Initialize indexer.
+const cursorName = "pool_initialize"
+
+// Repository stores registered pools in Postgres.
+type Repository struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+var _ pool.Repository = (*Repository)(nil)
+
+func New(pgPool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pgPool, q: db.New(pgPool)}
+}
+
+func (r *Repository) Get(ctx context.Context, id common.Hash) (*pool.Pool, error) {
+	row, err := r.q.GetPool(ctx, id.Hex())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pool.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("get pool: %w", err)
+	}
+
+	p := toDomain(row)
+
+	return &p, nil
+}
+
+func (r *Repository) List(ctx context.Context, filter pool.Filter) ([]pool.Pool, error) {
+	params := db.ListPoolsParams{RowLimit: int32(filter.Limit)} //nolint:gosec // limit is bounded by the API
+	if filter.Token != nil {
+		params.Token = filter.Token.Hex()
+	}
+
+	rows, err := r.q.ListPools(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("list pools: %w", err)
+	}
+
+	pools := make([]pool.Pool, len(rows))
+	for i, row := range rows {
+		pools[i] = toDomain(row)
+	}
+
+	return pools, nil
+}
+
+func (r *Repository) IndexedBlock(ctx context.Context) (uint64, error) {
+	block, err := r.q.GetIndexerCursor(ctx, cursorName)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, pool.ErrNotIndexed
+		}
+
+		return 0, fmt.Errorf("get pool indexer cursor: %w", err)
+	}
+
+	return uint64(block), nil //nolint:gosec // block numbers are non-negative
+}
+
+func (r *Repository) SaveBatch(ctx context.Context, pools []pool.Pool, indexedBlock uint64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	q := r.q.WithTx(tx)
+	now := time.Now().UTC()
+
+	for _, p := range pools {
+		if err := q.InsertPool(ctx, db.InsertPoolParams{
+			PoolID:           p.ID.Hex(),
+			Currency0:        common.HexToAddress(p.Key.Currency0).Hex(),
+			Currency1:        common.HexToAddress(p.Key.Currency1).Hex(),
+			Fee:              int(p.Key.Fee),
+			TickSpacing:      int(p.Key.TickSpacing),
+			Hooks:            common.HexToAddress(p.Key.Hooks).Hex(),
+			InitBlock:        int64(p.InitBlock), //nolint:gosec // block numbers fit in int64
+			InitTxHash:       p.InitTxHash.Hex(),
+			InitSqrtPriceX96: decimal.NewFromBigInt(p.SqrtPriceX96, 0),
+			InitTick:         int(p.Tick),
+			CreatedAt:        now,
+		}); err != nil {
+			return fmt.Errorf("insert pool: %w", err)
+		}
+	}
+
+	if err := q.UpsertIndexerCursor(ctx, db.UpsertIndexerCursorParams{
+		Name:        cursorName,
+		BlockNumber: int64(indexedBlock), //nolint:gosec // block numbers fit in int64
+		UpdatedAt:   now,
+	}); err != nil {
+		return fmt.Errorf("save pool indexer cursor: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func toDomain(row db.Pool) pool.Pool {
+	return pool.Pool{
+		ID: common.HexToHash(row.PoolID),
+		Key: poolid.PoolKey{
+			Currency0:   row.Currency0,
+			Currency1:   row.Currency1,
+			Fee:         uint32(row.Fee),        //nolint:gosec // uint24
+			TickSpacing: int32(row.TickSpacing), //nolint:gosec // int24
+			Hooks:       row.Hooks,
+		},
+		InitBlock:    uint64(row.InitBlock), //nolint:gosec // block numbers are non-negative
+		InitTxHash:   common.HexToHash(row.InitTxHash),
+		SqrtPriceX96: row.InitSqrtPriceX96.BigInt(),
+		Tick:         int32(row.InitTick), //nolint:gosec // int24
+	}
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/pool"
+	"remora/internal/token"
+)
+
+// Service reads registered pools and attaches their token metadata.
+type Service struct {
+	repo   pool.Repository
+	tokens token.Service
+	logger *slog.Logger
+}
+
+var (
+	_ pool.Service           = (*Service)(nil)
+	_ liquidity.PoolRegistry = (*Service)(nil)
+)
+
+// New creates a pool service. tokens may be nil, in which case pools carry no token metadata.
+func New(repo pool.Repository, tokens token.Service, logger *slog.Logger) *Service {
+	return &Service{repo: repo, tokens: tokens, logger: logger}
+}
+
+func (s *Service) Get(ctx context.Context, id common.Hash) (*pool.Pool, error) {
+	p, err := s.repo.Get(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get pool: %w", err)
+	}
+
+	s.fillTokens(ctx, []*pool.Pool{p})
+
+	return p, nil
+}
+
+func (s *Service) List(ctx context.Context, filter pool.Filter) ([]pool.Pool, error) {
+	pools, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("list pools: %w", err)
+	}
+
+	ptrs := make([]*pool.Pool, len(pools))
+	for i := range pools {
+		ptrs[i] = &pools[i]
+	}
+
+	s.fillTokens(ctx, ptrs)
+
+	return pools, nil
+}
+
+// PoolKey resolves a pool ID to its key, so liquidity requests can name a pool by ID alone.
+func (s *Service) PoolKey(ctx context.Context, id common.Hash) (*poolid.PoolKey, error) {
+	p, err := s.repo.Get(ctx, id)
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", liquidity.ErrUnknownPool, id.Hex())
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("get pool: %w", err)
+	}
+
+	return &p.Key, nil
+}
+
+// fillTokens attaches token metadata, resolving the distinct currencies of pools in one batch.
+// A side is left nil when its token cannot be read.
+func (s *Service) fillTokens(ctx context.Context, pools []*pool.Pool) {
+	if s.tokens == nil || len(pools) == 0 {
+		return
+	}
+
+	addrs := make([]common.Address, 0, 2*len(pools))
+	for _, p := range pools {
+		addrs = append(addrs, common.HexToAddress(p.Key.Currency0), common.HexToAddress(p.Key.Currency1))
+	}
+
+	tokens, err := s.tokens.GetMany(ctx, addrs)
+	if err != nil {
+		s.logger.WarnContext(ctx, "pool token metadata unavailable", slog.Int("pools", len(pools)), slog.Any("error", err))
+	}
+
+	for _, p := range pools {
+		p.Token0 = tokens[common.HexToAddress(p.Key.Currency0)]
+		p.Token1 = tokens[common.HexToAddress(p.Key.Currency1)]
+	}
+}
@@ -0,0 +1,5 @@
+package ratelimit
+
+import "errors"
+
+var ErrUnexpectedReply = errors.New("unexpected redis reply")
@@ -0,0 +1,80 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// openPeriod is how long requests skip the primary after it fails, so an outage does not cost
+// every request a primary timeout.
+const openPeriod = 5 * time.Second
+
+// FallbackLimiter uses primary and switches to fallback for requests where primary fails,
+// e.g. a RedisLimiter backed by a MemoryLimiter while Redis is down. After a failure the
+// circuit opens: requests go straight to fallback for openPeriod, then a single request probes
+// primary and closes the circuit if it succeeds.
+type FallbackLimiter struct {
+	primary  Limiter
+	fallback Limiter
+	logger   *slog.Logger
+	now      func() time.Time
+	// degraded tracks whether the last call failed over, so outages are logged once.
+	degraded atomic.Bool
+	// openUntil is when the open circuit next lets a probe through, in Unix nanoseconds; 0 while closed.
+	openUntil atomic.Int64
+	// probing is set while a probe of primary is in flight.
+	probing atomic.Bool
+}
+
+var _ Limiter = (*FallbackLimiter)(nil)
+
+func NewFallbackLimiter(primary, fallback Limiter, logger *slog.Logger) *FallbackLimiter {
+	return &FallbackLimiter{primary: primary, fallback: fallback, logger: logger, now: time.Now}
+}
+
+func (f *FallbackLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	skip, probe := f.circuit()
+	if skip {
+		return f.fallback.Allow(ctx, key, limit)
+	}
+
+	if probe {
+		defer f.probing.Store(false)
+	}
+
+	res, err := f.primary.Allow(ctx, key, limit)
+	if err == nil {
+		f.openUntil.Store(0)
+
+		if f.degraded.CompareAndSwap(true, false) {
+			f.logger.InfoContext(ctx, "rate limiter recovered")
+		}
+
+		return res, nil
+	}
+
+	f.openUntil.Store(f.now().Add(openPeriod).UnixNano())
+
+	if f.degraded.CompareAndSwap(false, true) {
+		f.logger.WarnContext(ctx, "rate limiter failed, using fallback", slog.Any("error", err))
+	}
+
+	return f.fallback.Allow(ctx, key, limit)
+}
+
+// circuit reports whether a request should skip primary, and whether it is the probe that
+// decides if the open circuit closes.
+func (f *FallbackLimiter) circuit() (skip, probe bool) {
+	until := f.openUntil.Load()
+	if until == 0 {
+		return false, false
+	}
+
+	if f.now().UnixNano() < until || !f.probing.CompareAndSwap(false, true) {
+		return true, false
+	}
+
+	return false, true
+}
@@ -0,0 +1,113 @@
+// Package ratelimit implements token-bucket rate limiting backed by Redis or process memory.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strings"
+	"time"
+)
+
+// Limit is a token bucket: Requests tokens refill evenly over Period, holding at most Burst.
+// A zero Limit disables limiting.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+}
+
+// Enabled reports whether the limit restricts anything.
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// Capacity is the bucket size; it defaults to Requests when Burst is unset.
+func (l Limit) Capacity() int {
+	if l.Burst > 0 {
+		return l.Burst
+	}
+
+	return l.Requests
+}
+
+// rate returns the refill rate in tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Result is the outcome of taking a token.
+type Result struct {
+	Allowed bool
+	// Limit is the bucket capacity.
+	Limit int
+	// Remaining is the number of whole tokens left.
+	Remaining int
+	// RetryAfter is how long until a token is available; zero when Allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// Limiter takes one token from the bucket identified by key.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// newResult builds a Result from the tokens left after taking (or failing to take) one.
+func newResult(limit Limit, allowed bool, tokens float64) Result {
+	rate := limit.rate()
+	capacity := limit.Capacity()
+
+	res := Result{
+		Allowed:   allowed,
+		Limit:     capacity,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     seconds((float64(capacity) - tokens) / rate),
+	}
+
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / rate)
+	}
+
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	if s <= 0 {
+		return 0
+	}
+
+	return time.Duration(s * float64(time.Second))
+}
+
+// Policy maps routes, written as "METHOD /pattern", to limits.
+type Policy struct {
+	Default Limit
+	routes  map[string]Limit
+}
+
+// NewPolicy returns a policy applying routes, falling back to def for other routes.
+func NewPolicy(def Limit, routes map[string]Limit) *Policy {
+	p := &Policy{Default: def, routes: make(map[string]Limit, len(routes))}
+	for route, limit := range routes {
+		p.routes[routeKey(route)] = limit
+	}
+
+	return p
+}
+
+// For returns the limit for a request method and chi route pattern.
+func (p *Policy) For(method, pattern string) Limit {
+	if limit, ok := p.routes[routeKey(method+" "+pattern)]; ok {
+		return limit
+	}
+
+	return p.Default
+}
+
+// routeKey normalises a route so config keys match regardless of case and spacing.
+func routeKey(route string) string {
+	method, pattern, _ := strings.Cut(strings.TrimSpace(route), " ")
+
+	return strings.ToUpper(method) + " " + strings.TrimSpace(pattern)
+}
@@ -0,0 +1,197 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMemoryLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 60, Period: time.Minute, Burst: 2}
+	ctx := t.Context()
+
+	for i, want := range []bool{true, true, false} {
+		res, err := limiter.Allow(ctx, "k", limit)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+
+		if res.Allowed != want {
+			t.Fatalf("request %d: Allowed = %v, want %v", i, res.Allowed, want)
+		}
+	}
+
+	res, _ := limiter.Allow(ctx, "k", limit)
+	if res.Remaining != 0 || res.RetryAfter != time.Second || res.Limit != 2 {
+		t.Errorf("exhausted result = %+v", res)
+	}
+
+	if res, _ := limiter.Allow(ctx, "other", limit); !res.Allowed {
+		t.Error("buckets are not independent per key")
+	}
+
+	now = now.Add(time.Second)
+
+	res, _ = limiter.Allow(ctx, "k", limit)
+	if !res.Allowed || res.Remaining != 0 || res.Reset != 2*time.Second {
+		t.Errorf("after refill result = %+v", res)
+	}
+}
+
+func TestMemoryLimiter_Sweep(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 1, Period: time.Second}
+
+	_, _ = limiter.Allow(t.Context(), "a", limit)
+
+	now = now.Add(sweepInterval)
+	_, _ = limiter.Allow(t.Context(), "b", limit)
+
+	if _, ok := limiter.buckets["a"]; ok {
+		t.Error("refilled bucket was not swept")
+	}
+}
+
+func TestRedisLimiter_Allow(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	limiter := NewRedisLimiter(client, "test:")
+	limit := Limit{Requests: 1, Period: time.Hour, Burst: 2}
+
+	for i, want := range []bool{true, true, false} {
+		res, err := limiter.Allow(t.Context(), "k", limit)
+		if err != nil {
+			t.Fatalf("Allow() error = %v", err)
+		}
+
+		if res.Allowed != want {
+			t.Fatalf("request %d: Allowed = %v, want %v", i, res.Allowed, want)
+		}
+
+		if res.Remaining != max(0, 1-i) {
+			t.Errorf("request %d: Remaining = %d", i, res.Remaining)
+		}
+	}
+
+	if srv.TTL("test:k") <= 0 {
+		t.Error("bucket has no expiry")
+	}
+}
+
+type failingLimiter struct{}
+
+func (failingLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	return Result{}, errors.New("redis down")
+}
+
+func TestFallbackLimiter(t *testing.T) {
+	t.Parallel()
+
+	limiter := NewFallbackLimiter(failingLimiter{}, NewMemoryLimiter(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	res, err := limiter.Allow(t.Context(), "k", Limit{Requests: 1, Period: time.Minute})
+	if err != nil || !res.Allowed {
+		t.Fatalf("Allow() = %+v, %v; want allowed by fallback", res, err)
+	}
+
+	if !limiter.degraded.Load() {
+		t.Error("limiter not marked degraded")
+	}
+}
+
+// flakyLimiter fails while down and counts calls.
+type flakyLimiter struct {
+	down  bool
+	calls int
+}
+
+func (f *flakyLimiter) Allow(context.Context, string, Limit) (Result, error) {
+	f.calls++
+
+	if f.down {
+		return Result{}, errors.New("redis down")
+	}
+
+	return Result{Allowed: true}, nil
+}
+
+func TestFallbackLimiter_OpensCircuit(t *testing.T) {
+	t.Parallel()
+
+	primary := &flakyLimiter{down: true}
+	now := time.Unix(1_700_000_000, 0)
+	limiter := NewFallbackLimiter(primary, NewMemoryLimiter(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	limiter.now = func() time.Time { return now }
+
+	limit := Limit{Requests: 100, Period: time.Minute}
+	allow := func() {
+		t.Helper()
+
+		if res, err := limiter.Allow(t.Context(), "k", limit); err != nil || !res.Allowed {
+			t.Fatalf("Allow() = %+v, %v; want allowed", res, err)
+		}
+	}
+
+	allow()
+	allow()
+	allow()
+
+	if primary.calls != 1 {
+		t.Fatalf("primary calls = %d, want 1 while the circuit is open", primary.calls)
+	}
+
+	// After the open period one request probes; a failed probe reopens the circuit.
+	now = now.Add(openPeriod)
+	allow()
+	allow()
+
+	if primary.calls != 2 {
+		t.Fatalf("primary calls = %d, want 2 after one failed probe", primary.calls)
+	}
+
+	now = now.Add(openPeriod)
+	primary.down = false
+	allow()
+	allow()
+
+	if primary.calls != 4 || limiter.degraded.Load() {
+		t.Errorf("primary calls = %d, degraded = %v; want the circuit closed after a good probe", primary.calls, limiter.degraded.Load())
+	}
+}
+
+func TestPolicy_For(t *testing.T) {
+	t.Parallel()
+
+	def := Limit{Requests: 100, Period: time.Minute}
+	dist := Limit{Requests: 5, Period: time.Minute}
+	policy := NewPolicy(def, map[string]Limit{"post  /v1/liquidity/distribution": dist})
+
+	if got := policy.For("POST", "/v1/liquidity/distribution"); got != dist {
+		t.Errorf("For(distribution) = %+v, want %+v", got, dist)
+	}
+
+	if got := policy.For("GET", "/v1/liquidity/distribution"); got != def {
+		t.Errorf("For(GET distribution) = %+v, want default", got)
+	}
+}
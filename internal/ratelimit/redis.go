@@ -0,0 +1,82 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills and takes from a bucket stored as a hash of tokens and ts (seconds).
+// It uses the Redis clock so all API instances agree on time, and expires idle buckets once
+// they would be full again.
+var tokenBucketScript = redis.NewScript(`local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+
+local t = redis.call('TIME')
+local now = tonumber(t[1]) + tonumber(t[2]) / 1000000
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+  tokens = capacity
+  ts = now
+end
+
+tokens = math.min(capacity, tokens + math.max(0, now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil((capacity - tokens) / rate * 1000) + 1000)
+
+return {allowed, tostring(tokens)}
+`) //nolint:gochecknoglobals // script hash is computed once
+
+// RedisLimiter keeps buckets in Redis so limits hold across API instances.
+type RedisLimiter struct {
+	client redis.Scripter
+	prefix string
+}
+
+var _ Limiter = (*RedisLimiter)(nil)
+
+// NewRedisLimiter returns a limiter storing buckets under prefix.
+func NewRedisLimiter(client redis.Scripter, prefix string) *RedisLimiter {
+	return &RedisLimiter{client: client, prefix: prefix}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	reply, err := tokenBucketScript.Run(ctx, l.client, []string{l.prefix + key},
+		strconv.FormatFloat(limit.rate(), 'f', -1, 64), limit.Capacity()).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("run token bucket script: %w", err)
+	}
+
+	if len(reply) != 2 { //nolint:mnd // {allowed, tokens}
+		return Result{}, fmt.Errorf("%w: %v", ErrUnexpectedReply, reply)
+	}
+
+	allowed, ok := reply[0].(int64)
+	if !ok {
+		return Result{}, fmt.Errorf("%w: allowed %v", ErrUnexpectedReply, reply[0])
+	}
+
+	tokensStr, ok := reply[1].(string)
+	if !ok {
+		return Result{}, fmt.Errorf("%w: tokens %v", ErrUnexpectedReply, reply[1])
+	}
+
+	tokens, err := strconv.ParseFloat(tokensStr, 64)
+	if err != nil {
+		return Result{}, fmt.Errorf("parse tokens: %w", err)
+	}
+
+	return newResult(limit, allowed == 1, tokens), nil
+}
@@ -0,0 +1,17 @@
+package rpc
+
+import "errors"
+
+var (
+	// ErrNoEndpoints is returned when no RPC endpoint is configured.
+	ErrNoEndpoints = errors.New("no rpc endpoints")
+
+	// ErrUnsupportedScheme is returned for an endpoint that is not HTTP(S).
+	ErrUnsupportedScheme = errors.New("unsupported rpc endpoint scheme")
+
+	// ErrEndpointStatus is returned when an endpoint answers with a non-200 status.
+	ErrEndpointStatus = errors.New("rpc endpoint status")
+
+	// ErrMissingState is logged when an endpoint lacks the block or state a read asks for.
+	ErrMissingState = errors.New("rpc endpoint missing block or state")
+)
@@ -0,0 +1,67 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// metrics records per-endpoint latency, errors and head lag on the global meter provider.
+type metrics struct {
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	lag      metric.Int64Gauge
+}
+
+func newMetrics() *metrics {
+	meter := otel.Meter("remora/rpc")
+
+	duration, err := meter.Float64Histogram(
+		"remora.rpc.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of JSON-RPC requests per endpoint"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	errs, err := meter.Int64Counter(
+		"remora.rpc.request.errors",
+		metric.WithDescription("Failed JSON-RPC requests per endpoint (transport errors, 429, 5xx and JSON-RPC errors)"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	lag, err := meter.Int64Gauge(
+		"remora.rpc.endpoint.block_lag",
+		metric.WithUnit("{block}"),
+		metric.WithDescription("Blocks each endpoint trails the highest known head"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	return &metrics{duration: duration, errors: errs, lag: lag}
+}
+
+func (m *metrics) record(ctx context.Context, endpoint, method string, d time.Duration, failed bool) {
+	attrs := metric.WithAttributes(attribute.String("endpoint", endpoint), attribute.String("method", method))
+
+	if m.duration != nil {
+		m.duration.Record(ctx, d.Seconds(), attrs)
+	}
+
+	if failed && m.errors != nil {
+		m.errors.Add(ctx, 1, attrs)
+	}
+}
+
+func (m *metrics) recordLag(ctx context.Context, endpoint string, lag uint64) {
+	if m.lag != nil {
+		m.lag.Record(ctx, int64(lag), metric.WithAttributes(attribute.String("endpoint", endpoint))) //nolint:gosec // lag is small
+	}
+}
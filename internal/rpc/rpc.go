@@ -0,0 +1,52 @@
+// Package rpc provides an Ethereum client backed by several JSON-RPC endpoints.
+//
+// The client is a regular *ethclient.Client whose HTTP transport picks the endpoint for each
+// request: reads are spread round-robin over healthy endpoints and retried on another one
+// with backoff, while transactions go to a single write endpoint and are never retried. An
+// endpoint is unhealthy for a cooldown after a failure, or while its head trails the
+// highest known head by more than MaxBlockLag blocks.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	defaultMaxRetries     = 2
+	defaultRetryBackoff   = 200 * time.Millisecond
+	defaultMaxBlockLag    = 5
+	defaultHealthInterval = 15 * time.Second
+)
+
+// Config configures the endpoints of a client.
+type Config struct {
+	URLs           []string      // Read endpoints; the first also takes writes unless WriteURL is set
+	WriteURL       string        // Endpoint for transactions and pending nonces
+	MaxRetries     int           // Extra attempts for a failed read (default 2)
+	RetryBackoff   time.Duration // Delay before the first retry, doubled per retry (default 200ms)
+	MaxBlockLag    uint64        // Blocks an endpoint may trail the highest head (default 5)
+	HealthInterval time.Duration // How often endpoint heads are refreshed (default 15s)
+}
+
+// Dial returns an Ethereum client over the endpoints of cfg. Close the client to release it.
+func Dial(ctx context.Context, cfg Config, logger *slog.Logger) (*ethclient.Client, error) {
+	t, err := NewTransport(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// The URL only selects the HTTP transport; the actual endpoint is chosen per request.
+	c, err := gethrpc.DialOptions(ctx, t.write.url.String(), gethrpc.WithHTTPClient(&http.Client{Transport: t}))
+	if err != nil {
+		return nil, fmt.Errorf("dial rpc: %w", err)
+	}
+
+	return ethclient.NewClient(c), nil
+}
@@ -0,0 +1,294 @@
+package rpc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// node is a minimal JSON-RPC server that reports a fixed head and counts calls per method.
+// Balance reads pinned above its head fail like a lagging geth node.
+type node struct {
+	head   atomic.Uint64
+	status atomic.Int64 // Non-zero fails every request with this HTTP status
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newNode(t *testing.T, head uint64) (*node, string) {
+	t.Helper()
+
+	n := &node{calls: make(map[string]int)}
+	n.head.Store(head)
+
+	srv := httptest.NewServer(n)
+	t.Cleanup(srv.Close)
+
+	return n, srv.URL
+}
+
+func (n *node) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+
+	_ = json.NewDecoder(r.Body).Decode(&req)
+
+	n.mu.Lock()
+	n.calls[req.Method]++
+	n.mu.Unlock()
+
+	if status := n.status.Load(); status != 0 {
+		w.WriteHeader(int(status))
+
+		return
+	}
+
+	var result string
+
+	switch req.Method {
+	case "eth_blockNumber":
+		result = fmt.Sprintf("%q", fmt.Sprintf("0x%x", n.head.Load()))
+	case "eth_chainId":
+		result = `"0x1"`
+	case "eth_sendRawTransaction":
+		result = fmt.Sprintf("%q", common.Hash{1}.Hex())
+	case "eth_getBalance":
+		var block hexutil.Uint64
+		if len(req.Params) == 2 && json.Unmarshal(req.Params[1], &block) == nil && uint64(block) > n.head.Load() {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":-32000,"message":"header not found"}}`, req.ID)
+
+			return
+		}
+
+		result = `"0x1"`
+	default:
+		result = "null"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":%s}`, req.ID, result)
+}
+
+func (n *node) count(method string) int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.calls[method]
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func dial(t *testing.T, cfg Config) *ethclient.Client {
+	t.Helper()
+
+	if cfg.RetryBackoff == 0 {
+		cfg.RetryBackoff = time.Millisecond
+	}
+
+	if cfg.HealthInterval == 0 {
+		cfg.HealthInterval = time.Hour
+	}
+
+	client, err := Dial(t.Context(), cfg, testLogger())
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(client.Close)
+
+	return client
+}
+
+func TestClient_FailsOverReads(t *testing.T) {
+	down, downURL := newNode(t, 100)
+	up, upURL := newNode(t, 100)
+
+	down.status.Store(http.StatusBadGateway)
+
+	client := dial(t, Config{URLs: []string{downURL, upURL}})
+
+	for range 4 {
+		id, err := client.ChainID(t.Context())
+		if err != nil || id.Int64() != 1 {
+			t.Fatalf("ChainID() = %v, %v; want 1", id, err)
+		}
+	}
+
+	// After its first failure the broken endpoint sits out its cooldown.
+	if got := down.count("eth_chainId"); got > 1 {
+		t.Errorf("down endpoint calls = %d, want at most 1", got)
+	}
+
+	if got := up.count("eth_chainId"); got != 4 {
+		t.Errorf("up endpoint calls = %d, want 4", got)
+	}
+}
+
+func TestClient_BalancesReads(t *testing.T) {
+	a, aURL := newNode(t, 100)
+	b, bURL := newNode(t, 100)
+
+	client := dial(t, Config{URLs: []string{aURL, bURL}})
+
+	for range 4 {
+		if _, err := client.ChainID(t.Context()); err != nil {
+			t.Fatalf("ChainID() error = %v", err)
+		}
+	}
+
+	if a.count("eth_chainId") != 2 || b.count("eth_chainId") != 2 {
+		t.Errorf("calls = %d and %d, want 2 each", a.count("eth_chainId"), b.count("eth_chainId"))
+	}
+}
+
+func TestClient_RetriesExhausted(t *testing.T) {
+	n, url := newNode(t, 100)
+	n.status.Store(http.StatusServiceUnavailable)
+
+	client := dial(t, Config{URLs: []string{url}, MaxRetries: 2})
+
+	if _, err := client.ChainID(t.Context()); err == nil {
+		t.Fatal("ChainID() succeeded against a failing endpoint")
+	}
+
+	if got := n.count("eth_chainId"); got != 3 {
+		t.Errorf("attempts = %d, want 3", got)
+	}
+}
+
+func TestClient_WritesGoToWriteEndpoint(t *testing.T) {
+	read, readURL := newNode(t, 100)
+	write, writeURL := newNode(t, 100)
+
+	client := dial(t, Config{URLs: []string{readURL}, WriteURL: writeURL})
+
+	key, _ := crypto.GenerateKey()
+	to := common.Address{2}
+	chainID := big.NewInt(1)
+
+	tx, err := types.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: chainID, To: &to, Gas: 21000}), types.LatestSignerForChainID(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.SendTransaction(t.Context(), tx); err != nil {
+		t.Fatalf("SendTransaction() error = %v", err)
+	}
+
+	// The null result fails to decode; only the routing matters here.
+	_, _ = client.PendingNonceAt(t.Context(), to)
+
+	if write.count("eth_sendRawTransaction") != 1 || write.count("eth_getTransactionCount") != 1 {
+		t.Errorf("write endpoint calls = %v", write.calls)
+	}
+
+	if read.count("eth_sendRawTransaction") != 0 || read.count("eth_getTransactionCount") != 0 {
+		t.Errorf("read endpoint got writes: %v", read.calls)
+	}
+
+	// Write failures are not retried elsewhere.
+	write.status.Store(http.StatusBadGateway)
+
+	if err := client.SendTransaction(t.Context(), tx); err == nil {
+		t.Error("SendTransaction() succeeded against a failing write endpoint")
+	}
+
+	if read.count("eth_sendRawTransaction") != 0 {
+		t.Error("failed write was retried on a read endpoint")
+	}
+}
+
+func TestTransport_SkipsLaggingEndpoints(t *testing.T) {
+	behind, behindURL := newNode(t, 90)
+	_, headURL := newNode(t, 100)
+
+	tr, err := NewTransport(Config{URLs: []string{behindURL, headURL}, MaxBlockLag: 5}, testLogger())
+	if err != nil {
+		t.Fatalf("NewTransport() error = %v", err)
+	}
+
+	tr.checkHeads(t.Context())
+
+	for range 3 {
+		if order := tr.readOrder(); order[0].head.Load() != 100 || order[1].head.Load() != 90 {
+			t.Fatalf("read order heads = %d, %d; want the lagging endpoint last", order[0].head.Load(), order[1].head.Load())
+		}
+	}
+
+	// Within the allowed lag both endpoints share reads again.
+	behind.head.Store(97)
+	tr.checkHeads(t.Context())
+
+	first := map[uint64]bool{}
+	for range 4 {
+		first[tr.readOrder()[0].head.Load()] = true
+	}
+
+	if len(first) != 2 {
+		t.Errorf("first endpoints = %v, want both", first)
+	}
+}
+
+func TestClient_FailsOverMissingBlock(t *testing.T) {
+	behind, behindURL := newNode(t, 97)
+	head, headURL := newNode(t, 100)
+
+	// Within MaxBlockLag, so the lagging endpoint stays in rotation.
+	client := dial(t, Config{URLs: []string{behindURL, headURL}, MaxBlockLag: 5})
+
+	for range 4 {
+		balance, err := client.BalanceAt(t.Context(), common.Address{1}, big.NewInt(100))
+		if err != nil || balance.Int64() != 1 {
+			t.Fatalf("BalanceAt() = %v, %v; want 1", balance, err)
+		}
+	}
+
+	if behind.count("eth_getBalance") == 0 {
+		t.Error("lagging endpoint was never tried")
+	}
+
+	if got := head.count("eth_getBalance"); got != 4 {
+		t.Errorf("head endpoint calls = %d, want 4", got)
+	}
+
+	// Older blocks are still served by the lagging endpoint.
+	for range 2 {
+		if _, err := client.BalanceAt(t.Context(), common.Address{1}, big.NewInt(90)); err != nil {
+			t.Fatalf("BalanceAt() error = %v", err)
+		}
+	}
+
+	if got := head.count("eth_getBalance"); got != 5 {
+		t.Errorf("head endpoint calls = %d, want 5", got)
+	}
+}
+
+func TestNewTransport_Validates(t *testing.T) {
+	if _, err := NewTransport(Config{}, testLogger()); !errors.Is(err, ErrNoEndpoints) {
+		t.Errorf("no urls error = %v, want %v", err, ErrNoEndpoints)
+	}
+
+	if _, err := NewTransport(Config{URLs: []string{"wss://node.example"}}, testLogger()); !errors.Is(err, ErrUnsupportedScheme) {
+		t.Errorf("ws url error = %v, want %v", err, ErrUnsupportedScheme)
+	}
+}
@@ -0,0 +1,488 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const healthCheckTimeout = 5 * time.Second
+
+var blockNumberRequest = []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`)
+
+// missingStateErrors are JSON-RPC error messages from a node that has not reached, or no
+// longer keeps, the block a read is pinned to. Another endpoint may still serve the read.
+var missingStateErrors = []string{
+	"header not found",
+	"block not found",
+	"unknown block",
+	"missing trie node",
+	"historical state",
+}
+
+// writeMethods go to the write endpoint. Pending nonces are read there too, since another
+// node's pool may not have seen our latest transactions yet.
+var writeMethods = map[string]bool{
+	"eth_sendRawTransaction":  true,
+	"eth_sendTransaction":     true,
+	"eth_getTransactionCount": true,
+}
+
+// Transport is an http.RoundTripper that spreads JSON-RPC requests over endpoints.
+type Transport struct {
+	reads   []*endpoint
+	write   *endpoint
+	cfg     Config
+	base    http.RoundTripper
+	metrics *metrics
+	logger  *slog.Logger
+
+	next        atomic.Uint64
+	checking    atomic.Bool
+	lastChecked atomic.Int64 // Unix nanoseconds of the last head refresh
+}
+
+type endpoint struct {
+	url  *url.URL
+	name string // Host only, so API keys in paths stay out of logs and metrics
+
+	head      atomic.Uint64
+	downUntil atomic.Int64 // Unix nanoseconds
+}
+
+var _ http.RoundTripper = (*Transport)(nil)
+
+func NewTransport(cfg Config, logger *slog.Logger) (*Transport, error) {
+	if len(cfg.URLs) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	}
+
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+
+	if cfg.MaxBlockLag == 0 {
+		cfg.MaxBlockLag = defaultMaxBlockLag
+	}
+
+	if cfg.HealthInterval <= 0 {
+		cfg.HealthInterval = defaultHealthInterval
+	}
+
+	t := &Transport{cfg: cfg, base: http.DefaultTransport, metrics: newMetrics(), logger: logger}
+
+	byURL := make(map[string]*endpoint, len(cfg.URLs))
+
+	for _, raw := range cfg.URLs {
+		ep, err := newEndpoint(raw)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := byURL[raw]; ok {
+			continue
+		}
+
+		byURL[raw] = ep
+		t.reads = append(t.reads, ep)
+	}
+
+	t.write = t.reads[0]
+
+	if cfg.WriteURL != "" {
+		if ep, ok := byURL[cfg.WriteURL]; ok {
+			t.write = ep
+		} else {
+			ep, err := newEndpoint(cfg.WriteURL)
+			if err != nil {
+				return nil, err
+			}
+
+			t.write = ep
+		}
+	}
+
+	return t, nil
+}
+
+func newEndpoint(raw string) (*endpoint, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse rpc url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedScheme, u.Scheme)
+	}
+
+	return &endpoint{url: u, name: u.Host}, nil
+}
+
+// RoundTrip sends a JSON-RPC request to a write endpoint or to healthy read endpoints,
+// retrying reads on transport errors, 429 or 5xx responses and missing block or state errors.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	method, write := classify(body)
+
+	if write {
+		resp, _, err := t.send(req, body, method, t.write)
+
+		return resp, err
+	}
+
+	t.maybeCheckHeads()
+
+	order := t.readOrder()
+
+	var (
+		resp    *http.Response
+		sendErr error
+	)
+
+	for attempt := 0; attempt <= t.cfg.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(req.Context(), t.cfg.RetryBackoff<<(attempt-1)); err != nil {
+				return nil, err
+			}
+		}
+
+		ep := order[attempt%len(order)]
+
+		var stale bool
+
+		resp, stale, sendErr = t.send(req, body, method, ep)
+		if sendErr == nil && !stale && !retryable(resp.StatusCode) {
+			return resp, nil
+		}
+
+		// A node behind the pinned block is otherwise fine, so it stays in rotation.
+		if !stale {
+			ep.markDown(t.cfg.HealthInterval)
+		}
+
+		t.logger.WarnContext(req.Context(), "rpc request failed",
+			slog.String("endpoint", ep.name),
+			slog.String("method", method),
+			slog.Int("attempt", attempt+1),
+			slog.Any("error", statusError(resp, stale, sendErr)))
+
+		if attempt < t.cfg.MaxRetries && resp != nil {
+			_ = resp.Body.Close()
+		}
+	}
+
+	return resp, sendErr
+}
+
+// send forwards req with body to ep and records its latency and outcome. It reports whether
+// the response carries a missing block or state error.
+func (t *Transport) send(req *http.Request, body []byte, method string, ep *endpoint) (*http.Response, bool, error) {
+	out := req.Clone(req.Context())
+	out.URL = ep.url
+	out.Host = ""
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.ContentLength = int64(len(body))
+	out.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+
+	if ep.url.User != nil {
+		password, _ := ep.url.User.Password()
+		out.SetBasicAuth(ep.url.User.Username(), password)
+	}
+
+	start := time.Now()
+
+	resp, err := t.base.RoundTrip(out)
+	if err != nil {
+		t.metrics.record(req.Context(), ep.name, method, time.Since(start), true)
+
+		return nil, false, err //nolint:wrapcheck // RoundTripper errors are passed through as-is
+	}
+
+	var rpcErr, stale bool
+
+	if resp.StatusCode == http.StatusOK {
+		if rpcErr, stale, err = inspect(resp); err != nil {
+			t.metrics.record(req.Context(), ep.name, method, time.Since(start), true)
+
+			return nil, false, err
+		}
+	}
+
+	t.metrics.record(req.Context(), ep.name, method, time.Since(start), rpcErr || retryable(resp.StatusCode))
+
+	return resp, stale, nil
+}
+
+// inspect buffers the body of resp and reports whether it holds a JSON-RPC error, and
+// whether any error is a missing block or state error.
+func inspect(resp *http.Response) (bool, bool, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+
+	if err != nil {
+		return false, false, fmt.Errorf("read response body: %w", err)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	type reply struct {
+		Error *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+
+	var replies []reply
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		_ = json.Unmarshal(trimmed, &replies)
+	} else {
+		var r reply
+		if json.Unmarshal(trimmed, &r) == nil {
+			replies = append(replies, r)
+		}
+	}
+
+	var failed, stale bool
+
+	for _, r := range replies {
+		if r.Error == nil {
+			continue
+		}
+
+		failed = true
+		stale = stale || missingState(r.Error.Message)
+	}
+
+	return failed, stale, nil
+}
+
+func missingState(message string) bool {
+	message = strings.ToLower(message)
+
+	for _, s := range missingStateErrors {
+		if strings.Contains(message, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// readOrder returns the read endpoints to try, healthy ones first in round-robin order.
+// Unhealthy endpoints stay at the end as a last resort.
+func (t *Transport) readOrder() []*endpoint {
+	start := int(t.next.Add(1) % uint64(len(t.reads))) //nolint:gosec // index below len
+
+	var highest uint64
+	for _, ep := range t.reads {
+		highest = max(highest, ep.head.Load())
+	}
+
+	now := time.Now().UnixNano()
+	healthy := make([]*endpoint, 0, len(t.reads))
+
+	var unhealthy []*endpoint
+
+	for i := range t.reads {
+		ep := t.reads[(start+i)%len(t.reads)]
+		if ep.healthy(now, highest, t.cfg.MaxBlockLag) {
+			healthy = append(healthy, ep)
+		} else {
+			unhealthy = append(unhealthy, ep)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+func (e *endpoint) healthy(now int64, highest, maxLag uint64) bool {
+	if now < e.downUntil.Load() {
+		return false
+	}
+
+	head := e.head.Load()
+
+	return head == 0 || highest-head <= maxLag
+}
+
+func (e *endpoint) markDown(cooldown time.Duration) {
+	e.downUntil.Store(time.Now().Add(cooldown).UnixNano())
+}
+
+// maybeCheckHeads refreshes endpoint heads in the background once per HealthInterval.
+func (t *Transport) maybeCheckHeads() {
+	if len(t.reads) < 2 || time.Since(time.Unix(0, t.lastChecked.Load())) < t.cfg.HealthInterval {
+		return
+	}
+
+	if !t.checking.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer t.checking.Store(false)
+
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+		defer cancel()
+
+		t.checkHeads(ctx)
+	}()
+}
+
+// checkHeads reads the head of every read endpoint, marking unreachable ones down.
+func (t *Transport) checkHeads(ctx context.Context) {
+	var wg sync.WaitGroup
+
+	for _, ep := range t.reads {
+		wg.Go(func() {
+			head, err := t.blockNumber(ctx, ep)
+			if err != nil {
+				ep.markDown(t.cfg.HealthInterval)
+				t.logger.WarnContext(ctx, "rpc health check failed", slog.String("endpoint", ep.name), slog.Any("error", err))
+
+				return
+			}
+
+			ep.head.Store(head)
+		})
+	}
+
+	wg.Wait()
+
+	var highest uint64
+	for _, ep := range t.reads {
+		highest = max(highest, ep.head.Load())
+	}
+
+	for _, ep := range t.reads {
+		if head := ep.head.Load(); head > 0 {
+			t.metrics.recordLag(ctx, ep.name, highest-head)
+		}
+	}
+
+	t.lastChecked.Store(time.Now().UnixNano())
+}
+
+func (t *Transport) blockNumber(ctx context.Context, ep *endpoint) (uint64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.url.String(), nil)
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, _, err := t.send(req, blockNumberRequest, "eth_blockNumber", ep)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("%w: %d", ErrEndpointStatus, resp.StatusCode)
+	}
+
+	var out struct {
+		Result hexutil.Uint64 `json:"result"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return 0, fmt.Errorf("decode block number: %w", err)
+	}
+
+	return uint64(out.Result), nil
+}
+
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer req.Body.Close()
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("read request body: %w", err)
+	}
+
+	return body, nil
+}
+
+// classify returns the JSON-RPC method for metrics ("batch" for batches) and whether the
+// request must go to the write endpoint.
+func classify(body []byte) (string, bool) {
+	type call struct {
+		Method string `json:"method"`
+	}
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var calls []call
+		if err := json.Unmarshal(trimmed, &calls); err != nil {
+			return "batch", false
+		}
+
+		for _, c := range calls {
+			if writeMethods[c.Method] {
+				return "batch", true
+			}
+		}
+
+		return "batch", false
+	}
+
+	var c call
+	if err := json.Unmarshal(trimmed, &c); err != nil {
+		return "unknown", false
+	}
+
+	return c.Method, writeMethods[c.Method]
+}
+
+func retryable(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+func statusError(resp *http.Response, stale bool, err error) error {
+	if err != nil {
+		return err
+	}
+
+	if stale {
+		return ErrMissingState
+	}
+
+	return fmt.Errorf("%w: %d", ErrEndpointStatus, resp.StatusCode)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("wait for retry: %w", ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
@@ -7,4 +7,7 @@ var (
 	ErrAgentPrivateKeyNotSet = errors.New("AGENT_PRIVATE_KEY not set")
 	ErrChainIDNotSet         = errors.New("CHAIN_ID not set")
 	ErrInvalidChainID        = errors.New("invalid CHAIN_ID")
+	ErrInvalidSignerConfig   = errors.New("invalid signer config")
+	ErrSignerMismatch        = errors.New("remote signer mismatch")
+	ErrSignerNotFound        = errors.New("no signer for address")
 )
@@ -0,0 +1,103 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// KeystoreSigner signs with an encrypted go-ethereum keystore file. The key is decrypted for
+// each signature and discarded afterwards; only the passphrase stays in memory.
+type KeystoreSigner struct {
+	ks         *keystore.KeyStore
+	account    accounts.Account
+	passphrase string
+	chainID    *big.Int
+}
+
+var _ Signer = (*KeystoreSigner)(nil)
+
+// NewKeystore opens the keystore file at path and checks the passphrase read from
+// passphraseFile (trailing newlines are ignored).
+func NewKeystore(path, passphraseFile string, chainID *big.Int) (*KeystoreSigner, error) {
+	if path == "" || passphraseFile == "" {
+		return nil, fmt.Errorf("%w: keystore path and passphrase file are required", ErrInvalidSignerConfig)
+	}
+
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("resolve keystore path: %w", err)
+	}
+
+	keyJSON, err := os.ReadFile(path) //nolint:gosec // path comes from operator config
+	if err != nil {
+		return nil, fmt.Errorf("read keystore: %w", err)
+	}
+
+	var header struct {
+		Address string `json:"address"`
+	}
+
+	if err := json.Unmarshal(keyJSON, &header); err != nil {
+		return nil, fmt.Errorf("parse keystore: %w", err)
+	}
+
+	if !common.IsHexAddress(header.Address) {
+		return nil, fmt.Errorf("%w: keystore address %q", ErrInvalidSignerConfig, header.Address)
+	}
+
+	passphrase, err := os.ReadFile(passphraseFile) //nolint:gosec // path comes from operator config
+	if err != nil {
+		return nil, fmt.Errorf("read passphrase: %w", err)
+	}
+
+	ks := keystore.NewKeyStore(filepath.Dir(path), keystore.StandardScryptN, keystore.StandardScryptP)
+
+	account, err := ks.Find(accounts.Account{
+		Address: common.HexToAddress(header.Address),
+		URL:     accounts.URL{Scheme: keystore.KeyStoreScheme, Path: path},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("find keystore account: %w", err)
+	}
+
+	s := &KeystoreSigner{
+		ks:         ks,
+		account:    account,
+		passphrase: strings.TrimRight(string(passphrase), "\r\n"),
+		chainID:    chainID,
+	}
+
+	// Fail at startup rather than on the first rebalance if the passphrase is wrong.
+	if _, err := ks.SignHashWithPassphrase(account, s.passphrase, make([]byte, common.HashLength)); err != nil {
+		return nil, fmt.Errorf("unlock keystore: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *KeystoreSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *KeystoreSigner) ChainID() *big.Int {
+	return s.chainID
+}
+
+func (s *KeystoreSigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	signed, err := s.ks.SignTxWithPassphrase(s.account, s.passphrase, tx, s.chainID)
+	if err != nil {
+		return nil, fmt.Errorf("sign tx with keystore: %w", err)
+	}
+
+	return signed, nil
+}
@@ -0,0 +1,111 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NonceSource returns the next nonce for an address from the chain.
+type NonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+}
+
+// NonceManager hands out nonces per address, tracking them locally so back-to-back
+// transactions do not depend on the node's pending pool having caught up. Each address is
+// tracked and locked independently, so one key's failures do not affect the others.
+type NonceManager struct {
+	source NonceSource
+
+	mu   sync.Mutex
+	keys map[common.Address]*nonceState
+}
+
+type nonceState struct {
+	mu    sync.Mutex
+	next  uint64
+	known bool
+}
+
+func NewNonceManager(source NonceSource) *NonceManager {
+	return &NonceManager{source: source, keys: make(map[common.Address]*nonceState)}
+}
+
+// Next reserves and returns the next nonce for addr. The first call, and the first after a
+// Reset, reads the pending nonce from the chain.
+func (m *NonceManager) Next(ctx context.Context, addr common.Address) (uint64, error) {
+	st := m.state(addr)
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if !st.known {
+		nonce, err := m.source.PendingNonceAt(ctx, addr)
+		if err != nil {
+			return 0, fmt.Errorf("get pending nonce: %w", err)
+		}
+
+		st.next = nonce
+		st.known = true
+	}
+
+	nonce := st.next
+	st.next++
+
+	return nonce, nil
+}
+
+// Reset forgets the tracked nonce for addr so the next call resyncs with the chain. Call it
+// when a reserved nonce was not used, e.g. after a failed send.
+func (m *NonceManager) Reset(addr common.Address) {
+	st := m.state(addr)
+
+	st.mu.Lock()
+	st.known = false
+	st.mu.Unlock()
+}
+
+func (m *NonceManager) state(addr common.Address) *nonceState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	st, ok := m.keys[addr]
+	if !ok {
+		st = &nonceState{}
+		m.keys[addr] = st
+	}
+
+	return st
+}
+
+// Backend wraps backend so contract bindings take nonces from m, resetting an address's
+// nonce when sending its transaction fails.
+func (m *NonceManager) Backend(backend bind.ContractBackend) bind.ContractBackend { //nolint:ireturn // decorates the interface
+	return &nonceBackend{ContractBackend: backend, nonces: m}
+}
+
+type nonceBackend struct {
+	bind.ContractBackend
+
+	nonces *NonceManager
+}
+
+func (b *nonceBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return b.nonces.Next(ctx, account)
+}
+
+func (b *nonceBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	if err := b.ContractBackend.SendTransaction(ctx, tx); err != nil {
+		if from, senderErr := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx); senderErr == nil {
+			b.nonces.Reset(from)
+		}
+
+		return fmt.Errorf("send transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Registry holds the signers of every agent identity the process may act as.
+type Registry struct {
+	signers map[common.Address]Signer
+}
+
+// NewRegistry returns a registry of signers, which must have distinct addresses.
+func NewRegistry(signers ...Signer) (*Registry, error) {
+	r := &Registry{signers: make(map[common.Address]Signer, len(signers))}
+
+	for _, s := range signers {
+		if _, ok := r.signers[s.Address()]; ok {
+			return nil, fmt.Errorf("%w: duplicate signer %s", ErrInvalidSignerConfig, s.Address().Hex())
+		}
+
+		r.signers[s.Address()] = s
+	}
+
+	return r, nil
+}
+
+// Get returns the signer for addr, e.g. a vault's Agent.
+func (r *Registry) Get(addr common.Address) (Signer, error) { //nolint:ireturn // registry of implementations
+	s, ok := r.signers[addr]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrSignerNotFound, addr.Hex())
+	}
+
+	return s, nil
+}
+
+// Addresses returns the registered addresses in ascending order.
+func (r *Registry) Addresses() []common.Address {
+	addrs := make([]common.Address, 0, len(r.signers))
+	for addr := range r.signers {
+		addrs = append(addrs, addr)
+	}
+
+	slices.SortFunc(addrs, func(a, b common.Address) int { return a.Cmp(b) })
+
+	return addrs
+}
+
+// NewRegistryFromEnv creates the signers selected by SIGNER_TYPE (default "env"). Each type
+// takes a comma-separated list of keys, one signer per entry:
+//   - env: AGENT_PRIVATE_KEY (development only; keys are held in memory)
+//   - keystore: SIGNER_KEYSTORE_PATH, with SIGNER_KEYSTORE_PASSWORD_FILE holding either one
+//     passphrase file for all keystores or one per keystore
+//   - web3signer: SIGNER_ADDRESS, all served by SIGNER_URL
+//
+// CHAIN_ID is required for all types.
+func NewRegistryFromEnv(ctx context.Context) (*Registry, error) {
+	chainIDStr := os.Getenv("CHAIN_ID")
+	if chainIDStr == "" {
+		return nil, ErrChainIDNotSet
+	}
+
+	chainID, ok := new(big.Int).SetString(chainIDStr, decimalBase)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidChainID, chainIDStr)
+	}
+
+	var signers []Signer
+
+	switch signerType := os.Getenv("SIGNER_TYPE"); signerType {
+	case "", TypeEnv:
+		keys := splitList(os.Getenv("AGENT_PRIVATE_KEY"))
+		if len(keys) == 0 {
+			return nil, ErrAgentPrivateKeyNotSet
+		}
+
+		for _, key := range keys {
+			s, err := New(strings.TrimPrefix(key, "0x"), chainID)
+			if err != nil {
+				return nil, err
+			}
+
+			signers = append(signers, s)
+		}
+	case TypeKeystore:
+		paths := splitList(os.Getenv("SIGNER_KEYSTORE_PATH"))
+		passFiles := splitList(os.Getenv("SIGNER_KEYSTORE_PASSWORD_FILE"))
+
+		if len(paths) == 0 || (len(passFiles) != 1 && len(passFiles) != len(paths)) {
+			return nil, fmt.Errorf("%w: need one passphrase file or one per keystore", ErrInvalidSignerConfig)
+		}
+
+		for i, path := range paths {
+			s, err := NewKeystore(path, passFiles[min(i, len(passFiles)-1)], chainID)
+			if err != nil {
+				return nil, err
+			}
+
+			signers = append(signers, s)
+		}
+	case TypeWeb3Signer:
+		addrs := splitList(os.Getenv("SIGNER_ADDRESS"))
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("%w: SIGNER_ADDRESS not set", ErrInvalidSignerConfig)
+		}
+
+		for _, addr := range addrs {
+			if !common.IsHexAddress(addr) {
+				return nil, fmt.Errorf("%w: SIGNER_ADDRESS %q", ErrInvalidSignerConfig, addr)
+			}
+
+			s, err := NewRemote(ctx, os.Getenv("SIGNER_URL"), common.HexToAddress(addr), chainID)
+			if err != nil {
+				return nil, err
+			}
+
+			signers = append(signers, s)
+		}
+	default:
+		return nil, fmt.Errorf("%w: unknown SIGNER_TYPE %q", ErrInvalidSignerConfig, signerType)
+	}
+
+	return NewRegistry(signers...)
+}
+
+func splitList(s string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
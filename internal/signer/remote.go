@@ -0,0 +1,116 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// RemoteSigner asks a signing service speaking the Web3Signer eth1 JSON-RPC API
+// (eth_signTransaction) to sign; the key never leaves the service.
+type RemoteSigner struct {
+	client  *rpc.Client
+	address common.Address
+	chainID *big.Int
+}
+
+var _ Signer = (*RemoteSigner)(nil)
+
+// NewRemote connects to the signing service at url, which must hold the key for address.
+func NewRemote(ctx context.Context, url string, address common.Address, chainID *big.Int) (*RemoteSigner, error) {
+	if url == "" {
+		return nil, fmt.Errorf("%w: signer url is required", ErrInvalidSignerConfig)
+	}
+
+	client, err := rpc.DialContext(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("dial signer: %w", err)
+	}
+
+	return &RemoteSigner{client: client, address: address, chainID: chainID}, nil
+}
+
+func (s *RemoteSigner) Address() common.Address {
+	return s.address
+}
+
+func (s *RemoteSigner) ChainID() *big.Int {
+	return s.chainID
+}
+
+// Close closes the connection to the signing service.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// signTxArgs are the eth_signTransaction parameters.
+type signTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+}
+
+// SignTx sends tx to the signing service and checks that the returned transaction is tx
+// signed by Address, so a misbehaving service cannot substitute another transaction.
+func (s *RemoteSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	args := signTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(s.chainID),
+	}
+
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	var raw hexutil.Bytes
+	if err := s.client.CallContext(ctx, &raw, "eth_signTransaction", args); err != nil {
+		return nil, fmt.Errorf("eth_signTransaction: %w", err)
+	}
+
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("decode signed tx: %w", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(s.chainID), signed)
+	if err != nil {
+		return nil, fmt.Errorf("recover signed tx sender: %w", err)
+	}
+
+	if sender != s.address || !sameTx(tx, signed) {
+		return nil, fmt.Errorf("%w: signed tx does not match request", ErrSignerMismatch)
+	}
+
+	return signed, nil
+}
+
+// sameTx compares the signed fields of two transactions.
+func sameTx(a, b *types.Transaction) bool {
+	return a.Nonce() == b.Nonce() &&
+		a.Gas() == b.Gas() &&
+		a.GasFeeCap().Cmp(b.GasFeeCap()) == 0 &&
+		a.GasTipCap().Cmp(b.GasTipCap()) == 0 &&
+		a.Value().Cmp(b.Value()) == 0 &&
+		((a.To() == nil && b.To() == nil) || (a.To() != nil && b.To() != nil && *a.To() == *b.To())) &&
+		string(a.Data()) == string(b.Data())
+}
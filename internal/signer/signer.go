@@ -1,52 +1,68 @@
 package signer
 
 import (
+	"context"
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
-	"os"
-	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const decimalBase = 10
 
-// Signer holds the private key and provides transaction signing capabilities.
-type Signer struct {
-	privateKey *ecdsa.PrivateKey
-	address    common.Address
-	chainID    *big.Int
-}
+// Signer types selectable with SIGNER_TYPE.
+const (
+	TypeEnv        = "env"
+	TypeKeystore   = "keystore"
+	TypeWeb3Signer = "web3signer"
+)
 
-// NewFromEnv creates a Signer from environment variables.
-// Reads AGENT_PRIVATE_KEY and CHAIN_ID from environment.
-func NewFromEnv() (*Signer, error) {
-	privateKey := os.Getenv("AGENT_PRIVATE_KEY")
-	if privateKey == "" {
-		return nil, ErrAgentPrivateKeyNotSet
-	}
+// Signer signs transactions for a single address. Implementations may keep the key out of
+// process (Web3Signer) or only decrypt it while signing (keystore).
+type Signer interface {
+	// Address returns the address transactions are signed for.
+	Address() common.Address
 
-	privateKey = strings.TrimPrefix(privateKey, "0x")
+	// ChainID returns the chain ID used for replay protection.
+	ChainID() *big.Int
 
-	chainIDStr := os.Getenv("CHAIN_ID")
-	if chainIDStr == "" {
-		return nil, ErrChainIDNotSet
-	}
+	// SignTx returns tx signed by Address.
+	SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error)
+}
 
-	chainID, ok := new(big.Int).SetString(chainIDStr, decimalBase)
-	if !ok {
-		return nil, fmt.Errorf("%w: %s", ErrInvalidChainID, chainIDStr)
+// TransactOpts returns bind.TransactOpts that sign with s.
+func TransactOpts(ctx context.Context, s Signer) *bind.TransactOpts {
+	from := s.Address()
+
+	return &bind.TransactOpts{
+		From:    from,
+		Context: ctx,
+		Signer: func(addr common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if addr != from {
+				return nil, bind.ErrNotAuthorized
+			}
+
+			return s.SignTx(ctx, tx)
+		},
 	}
+}
 
-	return New(privateKey, chainID)
+// KeySigner holds the private key in memory. Use it for development only.
+type KeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+	chainID    *big.Int
 }
 
-// New creates a new Signer from a hex-encoded private key.
+var _ Signer = (*KeySigner)(nil)
+
+// New creates a KeySigner from a hex-encoded private key.
 // The privateKey should be 64 hex characters without 0x prefix.
-func New(privateKeyHex string, chainID *big.Int) (*Signer, error) {
+func New(privateKeyHex string, chainID *big.Int) (*KeySigner, error) {
 	privateKey, err := crypto.HexToECDSA(privateKeyHex)
 	if err != nil {
 		return nil, fmt.Errorf("invalid private key: %w", err)
@@ -61,7 +77,7 @@ func New(privateKeyHex string, chainID *big.Int) (*Signer, error) {
 
 	address := crypto.PubkeyToAddress(*publicKeyECDSA)
 
-	return &Signer{
+	return &KeySigner{
 		privateKey: privateKey,
 		address:    address,
 		chainID:    chainID,
@@ -69,21 +85,21 @@ func New(privateKeyHex string, chainID *big.Int) (*Signer, error) {
 }
 
 // Address returns the Ethereum address derived from the private key.
-func (s *Signer) Address() common.Address {
+func (s *KeySigner) Address() common.Address {
 	return s.address
 }
 
 // ChainID returns the chain ID.
-func (s *Signer) ChainID() *big.Int {
+func (s *KeySigner) ChainID() *big.Int {
 	return s.chainID
 }
 
-// TransactOpts returns bind.TransactOpts for contract interactions.
-func (s *Signer) TransactOpts() (*bind.TransactOpts, error) {
-	auth, err := bind.NewKeyedTransactorWithChainID(s.privateKey, s.chainID)
+// SignTx signs tx with the private key.
+func (s *KeySigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(s.chainID), s.privateKey)
 	if err != nil {
-		return nil, fmt.Errorf("create transactor: %w", err)
+		return nil, fmt.Errorf("sign tx: %w", err)
 	}
 
-	return auth, nil
+	return signed, nil
 }
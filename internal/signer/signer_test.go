@@ -0,0 +1,240 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var testChainID = big.NewInt(84532)
+
+func testTx() *types.Transaction {
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   testChainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(5),
+		Data:      []byte{0x01, 0x02},
+	})
+}
+
+func assertSignedBy(t *testing.T, s Signer, tx *types.Transaction) {
+	t.Helper()
+
+	signed, err := TransactOpts(t.Context(), s).Signer(s.Address(), tx)
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(testChainID), signed)
+	if err != nil || sender != s.Address() {
+		t.Fatalf("sender = %v (%v), want %v", sender, err, s.Address())
+	}
+}
+
+func TestKeySigner(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+
+	s, err := New(common.Bytes2Hex(crypto.FromECDSA(key)), testChainID)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	assertSignedBy(t, s, testTx())
+
+	if _, err := TransactOpts(t.Context(), s).Signer(common.Address{1}, testTx()); err == nil {
+		t.Error("signing for another address succeeded")
+	}
+}
+
+func TestKeystoreSigner(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).NewAccount("secret")
+	if err != nil {
+		t.Fatalf("create account: %v", err)
+	}
+
+	passFile := filepath.Join(t.TempDir(), "pass")
+	if err := os.WriteFile(passFile, []byte("secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewKeystore(account.URL.Path, passFile, testChainID)
+	if err != nil {
+		t.Fatalf("NewKeystore() error = %v", err)
+	}
+
+	if s.Address() != account.Address {
+		t.Errorf("Address() = %v, want %v", s.Address(), account.Address)
+	}
+
+	assertSignedBy(t, s, testTx())
+
+	if err := os.WriteFile(passFile, []byte("wrong"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewKeystore(account.URL.Path, passFile, testChainID); err == nil {
+		t.Error("NewKeystore() with wrong passphrase succeeded")
+	}
+}
+
+// web3Signer implements eth_signTransaction; tamper makes it sign a different nonce.
+type web3Signer struct {
+	key    *ecdsa.PrivateKey
+	tamper bool
+}
+
+func (w *web3Signer) SignTransaction(args signTxArgs) (hexutil.Bytes, error) {
+	nonce := uint64(args.Nonce)
+	if w.tamper {
+		nonce++
+	}
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   args.ChainID.ToInt(),
+		Nonce:     nonce,
+		GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+		GasFeeCap: args.MaxFeePerGas.ToInt(),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     args.Value.ToInt(),
+		Data:      args.Data,
+	})
+
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), w.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return signed.MarshalBinary()
+}
+
+func TestRemoteSigner(t *testing.T) {
+	t.Parallel()
+
+	key, _ := crypto.GenerateKey()
+	addr := crypto.PubkeyToAddress(key.PublicKey)
+
+	for _, tamper := range []bool{false, true} {
+		srv := rpc.NewServer()
+		if err := srv.RegisterName("eth", &web3Signer{key: key, tamper: tamper}); err != nil {
+			t.Fatal(err)
+		}
+
+		httpSrv := httptest.NewServer(srv)
+		t.Cleanup(httpSrv.Close)
+
+		s, err := NewRemote(t.Context(), httpSrv.URL, addr, testChainID)
+		if err != nil {
+			t.Fatalf("NewRemote() error = %v", err)
+		}
+		t.Cleanup(s.Close)
+
+		if !tamper {
+			assertSignedBy(t, s, testTx())
+
+			continue
+		}
+
+		if _, err := s.SignTx(t.Context(), testTx()); !errors.Is(err, ErrSignerMismatch) {
+			t.Errorf("SignTx() with tampered response error = %v, want %v", err, ErrSignerMismatch)
+		}
+	}
+}
+
+func TestNewRegistryFromEnv(t *testing.T) {
+	key1, _ := crypto.GenerateKey()
+	key2, _ := crypto.GenerateKey()
+
+	t.Setenv("CHAIN_ID", testChainID.String())
+	t.Setenv("SIGNER_TYPE", TypeEnv)
+	t.Setenv("AGENT_PRIVATE_KEY", common.Bytes2Hex(crypto.FromECDSA(key1))+", 0x"+common.Bytes2Hex(crypto.FromECDSA(key2)))
+
+	r, err := NewRegistryFromEnv(t.Context())
+	if err != nil {
+		t.Fatalf("NewRegistryFromEnv() error = %v", err)
+	}
+
+	for _, key := range []*ecdsa.PrivateKey{key1, key2} {
+		addr := crypto.PubkeyToAddress(key.PublicKey)
+
+		s, err := r.Get(addr)
+		if err != nil || s.Address() != addr {
+			t.Errorf("Get(%v) = %v, %v", addr, s, err)
+		}
+	}
+
+	if _, err := r.Get(common.Address{1}); !errors.Is(err, ErrSignerNotFound) {
+		t.Errorf("Get(unknown) error = %v, want %v", err, ErrSignerNotFound)
+	}
+
+	t.Setenv("AGENT_PRIVATE_KEY", common.Bytes2Hex(crypto.FromECDSA(key1))+","+common.Bytes2Hex(crypto.FromECDSA(key1)))
+
+	if _, err := NewRegistryFromEnv(t.Context()); !errors.Is(err, ErrInvalidSignerConfig) {
+		t.Errorf("duplicate keys error = %v, want %v", err, ErrInvalidSignerConfig)
+	}
+}
+
+type fakeNonceSource map[common.Address]uint64
+
+func (f fakeNonceSource) PendingNonceAt(_ context.Context, addr common.Address) (uint64, error) {
+	return f[addr], nil
+}
+
+func TestNonceManager(t *testing.T) {
+	t.Parallel()
+
+	a, b := common.Address{0xa}, common.Address{0xb}
+	source := fakeNonceSource{a: 5, b: 40}
+	m := NewNonceManager(source)
+
+	next := func(addr common.Address) uint64 {
+		t.Helper()
+
+		n, err := m.Next(t.Context(), addr)
+		if err != nil {
+			t.Fatalf("Next() error = %v", err)
+		}
+
+		return n
+	}
+
+	if got := []uint64{next(a), next(a), next(b), next(a)}; got[0] != 5 || got[1] != 6 || got[2] != 40 || got[3] != 7 {
+		t.Errorf("nonces = %v, want [5 6 40 7]", got)
+	}
+
+	// The node's pending nonce moved on after only one of our transactions landed.
+	source[a] = 6
+	m.Reset(a)
+
+	if n := next(a); n != 6 {
+		t.Errorf("after reset nonce = %d, want 6", n)
+	}
+
+	if n := next(b); n != 41 {
+		t.Errorf("other key nonce = %d, want 41", n)
+	}
+}
@@ -0,0 +1,11 @@
+package strategy
+
+import "errors"
+
+var (
+	// ErrInvalidSmoothing is returned for a smoothing configuration that cannot be applied.
+	ErrInvalidSmoothing = errors.New("invalid smoothing config")
+
+	// ErrSmoothingUnavailable is returned when smoothing is requested from a service without a block source.
+	ErrSmoothingUnavailable = errors.New("smoothing requires a block source")
+)
@@ -2,8 +2,8 @@ package service
 
 import (
 	"context"
-	"fmt"
 	"math/big"
+	"strconv"
 	"time"
 
 	"remora/internal/coverage"
@@ -14,12 +14,15 @@ import (
 // Service implements strategy.Service.
 type Service struct {
 	liquiditySvc liquidity.Service
+	blocks       BlockSource
 }
 
-// New creates a new strategy service.
-func New(liquiditySvc liquidity.Service) *Service {
+// New creates a new strategy service. blocks pins the samples of a smoothed target and may be
+// nil when smoothing is not used.
+func New(liquiditySvc liquidity.Service, blocks BlockSource) *Service {
 	return &Service{
 		liquiditySvc: liquiditySvc,
+		blocks:       blocks,
 	}
 }
 
@@ -28,14 +31,10 @@ var _ strategy.Service = (*Service)(nil)
 
 // ComputeTargetPositions computes optimal LP positions based on market liquidity.
 func (s *Service) ComputeTargetPositions(ctx context.Context, params *strategy.ComputeParams) (*strategy.ComputeResult, error) {
-	// Step 1: Get market liquidity distribution
-	dist, err := s.liquiditySvc.GetDistribution(ctx, &liquidity.DistributionParams{
-		PoolKey:      params.PoolKey,
-		BinSizeTicks: params.BinSizeTicks,
-		TickRange:    params.TickRange,
-	})
+	// Step 1: Get market liquidity distribution, averaged over recent blocks if configured
+	dist, samples, err := s.marketDistribution(ctx, params)
 	if err != nil {
-		return nil, fmt.Errorf("get distribution: %w", err)
+		return nil, err
 	}
 
 	sqrtPriceX96 := new(big.Int)
@@ -52,6 +51,7 @@ func (s *Service) ComputeTargetPositions(ctx context.Context, params *strategy.C
 			Bins:         nil,
 			Metrics:      coverage.Metrics{},
 			ComputedAt:   time.Now().UTC(),
+			Samples:      samples,
 		}, nil
 	}
 
@@ -65,6 +65,7 @@ func (s *Service) ComputeTargetPositions(ctx context.Context, params *strategy.C
 		Bins:         allocationBins,
 		Metrics:      result.Metrics,
 		ComputedAt:   time.Now().UTC(),
+		Samples:      samples,
 	}, nil
 }
 
@@ -84,12 +85,26 @@ func toAllocationBins(liqBins []liquidity.Bin, currentTick int32, allowedLower,
 		isCurrent := currentTick >= b.TickLower && currentTick < b.TickUpper
 
 		bins = append(bins, coverage.Bin{
-			TickLower: b.TickLower,
-			TickUpper: b.TickUpper,
-			Liquidity: b.ActiveLiquidity,
-			IsCurrent: isCurrent,
+			TickLower:         b.TickLower,
+			TickUpper:         b.TickUpper,
+			PriceLower:        parsePrice(b.PriceLower),
+			PriceUpper:        parsePrice(b.PriceUpper),
+			InversePriceLower: parsePrice(b.InversePriceLower),
+			InversePriceUpper: parsePrice(b.InversePriceUpper),
+			Liquidity:         b.ActiveLiquidity,
+			IsCurrent:         isCurrent,
 		})
 	}
 
 	return bins
 }
+
+// parsePrice parses a formatted bin price, returning 0 when the bin has none.
+func parsePrice(s string) float64 {
+	p, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+
+	return p
+}
@@ -172,3 +172,19 @@ func TestToAllocationBins_OutputType(t *testing.T) {
 		t.Fatal("expected 1 bin")
 	}
 }
+
+func TestToAllocationBins_CarriesPrices(t *testing.T) {
+	bins := []liquidity.Bin{
+		{TickLower: 0, TickUpper: 100, ActiveLiquidity: big.NewInt(500), PriceLower: "1", PriceUpper: "1.01", InversePriceLower: "0.99", InversePriceUpper: "1"},
+		{TickLower: 100, TickUpper: 200, ActiveLiquidity: big.NewInt(500)},
+	}
+
+	result := toAllocationBins(bins, 50, 0, 200)
+
+	if result[0].PriceLower != 1 || result[0].PriceUpper != 1.01 || result[0].InversePriceLower != 0.99 || result[0].InversePriceUpper != 1 {
+		t.Errorf("prices not carried: %+v", result[0])
+	}
+	if result[1].PriceLower != 0 || result[1].InversePriceUpper != 0 {
+		t.Errorf("unpriced bin got prices: %+v", result[1])
+	}
+}
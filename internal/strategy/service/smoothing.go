@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+
+	"remora/internal/liquidity"
+	"remora/internal/strategy"
+)
+
+// BlockSource returns the latest block number.
+type BlockSource interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// marketDistribution returns the distribution the target is built from and the number of
+// distributions averaged into its bins. With smoothing, every sample is read at a pinned block
+// and the bins of the latest one carry the weighted average; tick and price stay current.
+func (s *Service) marketDistribution(ctx context.Context, params *strategy.ComputeParams) (*liquidity.Distribution, int, error) {
+	if !params.Smoothing.Enabled() {
+		dist, err := s.liquiditySvc.GetDistribution(ctx, distributionParams(params, 0))
+		if err != nil {
+			return nil, 0, fmt.Errorf("get distribution: %w", err)
+		}
+
+		return dist, 1, nil
+	}
+
+	if err := params.Smoothing.Validate(); err != nil {
+		return nil, 0, err
+	}
+
+	if s.blocks == nil {
+		return nil, 0, strategy.ErrSmoothingUnavailable
+	}
+
+	latest, err := s.blocks.BlockNumber(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get block number: %w", err)
+	}
+
+	blocks := sampleBlocks(latest, params.Smoothing)
+	dists := make([]*liquidity.Distribution, len(blocks))
+	errs := make([]error, len(blocks))
+
+	var wg sync.WaitGroup
+	for i, block := range blocks {
+		wg.Go(func() {
+			dists[i], errs[i] = s.liquiditySvc.GetDistribution(ctx, distributionParams(params, block))
+		})
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, 0, fmt.Errorf("get distribution: %w", err)
+	}
+
+	smoothed := *dists[0]
+	smoothed.Bins = smoothBins(dists, params.Smoothing.Alpha)
+
+	return &smoothed, len(dists), nil
+}
+
+func distributionParams(params *strategy.ComputeParams, block uint64) *liquidity.DistributionParams {
+	return &liquidity.DistributionParams{
+		PoolKey:      params.PoolKey,
+		BinSizeTicks: params.BinSizeTicks,
+		TickRange:    params.TickRange,
+		BlockNumber:  block,
+	}
+}
+
+// sampleBlocks returns the blocks to sample, newest first. Samples that would reach block 0
+// are dropped, since block 0 means latest to the liquidity service.
+func sampleBlocks(latest uint64, cfg strategy.Smoothing) []uint64 {
+	blocks := []uint64{latest}
+
+	for k := uint64(1); k < uint64(cfg.Samples); k++ { //nolint:gosec // Samples > 1 when enabled
+		back := k * cfg.BlockStep
+		if back >= latest {
+			break
+		}
+
+		blocks = append(blocks, latest-back)
+	}
+
+	return blocks
+}
+
+// smoothBins returns the bins of dists[0] with each bin's liquidity replaced by the
+// exponentially weighted average over dists (newest first). Older samples are evaluated at
+// the bin midpoints from their own initialized ticks, so their bin grids need not match.
+func smoothBins(dists []*liquidity.Distribution, alpha float64) []liquidity.Bin {
+	const halfDivisor = 2
+
+	weights := make([]float64, len(dists))
+	total := 0.0
+
+	for k := range dists {
+		weights[k] = math.Pow(1-alpha, float64(k))
+		total += weights[k]
+	}
+
+	bins := make([]liquidity.Bin, len(dists[0].Bins))
+
+	for i, b := range dists[0].Bins {
+		mid := b.TickLower + (b.TickUpper-b.TickLower)/halfDivisor
+
+		sum := new(big.Float).Mul(new(big.Float).SetInt(b.ActiveLiquidity), big.NewFloat(weights[0]))
+		for k := 1; k < len(dists); k++ {
+			l := activeLiquidityAt(dists[k].InitializedTicks, mid)
+			sum.Add(sum, new(big.Float).Mul(new(big.Float).SetInt(l), big.NewFloat(weights[k])))
+		}
+
+		avg, _ := sum.Quo(sum, big.NewFloat(total)).Int(nil)
+
+		bins[i] = b
+		bins[i].ActiveLiquidity = avg
+	}
+
+	return bins
+}
+
+// activeLiquidityAt sums the liquidity net of the ticks at or below tick, matching how the
+// liquidity service derives bin liquidity.
+func activeLiquidityAt(ticks []liquidity.TickInfo, tick int32) *big.Int {
+	l := new(big.Int)
+
+	for _, t := range ticks {
+		if t.Tick > tick {
+			break
+		}
+
+		l.Add(l, t.LiquidityNet)
+	}
+
+	return l
+}
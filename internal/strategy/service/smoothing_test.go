@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/liquidity"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/strategy"
+)
+
+// fakeLiquidity serves one distribution per block; block 0 is the latest read.
+type fakeLiquidity struct {
+	mu     sync.Mutex
+	dists  map[uint64]*liquidity.Distribution
+	blocks []uint64
+}
+
+func (f *fakeLiquidity) GetDistribution(_ context.Context, params *liquidity.DistributionParams) (*liquidity.Distribution, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.blocks = append(f.blocks, params.BlockNumber)
+
+	dist, ok := f.dists[params.BlockNumber]
+	if !ok {
+		return nil, liquidity.ErrInvalidBlock
+	}
+
+	return dist, nil
+}
+
+func (f *fakeLiquidity) GetSlot0(context.Context, *poolid.PoolKey) (*liquidity.Slot0, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeLiquidity) ResolvePoolKey(context.Context, common.Hash) (*poolid.PoolKey, error) {
+	return nil, errors.New("not implemented")
+}
+
+type fixedBlock uint64
+
+func (b fixedBlock) BlockNumber(context.Context) (uint64, error) { return uint64(b), nil }
+
+// flatDistribution has liquidity l over [-100, 100) in two 100-tick bins.
+func flatDistribution(l int64) *liquidity.Distribution {
+	return &liquidity.Distribution{
+		CurrentTick:  10,
+		SqrtPriceX96: "79228162514264337593543950336",
+		InitializedTicks: []liquidity.TickInfo{
+			{Tick: -100, LiquidityNet: big.NewInt(l)},
+			{Tick: 100, LiquidityNet: big.NewInt(-l)},
+		},
+		Bins: []liquidity.Bin{
+			{TickLower: -100, TickUpper: 0, ActiveLiquidity: big.NewInt(l)},
+			{TickLower: 0, TickUpper: 100, ActiveLiquidity: big.NewInt(l)},
+		},
+	}
+}
+
+func TestSampleBlocks(t *testing.T) {
+	got := sampleBlocks(1000, strategy.Smoothing{Samples: 4, BlockStep: 300, Alpha: 0.5})
+	if len(got) != 4 || got[0] != 1000 || got[3] != 100 {
+		t.Errorf("sampleBlocks = %v, want [1000 700 400 100]", got)
+	}
+
+	// Block 0 would mean latest, so the fourth sample is dropped.
+	if got := sampleBlocks(900, strategy.Smoothing{Samples: 4, BlockStep: 300, Alpha: 0.5}); len(got) != 3 {
+		t.Errorf("sampleBlocks near genesis = %v, want 3 samples", got)
+	}
+}
+
+func TestComputeTargetPositions_Smoothed(t *testing.T) {
+	liq := &fakeLiquidity{dists: map[uint64]*liquidity.Distribution{
+		1000: flatDistribution(400),
+		900:  flatDistribution(100),
+	}}
+
+	svc := New(liq, fixedBlock(1000))
+
+	res, err := svc.ComputeTargetPositions(t.Context(), &strategy.ComputeParams{
+		BinSizeTicks:     100,
+		TickRange:        1000,
+		AllowedTickLower: -100,
+		AllowedTickUpper: 100,
+		Smoothing:        strategy.Smoothing{Samples: 2, BlockStep: 100, Alpha: 0.5},
+	})
+	if err != nil {
+		t.Fatalf("ComputeTargetPositions() error = %v", err)
+	}
+
+	if res.Samples != 2 {
+		t.Errorf("Samples = %d, want 2", res.Samples)
+	}
+
+	// Weights 1 and 0.5: (400 + 0.5*100) / 1.5 = 300.
+	for _, b := range res.Bins {
+		if b.Liquidity.Int64() != 300 {
+			t.Errorf("bin [%d, %d) liquidity = %s, want 300", b.TickLower, b.TickUpper, b.Liquidity)
+		}
+	}
+
+	if res.CurrentTick != 10 {
+		t.Errorf("CurrentTick = %d, want the latest sample's 10", res.CurrentTick)
+	}
+
+	// The latest sample must not mutate the distribution it came from.
+	if l := liq.dists[1000].Bins[0].ActiveLiquidity.Int64(); l != 400 {
+		t.Errorf("source distribution modified: %d", l)
+	}
+}
+
+func TestComputeTargetPositions_SmoothingErrors(t *testing.T) {
+	liq := &fakeLiquidity{dists: map[uint64]*liquidity.Distribution{0: flatDistribution(1)}}
+	params := &strategy.ComputeParams{BinSizeTicks: 100, TickRange: 1000, AllowedTickLower: -100, AllowedTickUpper: 100}
+
+	if _, err := New(liq, nil).ComputeTargetPositions(t.Context(), params); err != nil {
+		t.Fatalf("unsmoothed ComputeTargetPositions() error = %v", err)
+	}
+
+	if len(liq.blocks) != 1 || liq.blocks[0] != 0 {
+		t.Errorf("unsmoothed reads = %v, want one latest read", liq.blocks)
+	}
+
+	params.Smoothing = strategy.Smoothing{Samples: 3, BlockStep: 10, Alpha: 0.5}
+	if _, err := New(liq, nil).ComputeTargetPositions(t.Context(), params); !errors.Is(err, strategy.ErrSmoothingUnavailable) {
+		t.Errorf("without block source error = %v, want %v", err, strategy.ErrSmoothingUnavailable)
+	}
+
+	params.Smoothing.Alpha = 0
+	if _, err := New(liq, fixedBlock(100)).ComputeTargetPositions(t.Context(), params); !errors.Is(err, strategy.ErrInvalidSmoothing) {
+		t.Errorf("zero alpha error = %v, want %v", err, strategy.ErrInvalidSmoothing)
+	}
+}
@@ -4,6 +4,7 @@ package strategy
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"time"
 
@@ -25,6 +26,38 @@ type ComputeParams struct {
 	AlgoConfig       coverage.Config // Algorithm configuration
 	AllowedTickLower int32           // Vault's allowed lower tick bound
 	AllowedTickUpper int32           // Vault's allowed upper tick bound
+	Smoothing        Smoothing       // Time-weighted market target; zero uses the latest distribution only
+}
+
+// Smoothing builds the market target from an exponentially weighted average of recent
+// distributions instead of a single snapshot, so short-lived liquidity (e.g. JIT positions)
+// moves the target less. The sample k steps back is weighted by (1-Alpha)^k.
+type Smoothing struct {
+	Samples   int     // Distributions to average, including the latest; 0 or 1 disables smoothing
+	BlockStep uint64  // Blocks between consecutive samples
+	Alpha     float64 // Decay in (0, 1]; higher values favour recent samples
+}
+
+// Enabled reports whether more than one distribution is averaged.
+func (s Smoothing) Enabled() bool {
+	return s.Samples > 1
+}
+
+// Validate checks an enabled configuration.
+func (s Smoothing) Validate() error {
+	if !s.Enabled() {
+		return nil
+	}
+
+	if s.BlockStep == 0 {
+		return fmt.Errorf("%w: block step must be positive", ErrInvalidSmoothing)
+	}
+
+	if s.Alpha <= 0 || s.Alpha > 1 {
+		return fmt.Errorf("%w: alpha must be in (0, 1], got %v", ErrInvalidSmoothing, s.Alpha)
+	}
+
+	return nil
 }
 
 // ComputeResult contains the computed target positions.
@@ -35,6 +68,7 @@ type ComputeResult struct {
 	Bins         []coverage.Bin     // Original market liquidity bins
 	Metrics      coverage.Metrics   // Coverage metrics
 	ComputedAt   time.Time          // Timestamp when computation was performed
+	Samples      int                // Distributions averaged into Bins
 }
 
 // Position represents an existing LP position (for future use with gap calculation).
@@ -0,0 +1,11 @@
+package token
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a token is not stored.
+	ErrNotFound = errors.New("token not found")
+
+	// ErrNotERC20 is returned when a contract does not answer decimals().
+	ErrNotERC20 = errors.New("not an erc20 token")
+)
@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/multicall"
+	"remora/internal/token"
+)
+
+const (
+	// maxSymbolLen bounds symbols read from arbitrary contracts.
+	maxSymbolLen = 64
+	// maxTokensPerBatch caps the tokens read by one Multicall3 eth_call, two calls each.
+	maxTokensPerBatch = 100
+)
+
+var erc20ABI abi.ABI
+
+func init() {
+	var err error
+
+	erc20ABI, err = abi.JSON(strings.NewReader(`[
+		{"name":"decimals","type":"function","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+		{"name":"symbol","type":"function","inputs":[],"outputs":[{"name":"","type":"string"}]}
+	]`))
+	if err != nil {
+		panic("parse erc20 abi: " + err.Error())
+	}
+}
+
+// ERC20Reader reads decimals and symbol from a token contract in one Multicall3 batch.
+type ERC20Reader struct {
+	caller ethereum.ContractCaller
+}
+
+var _ token.Reader = (*ERC20Reader)(nil)
+
+func NewERC20Reader(caller ethereum.ContractCaller) *ERC20Reader {
+	return &ERC20Reader{caller: caller}
+}
+
+// Read returns the token metadata. A missing or malformed symbol falls back to the short
+// address; a missing decimals() is ErrNotERC20.
+func (r *ERC20Reader) Read(ctx context.Context, addr common.Address) (*token.Token, error) {
+	if token.IsNative(addr) {
+		return token.Native(), nil
+	}
+
+	calls, err := tokenCalls(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := multicall.Aggregate3(ctx, r.caller, calls, nil)
+	if err != nil {
+		return nil, fmt.Errorf("read token %s: %w", addr.Hex(), err)
+	}
+
+	return decodeToken(addr, results[0], results[1])
+}
+
+// ReadMany reads the tokens maxTokensPerBatch at a time, one Multicall3 batch each. Contracts
+// without decimals() are left out.
+func (r *ERC20Reader) ReadMany(ctx context.Context, addrs []common.Address) (map[common.Address]*token.Token, error) {
+	tokens := make(map[common.Address]*token.Token, len(addrs))
+
+	var contracts []common.Address
+
+	for _, addr := range addrs {
+		if token.IsNative(addr) {
+			tokens[addr] = token.Native()
+
+			continue
+		}
+
+		contracts = append(contracts, addr)
+	}
+
+	for start := 0; start < len(contracts); start += maxTokensPerBatch {
+		chunk := contracts[start:min(start+maxTokensPerBatch, len(contracts))]
+		calls := make([]multicall.Call, 0, 2*len(chunk))
+
+		for _, addr := range chunk {
+			c, err := tokenCalls(addr)
+			if err != nil {
+				return nil, err
+			}
+
+			calls = append(calls, c...)
+		}
+
+		results, err := multicall.Aggregate3(ctx, r.caller, calls, nil)
+		if err != nil {
+			return nil, fmt.Errorf("read tokens: %w", err)
+		}
+
+		for i, addr := range chunk {
+			if t, err := decodeToken(addr, results[2*i], results[2*i+1]); err == nil {
+				tokens[addr] = t
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// tokenCalls returns the decimals() and symbol() calls of addr, both allowed to fail.
+func tokenCalls(addr common.Address) ([]multicall.Call, error) {
+	decimalsCall, err := multicall.NewCall(&erc20ABI, addr, "decimals")
+	if err != nil {
+		return nil, err
+	}
+
+	symbolCall, err := multicall.NewCall(&erc20ABI, addr, "symbol")
+	if err != nil {
+		return nil, err
+	}
+
+	decimalsCall.AllowFailure = true
+	symbolCall.AllowFailure = true
+
+	return []multicall.Call{decimalsCall, symbolCall}, nil
+}
+
+// decodeToken decodes the results of tokenCalls.
+func decodeToken(addr common.Address, decimalsResult, symbolResult multicall.Result) (*token.Token, error) {
+	var decimals any
+	if err := multicall.Decode(&erc20ABI, "decimals", decimalsResult, &decimals); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", token.ErrNotERC20, addr.Hex(), err)
+	}
+
+	d, ok := decimals.(uint8)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s: decimals type %T", token.ErrNotERC20, addr.Hex(), decimals)
+	}
+
+	return &token.Token{Address: addr, Symbol: decodeSymbol(addr, symbolResult), Decimals: d}, nil
+}
+
+// decodeSymbol decodes a string symbol, or a bytes32 one as returned by older tokens (e.g. MKR).
+func decodeSymbol(addr common.Address, r multicall.Result) string {
+	var symbol any
+	if err := multicall.Decode(&erc20ABI, "symbol", r, &symbol); err == nil {
+		if s, ok := symbol.(string); ok && s != "" && len(s) <= maxSymbolLen && utf8.ValidString(s) {
+			return s
+		}
+	}
+
+	if r.Success && len(r.ReturnData) == common.HashLength {
+		if s := string(bytes.TrimRight(r.ReturnData, "\x00")); s != "" && utf8.ValidString(s) {
+			return s
+		}
+	}
+
+	return addr.Hex()[:10]
+}
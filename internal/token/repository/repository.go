@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+
+	"remora/internal/db"
+	"remora/internal/token"
+)
+
+// Repository stores token metadata in PostgreSQL.
+type Repository struct {
+	q *db.Queries
+}
+
+var _ token.Repository = (*Repository)(nil)
+
+func New(q *db.Queries) *Repository {
+	return &Repository{q: q}
+}
+
+func (r *Repository) Get(ctx context.Context, addr common.Address) (*token.Token, error) {
+	row, err := r.q.GetToken(ctx, addr.Hex())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, token.ErrNotFound
+		}
+
+		return nil, fmt.Errorf("get token: %w", err)
+	}
+
+	return toToken(row), nil
+}
+
+func (r *Repository) GetMany(ctx context.Context, addrs []common.Address) ([]*token.Token, error) {
+	hexes := make([]string, len(addrs))
+	for i, addr := range addrs {
+		hexes[i] = addr.Hex()
+	}
+
+	rows, err := r.q.ListTokens(ctx, hexes)
+	if err != nil {
+		return nil, fmt.Errorf("list tokens: %w", err)
+	}
+
+	tokens := make([]*token.Token, len(rows))
+	for i, row := range rows {
+		tokens[i] = toToken(row)
+	}
+
+	return tokens, nil
+}
+
+func (r *Repository) Save(ctx context.Context, t *token.Token) error {
+	err := r.q.UpsertToken(ctx, db.UpsertTokenParams{
+		Address:   t.Address.Hex(),
+		Symbol:    t.Symbol,
+		Decimals:  int16(t.Decimals),
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("save token: %w", err)
+	}
+
+	return nil
+}
+
+func toToken(row db.Token) *token.Token {
+	return &token.Token{
+		Address:  common.HexToAddress(row.Address),
+		Symbol:   row.Symbol,
+		Decimals: uint8(row.Decimals), //nolint:gosec // stored from a uint8
+	}
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/token"
+)
+
+// failureTTL is how long a token that could not be resolved is not retried, so lists that
+// include it do not read the chain on every request.
+const failureTTL = 5 * time.Minute
+
+// Service resolves token metadata from memory, then the repository, then the chain, storing
+// what it reads so each token hits the chain once. Failures are remembered for failureTTL.
+type Service struct {
+	repo   token.Repository
+	reader token.Reader
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu     sync.RWMutex
+	tokens map[common.Address]*token.Token
+	failed map[common.Address]failure
+}
+
+// failure is a lookup error and when the token may be retried.
+type failure struct {
+	err   error
+	until time.Time
+}
+
+var _ token.Service = (*Service)(nil)
+
+// New creates a token service. repo may be nil to cache in memory only.
+func New(repo token.Repository, reader token.Reader, logger *slog.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		reader: reader,
+		logger: logger,
+		now:    time.Now,
+		tokens: make(map[common.Address]*token.Token),
+		failed: make(map[common.Address]failure),
+	}
+}
+
+func (s *Service) Get(ctx context.Context, addr common.Address) (*token.Token, error) {
+	if token.IsNative(addr) {
+		return token.Native(), nil
+	}
+
+	if t, ok, err := s.cached(addr); ok {
+		return t, err
+	}
+
+	t, err := s.load(ctx, addr)
+	if err != nil {
+		s.fail(addr, err)
+
+		return nil, err
+	}
+
+	s.store(addr, t)
+
+	return t, nil
+}
+
+// GetMany resolves the addresses not in memory with one repository query and one batched
+// chain read.
+func (s *Service) GetMany(ctx context.Context, addrs []common.Address) (map[common.Address]*token.Token, error) {
+	tokens := make(map[common.Address]*token.Token, len(addrs))
+	seen := make(map[common.Address]bool, len(addrs))
+
+	var (
+		missing []common.Address
+		errs    []error
+	)
+
+	for _, addr := range addrs {
+		if seen[addr] {
+			continue
+		}
+
+		seen[addr] = true
+
+		if token.IsNative(addr) {
+			tokens[addr] = token.Native()
+
+			continue
+		}
+
+		t, ok, err := s.cached(addr)
+
+		switch {
+		case !ok:
+			missing = append(missing, addr)
+		case err != nil:
+			errs = append(errs, err)
+		default:
+			tokens[addr] = t
+		}
+	}
+
+	if len(missing) > 0 {
+		errs = append(errs, s.loadMany(ctx, missing, tokens))
+	}
+
+	return tokens, errors.Join(errs...)
+}
+
+func (s *Service) load(ctx context.Context, addr common.Address) (*token.Token, error) {
+	if s.repo != nil {
+		t, err := s.repo.Get(ctx, addr)
+		if err == nil {
+			return t, nil
+		}
+
+		if !errors.Is(err, token.ErrNotFound) {
+			s.logger.WarnContext(ctx, "read stored token failed", slog.String("token", addr.Hex()), slog.Any("error", err))
+		}
+	}
+
+	t, err := s.reader.Read(ctx, addr)
+	if err != nil {
+		return nil, fmt.Errorf("read token metadata: %w", err)
+	}
+
+	s.save(ctx, t)
+
+	return t, nil
+}
+
+// loadMany resolves addrs into tokens from the repository, then the chain, and returns the
+// failures joined.
+func (s *Service) loadMany(ctx context.Context, addrs []common.Address, tokens map[common.Address]*token.Token) error {
+	unresolved := addrs
+
+	if s.repo != nil {
+		stored, err := s.repo.GetMany(ctx, addrs)
+		if err != nil {
+			s.logger.WarnContext(ctx, "read stored tokens failed", slog.Int("tokens", len(addrs)), slog.Any("error", err))
+		}
+
+		for _, t := range stored {
+			s.store(t.Address, t)
+			tokens[t.Address] = t
+		}
+
+		unresolved = make([]common.Address, 0, len(addrs))
+
+		for _, addr := range addrs {
+			if _, ok := tokens[addr]; !ok {
+				unresolved = append(unresolved, addr)
+			}
+		}
+	}
+
+	if len(unresolved) == 0 {
+		return nil
+	}
+
+	read, err := s.reader.ReadMany(ctx, unresolved)
+	if err != nil {
+		err = fmt.Errorf("read token metadata: %w", err)
+
+		for _, addr := range unresolved {
+			s.fail(addr, err)
+		}
+
+		return err
+	}
+
+	var errs []error
+
+	for _, addr := range unresolved {
+		t, ok := read[addr]
+		if !ok {
+			err := fmt.Errorf("read token metadata: %w: %s", token.ErrNotERC20, addr.Hex())
+			s.fail(addr, err)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		s.save(ctx, t)
+		s.store(addr, t)
+		tokens[addr] = t
+	}
+
+	return errors.Join(errs...)
+}
+
+// cached returns the token or the unexpired failure remembered for addr; ok is false for neither.
+func (s *Service) cached(addr common.Address) (*token.Token, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if t, ok := s.tokens[addr]; ok {
+		return t, true, nil
+	}
+
+	if f, ok := s.failed[addr]; ok && s.now().Before(f.until) {
+		return nil, true, f.err
+	}
+
+	return nil, false, nil
+}
+
+func (s *Service) store(addr common.Address, t *token.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[addr] = t
+	delete(s.failed, addr)
+}
+
+func (s *Service) fail(addr common.Address, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failed[addr] = failure{err: err, until: s.now().Add(failureTTL)}
+}
+
+// save stores a token read from the chain, logging a failure.
+func (s *Service) save(ctx context.Context, t *token.Token) {
+	if s.repo == nil {
+		return
+	}
+
+	if err := s.repo.Save(ctx, t); err != nil {
+		s.logger.WarnContext(ctx, "store token failed", slog.String("token", t.Address.Hex()), slog.Any("error", err))
+	}
+}
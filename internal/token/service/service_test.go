@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/mock/gomock"
+
+	"remora/internal/token"
+	"remora/internal/token/mocks"
+)
+
+var usdc = &token.Token{Address: common.HexToAddress("0x036CbD53842c5426634e7929541eC2318f3dCF7e"), Symbol: "USDC", Decimals: 6}
+
+func TestService_GetNative(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	svc := New(mocks.NewMockRepository(ctrl), mocks.NewMockReader(ctrl), slog.Default())
+
+	got, err := svc.Get(t.Context(), common.Address{})
+	if err != nil || got.Symbol != token.NativeSymbol || got.Decimals != token.NativeDecimals {
+		t.Errorf("Get(native) = %+v, %v", got, err)
+	}
+}
+
+func TestService_GetReadsChainOnceAndStores(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	reader := mocks.NewMockReader(ctrl)
+	svc := New(repo, reader, slog.Default())
+
+	repo.EXPECT().Get(gomock.Any(), usdc.Address).Return(nil, token.ErrNotFound)
+	reader.EXPECT().Read(gomock.Any(), usdc.Address).Return(usdc, nil)
+	repo.EXPECT().Save(gomock.Any(), usdc).Return(nil)
+
+	for range 2 {
+		got, err := svc.Get(t.Context(), usdc.Address)
+		if err != nil || got.Decimals != 6 || got.Symbol != "USDC" {
+			t.Fatalf("Get() = %+v, %v", got, err)
+		}
+	}
+}
+
+func TestService_GetStored(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	svc := New(repo, mocks.NewMockReader(ctrl), slog.Default())
+
+	repo.EXPECT().Get(gomock.Any(), usdc.Address).Return(usdc, nil)
+
+	if got, err := svc.Get(t.Context(), usdc.Address); err != nil || got != usdc {
+		t.Errorf("Get() = %+v, %v", got, err)
+	}
+}
+
+func TestService_GetManyBatchesAndRemembersFailures(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockRepository(ctrl)
+	reader := mocks.NewMockReader(ctrl)
+	svc := New(repo, reader, slog.Default())
+
+	now := time.Unix(1000, 0)
+	svc.now = func() time.Time { return now }
+
+	dai := &token.Token{Address: common.HexToAddress("0x6B175474E89094C44Da98b954EedeAC495271d0F"), Symbol: "DAI", Decimals: 18}
+	hook := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	repo.EXPECT().GetMany(gomock.Any(), []common.Address{usdc.Address, dai.Address, hook}).Return([]*token.Token{dai}, nil)
+	reader.EXPECT().ReadMany(gomock.Any(), []common.Address{usdc.Address, hook}).Return(map[common.Address]*token.Token{usdc.Address: usdc}, nil)
+	repo.EXPECT().Save(gomock.Any(), usdc).Return(nil)
+
+	addrs := []common.Address{usdc.Address, {}, dai.Address, usdc.Address, hook}
+
+	got, err := svc.GetMany(t.Context(), addrs)
+	if !errors.Is(err, token.ErrNotERC20) {
+		t.Errorf("GetMany() error = %v, want ErrNotERC20 for the hook", err)
+	}
+
+	if len(got) != 3 || got[usdc.Address] != usdc || got[dai.Address] != dai || got[common.Address{}].Symbol != token.NativeSymbol {
+		t.Fatalf("GetMany() = %v, want USDC, DAI and ETH", got)
+	}
+
+	// Resolved tokens and the failure are served from memory.
+	if _, err := svc.GetMany(t.Context(), addrs); !errors.Is(err, token.ErrNotERC20) {
+		t.Errorf("second GetMany() error = %v, want the remembered failure", err)
+	}
+
+	// The failure is retried once it expires.
+	now = now.Add(failureTTL)
+
+	repo.EXPECT().GetMany(gomock.Any(), []common.Address{hook}).Return(nil, nil)
+	reader.EXPECT().ReadMany(gomock.Any(), []common.Address{hook}).Return(map[common.Address]*token.Token{}, nil)
+
+	if _, err := svc.GetMany(t.Context(), addrs); !errors.Is(err, token.ErrNotERC20) {
+		t.Errorf("GetMany() after expiry error = %v, want ErrNotERC20", err)
+	}
+}
@@ -0,0 +1,60 @@
+// Package token resolves ERC20 metadata (symbol and decimals) for pool currencies.
+package token
+
+//go:generate mockgen -source=token.go -destination=mocks/mock_repository.go -package=mocks Repository Reader
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// NativeDecimals and NativeSymbol describe native ETH, which Uniswap v4 pools represent
+// with the zero address.
+const (
+	NativeDecimals = 18
+	NativeSymbol   = "ETH"
+)
+
+// Token is the metadata of a pool currency.
+type Token struct {
+	Address  common.Address `json:"address"`
+	Symbol   string         `json:"symbol"`
+	Decimals uint8          `json:"decimals"`
+}
+
+// IsNative reports whether addr is native ETH.
+func IsNative(addr common.Address) bool {
+	return addr == (common.Address{})
+}
+
+// Native returns the metadata of native ETH.
+func Native() *Token {
+	return &Token{Symbol: NativeSymbol, Decimals: NativeDecimals}
+}
+
+// Service resolves token metadata.
+type Service interface {
+	// Get returns the metadata of the token at addr. The zero address is native ETH.
+	Get(ctx context.Context, addr common.Address) (*Token, error)
+
+	// GetMany resolves each distinct address once, leaving out those it cannot. The error joins
+	// the failures, so the tokens resolved are usable even when it is non-nil.
+	GetMany(ctx context.Context, addrs []common.Address) (map[common.Address]*Token, error)
+}
+
+// Repository persists resolved metadata, which never changes for a deployed token.
+type Repository interface {
+	// Get returns ErrNotFound when addr has not been stored.
+	Get(ctx context.Context, addr common.Address) (*Token, error)
+	// GetMany returns the stored tokens among addrs.
+	GetMany(ctx context.Context, addrs []common.Address) ([]*Token, error)
+	Save(ctx context.Context, t *Token) error
+}
+
+// Reader reads metadata from the token contract.
+type Reader interface {
+	Read(ctx context.Context, addr common.Address) (*Token, error)
+	// ReadMany reads several tokens in one batch. Contracts that are not ERC20 are left out.
+	ReadMany(ctx context.Context, addrs []common.Address) (map[common.Address]*Token, error)
+}
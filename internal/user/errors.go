@@ -4,4 +4,7 @@ import (
 	"errors"
 )
 
-var ErrNotFound = errors.New("not found")
+var (
+	ErrNotFound       = errors.New("not found")
+	ErrInvalidAddress = errors.New("invalid address")
+)
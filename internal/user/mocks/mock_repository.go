@@ -56,6 +56,21 @@ func (mr *MockServiceMockRecorder) ByID(ctx, id any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ByID", reflect.TypeOf((*MockService)(nil).ByID), ctx, id)
 }
 
+// UpsertByAddress mocks base method.
+func (m *MockService) UpsertByAddress(ctx context.Context, address string) (user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertByAddress", ctx, address)
+	ret0, _ := ret[0].(user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertByAddress indicates an expected call of UpsertByAddress.
+func (mr *MockServiceMockRecorder) UpsertByAddress(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertByAddress", reflect.TypeOf((*MockService)(nil).UpsertByAddress), ctx, address)
+}
+
 // MockRepository is a mock of Repository interface.
 type MockRepository struct {
 	ctrl     *gomock.Controller
@@ -93,3 +108,18 @@ func (mr *MockRepositoryMockRecorder) GetUser(ctx, id any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockRepository)(nil).GetUser), ctx, id)
 }
+
+// UpsertUserByAddress mocks base method.
+func (m *MockRepository) UpsertUserByAddress(ctx context.Context, address string) (user.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertUserByAddress", ctx, address)
+	ret0, _ := ret[0].(user.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertUserByAddress indicates an expected call of UpsertUserByAddress.
+func (mr *MockRepositoryMockRecorder) UpsertUserByAddress(ctx, address any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertUserByAddress", reflect.TypeOf((*MockRepository)(nil).UpsertUserByAddress), ctx, address)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -33,6 +34,22 @@ func (r *Repository) GetUser(ctx context.Context, id uuid.UUID) (user.User, erro
 	return toDomain(u), nil
 }
 
+func (r *Repository) UpsertUserByAddress(ctx context.Context, address string) (user.User, error) {
+	now := time.Now()
+
+	u, err := r.q.UpsertUserByAddress(ctx, db.UpsertUserByAddressParams{
+		ID:        uuid.New(),
+		Address:   address,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+	if err != nil {
+		return user.User{}, fmt.Errorf("upsert user by address: %w", err)
+	}
+
+	return toDomain(u), nil
+}
+
 func toDomain(u db.User) user.User {
 	return user.User{
 		ID:        u.ID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/google/uuid"
 
 	"remora/internal/user"
@@ -25,3 +26,17 @@ func (s *Service) ByID(ctx context.Context, id uuid.UUID) (user.User, error) {
 
 	return u, nil
 }
+
+func (s *Service) UpsertByAddress(ctx context.Context, address string) (user.User, error) {
+	if !common.IsHexAddress(address) {
+		return user.User{}, fmt.Errorf("%w: %q", user.ErrInvalidAddress, address)
+	}
+
+	// Stored checksummed so lookups by address are stable.
+	u, err := s.repo.UpsertUserByAddress(ctx, common.HexToAddress(address).Hex())
+	if err != nil {
+		return user.User{}, fmt.Errorf("upsert user by address: %w", err)
+	}
+
+	return u, nil
+}
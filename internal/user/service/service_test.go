@@ -109,6 +109,92 @@ func TestService_ByID(t *testing.T) {
 	}
 }
 
+func TestService_UpsertByAddress(t *testing.T) {
+	t.Parallel()
+
+	checksummed := "0x52908400098527886E0F7030069857D2E4169EE7"
+	wantUser := user.User{
+		ID:      uuid.MustParse("b1c2d3e4-f5a6-7b8c-9d0e-f1a2b3c4d5e6"),
+		Address: checksummed,
+	}
+
+	tests := []struct {
+		name      string
+		address   string
+		setupRepo func(ctrl *gomock.Controller) *mocks.MockRepository
+		want      user.User
+		wantErr   bool
+		err       error
+	}{
+		{
+			name:    "success - stores checksummed address",
+			address: "0x52908400098527886e0f7030069857d2e4169ee7",
+			setupRepo: func(ctrl *gomock.Controller) *mocks.MockRepository {
+				repo := mocks.NewMockRepository(ctrl)
+				repo.EXPECT().
+					UpsertUserByAddress(gomock.Any(), checksummed).
+					Return(wantUser, nil)
+
+				return repo
+			},
+			want: wantUser,
+		},
+		{
+			name:    "error - invalid address",
+			address: "0x1234",
+			setupRepo: func(ctrl *gomock.Controller) *mocks.MockRepository {
+				return mocks.NewMockRepository(ctrl)
+			},
+			wantErr: true,
+			err:     user.ErrInvalidAddress,
+		},
+		{
+			name:    "error - other",
+			address: checksummed,
+			setupRepo: func(ctrl *gomock.Controller) *mocks.MockRepository {
+				repo := mocks.NewMockRepository(ctrl)
+				repo.EXPECT().
+					UpsertUserByAddress(gomock.Any(), checksummed).
+					Return(user.User{}, errDB)
+
+				return repo
+			},
+			wantErr: true,
+			err:     errDB,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			ctrl := gomock.NewController(t)
+			svc := service.New(tt.setupRepo(ctrl))
+
+			got, err := svc.UpsertByAddress(t.Context(), tt.address)
+			if err != nil {
+				if !tt.wantErr {
+					t.Errorf("UpsertByAddress() failed: %v", err)
+				}
+
+				if tt.err != nil && !errors.Is(err, tt.err) {
+					t.Errorf("UpsertByAddress() error = %v, want %v", err, tt.err)
+				}
+
+				return
+			}
+
+			if tt.wantErr {
+				t.Errorf("UpsertByAddress() expected error")
+				return
+			}
+
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("UpsertByAddress() = %v, want %v, diff %v", got, tt.want, cmp.Diff(got, tt.want))
+			}
+		})
+	}
+}
+
 func TestMain(m *testing.M) {
 	leak := flag.Bool("leak", true, "enable goleak checks")
 	flag.Parse()
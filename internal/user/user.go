@@ -20,9 +20,12 @@ type User struct {
 // Service defines the use cases for user. No external dependencies.
 type Service interface {
 	ByID(ctx context.Context, id uuid.UUID) (User, error)
+	// UpsertByAddress returns the user owning address, creating it on first sight.
+	UpsertByAddress(ctx context.Context, address string) (User, error)
 }
 
 // Repository abstracts user persistence. Implementations may depend on db.
 type Repository interface {
 	GetUser(ctx context.Context, id uuid.UUID) (User, error)
+	UpsertUserByAddress(ctx context.Context, address string) (User, error)
 }
@@ -5,6 +5,7 @@ import (
 	"log/slog"
 	"math/big"
 	"net/http"
+	"strconv"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/go-chi/chi/v5"
@@ -13,6 +14,7 @@ import (
 	"remora/internal/httpwrap"
 	"remora/internal/liquidity"
 	"remora/internal/liquidity/poolid"
+	"remora/internal/user"
 	"remora/internal/vault"
 )
 
@@ -21,7 +23,12 @@ import (
 type VaultFactory func(address common.Address) (vault.Vault, error)
 
 // AddRoutes registers vault-related routes on the provided router.
-func AddRoutes(r chi.Router, factory VaultFactory, liquiditySvc liquidity.Service) {
+// On-chain routes need factory; the events route needs events. Either may be nil.
+func AddRoutes(r chi.Router, factory VaultFactory, liquiditySvc liquidity.Service, events vault.EventLister) {
+	if events != nil {
+		r.Get("/vaults/{address}/events", httpwrap.Handler(getEvents(events)))
+	}
+
 	if factory == nil {
 		return
 	}
@@ -30,8 +37,24 @@ func AddRoutes(r chi.Router, factory VaultFactory, liquiditySvc liquidity.Servic
 	r.Get("/vaults/{address}/positions", httpwrap.Handler(getPositions(factory, liquiditySvc)))
 }
 
+// AddOwnerRoutes registers routes scoped to the authenticated user. The caller must mount them
+// behind AuthMiddleware. "My vaults" needs owners, which only the vault indexer keeps current;
+// callers pass nil while the indexer is off so the route is not served with an empty index.
+func AddOwnerRoutes(r chi.Router, users user.Service, owners vault.OwnerIndex, factory VaultFactory, liquiditySvc liquidity.Service) {
+	if factory == nil {
+		return
+	}
+
+	r.Post("/vaults/{address}/tx/{action}", httpwrap.Handler(postOwnerTx(users, factory)))
+
+	if owners != nil {
+		r.Get("/me/vaults", httpwrap.Handler(getMyVaults(users, owners, factory, liquiditySvc)))
+	}
+}
+
 // StateResponse is the API response for vault state.
 type StateResponse struct {
+	Owner            string          `json:"owner"`
 	Agent            string          `json:"agent"`
 	AgentPaused      bool            `json:"agentPaused"`
 	SwapAllowed      bool            `json:"swapAllowed"`
@@ -55,9 +78,10 @@ type PoolKeyResponse struct {
 
 // PositionsResponse is the API response for vault positions (with vault totals).
 type PositionsResponse struct {
-	Amount0   string             `json:"amount0"`
-	Amount1   string             `json:"amount1"`
-	Positions []PositionResponse `json:"positions"`
+	BlockNumber uint64             `json:"blockNumber"` // block the positions were read at
+	Amount0     string             `json:"amount0"`
+	Amount1     string             `json:"amount1"`
+	Positions   []PositionResponse `json:"positions"`
 }
 
 // PositionResponse is a single position in the API response.
@@ -114,6 +138,7 @@ func getState(factory VaultFactory) func(*http.Request) (*httpwrap.Response, *ht
 		return &httpwrap.Response{
 			StatusCode: http.StatusOK,
 			Body: &StateResponse{
+				Owner:            state.Owner.Hex(),
 				Agent:            state.Agent.Hex(),
 				AgentPaused:      state.AgentPaused,
 				SwapAllowed:      state.SwapAllowed,
@@ -165,20 +190,20 @@ func getPositions(factory VaultFactory, liquiditySvc liquidity.Service) func(*ht
 			}
 		}
 
-		state, err := v.GetState(r.Context())
-		if err != nil {
-			slog.ErrorContext(r.Context(), "get vault state failed", slog.String("address", addrHex), slog.String("error", err.Error()))
+		var blockNumber *big.Int
 
-			return nil, &httpwrap.ErrorResponse{
-				StatusCode: http.StatusInternalServerError,
-				ErrorMsg:   err.Error(),
-				Err:        err,
+		if b := r.URL.Query().Get("block"); b != "" {
+			n, err := strconv.ParseUint(b, 10, 64)
+			if err != nil {
+				return nil, httpwrap.NewInvalidParamErrorResponse("block")
 			}
+
+			blockNumber = new(big.Int).SetUint64(n)
 		}
 
-		positions, err := v.GetPositions(r.Context())
+		snapshot, err := v.GetSnapshot(r.Context(), blockNumber)
 		if err != nil {
-			slog.ErrorContext(r.Context(), "get vault positions failed", slog.String("address", addrHex), slog.String("error", err.Error()))
+			slog.ErrorContext(r.Context(), "get vault snapshot failed", slog.String("address", addrHex), slog.String("error", err.Error()))
 
 			return nil, &httpwrap.ErrorResponse{
 				StatusCode: http.StatusInternalServerError,
@@ -187,6 +212,8 @@ func getPositions(factory VaultFactory, liquiditySvc liquidity.Service) func(*ht
 			}
 		}
 
+		state, positions := &snapshot.State, snapshot.Positions
+
 		poolKey := vaultPoolKeyToLiquidity(&state.PoolKey)
 
 		var slot0 *liquidity.Slot0
@@ -210,10 +237,7 @@ func getPositions(factory VaultFactory, liquiditySvc liquidity.Service) func(*ht
 			amount0, amount1 := "0", "0"
 
 			if slot0 != nil {
-				sqrtPriceA := allocation.TickToSqrtPriceX96(int(p.TickLower))
-				sqrtPriceB := allocation.TickToSqrtPriceX96(int(p.TickUpper))
-				a0 := allocation.GetAmount0ForLiquidity(slot0.SqrtPriceX96, sqrtPriceA, sqrtPriceB, p.Liquidity)
-				a1 := allocation.GetAmount1ForLiquidity(slot0.SqrtPriceX96, sqrtPriceA, sqrtPriceB, p.Liquidity)
+				a0, a1 := positionAmounts(slot0.SqrtPriceX96, p)
 				amount0 = a0.String()
 				amount1 = a1.String()
 
@@ -234,14 +258,24 @@ func getPositions(factory VaultFactory, liquiditySvc liquidity.Service) func(*ht
 		return &httpwrap.Response{
 			StatusCode: http.StatusOK,
 			Body: &PositionsResponse{
-				Amount0:   total0.String(),
-				Amount1:   total1.String(),
-				Positions: resp,
+				BlockNumber: snapshot.BlockNumber,
+				Amount0:     total0.String(),
+				Amount1:     total1.String(),
+				Positions:   resp,
 			},
 		}, nil
 	}
 }
 
+// positionAmounts returns the token amounts held by a position at sqrtPriceX96.
+func positionAmounts(sqrtPriceX96 *big.Int, p vault.Position) (amount0, amount1 *big.Int) {
+	sqrtPriceA := allocation.TickToSqrtPriceX96(int(p.TickLower))
+	sqrtPriceB := allocation.TickToSqrtPriceX96(int(p.TickUpper))
+
+	return allocation.GetAmount0ForLiquidity(sqrtPriceX96, sqrtPriceA, sqrtPriceB, p.Liquidity),
+		allocation.GetAmount1ForLiquidity(sqrtPriceX96, sqrtPriceA, sqrtPriceB, p.Liquidity)
+}
+
 // vaultPoolKeyToLiquidity converts vault.PoolKey to poolid.PoolKey for liquidity service.
 func vaultPoolKeyToLiquidity(k *vault.PoolKey) *poolid.PoolKey {
 	if k == nil {
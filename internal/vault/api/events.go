@@ -0,0 +1,174 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/httpwrap"
+	"remora/internal/vault"
+)
+
+const (
+	defaultEventsLimit = 50
+	maxEventsLimit     = 200
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// EventsResponse is the API response for a page of vault events, newest first.
+type EventsResponse struct {
+	Events []EventResponse `json:"events"`
+	// NextCursor is passed as ?cursor= to fetch the next (older) page; empty when there are no more events.
+	NextCursor string `json:"nextCursor"`
+}
+
+// EventResponse is a single vault event in the API response.
+type EventResponse struct {
+	Type        string          `json:"type"`
+	BlockNumber uint64          `json:"blockNumber"`
+	LogIndex    uint            `json:"logIndex"`
+	BlockHash   string          `json:"blockHash"`
+	TxHash      string          `json:"txHash"`
+	BlockTime   time.Time       `json:"blockTime"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// getEvents returns a handler listing a vault's indexed events.
+//
+// Query params: type (comma-separated event types), fromBlock, toBlock, cursor, limit.
+func getEvents(events vault.EventLister) func(*http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		addrHex := chi.URLParam(r, "address")
+		if !common.IsHexAddress(addrHex) {
+			return nil, httpwrap.NewInvalidParamErrorResponse("address")
+		}
+
+		filter, errResp := parseEventFilter(r.URL.Query())
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		filter.Vault = common.HexToAddress(addrHex)
+
+		// Fetch one extra row to know whether another page exists.
+		limit := filter.Limit
+		filter.Limit++
+
+		list, err := events.ListEvents(r.Context(), filter)
+		if err != nil {
+			slog.ErrorContext(r.Context(), "list vault events failed", slog.String("address", addrHex), slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "list vault events failed",
+				Err:        err,
+			}
+		}
+
+		resp := &EventsResponse{Events: make([]EventResponse, 0, min(len(list), limit))}
+
+		for i, e := range list {
+			if i == limit {
+				last := list[i-1]
+				resp.NextCursor = encodeEventCursor(vault.EventCursor{BlockNumber: last.BlockNumber, LogIndex: last.LogIndex})
+
+				break
+			}
+
+			resp.Events = append(resp.Events, EventResponse{
+				Type:        string(e.Type),
+				BlockNumber: e.BlockNumber,
+				LogIndex:    e.LogIndex,
+				BlockHash:   e.BlockHash.Hex(),
+				TxHash:      e.TxHash.Hex(),
+				BlockTime:   e.BlockTime,
+				Data:        e.Data,
+			})
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       resp,
+		}, nil
+	}
+}
+
+func parseEventFilter(q url.Values) (vault.EventFilter, *httpwrap.ErrorResponse) {
+	filter := vault.EventFilter{Limit: defaultEventsLimit}
+
+	if types := q.Get("type"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			et := vault.EventType(strings.TrimSpace(t))
+			if !vault.IsEventType(et) {
+				return filter, httpwrap.NewInvalidParamErrorResponse("type")
+			}
+
+			filter.Types = append(filter.Types, et)
+		}
+	}
+
+	var err error
+
+	// Blocks are stored as BIGINT, so both bounds must fit in 63 bits.
+	if v := q.Get("fromBlock"); v != "" {
+		if filter.FromBlock, err = strconv.ParseUint(v, 10, 63); err != nil {
+			return filter, httpwrap.NewInvalidParamErrorResponse("fromBlock")
+		}
+	}
+
+	if v := q.Get("toBlock"); v != "" {
+		if filter.ToBlock, err = strconv.ParseUint(v, 10, 63); err != nil || filter.ToBlock < filter.FromBlock {
+			return filter, httpwrap.NewInvalidParamErrorResponse("toBlock")
+		}
+	}
+
+	if v := q.Get("limit"); v != "" {
+		if filter.Limit, err = strconv.Atoi(v); err != nil || filter.Limit < 1 || filter.Limit > maxEventsLimit {
+			return filter, httpwrap.NewInvalidParamErrorResponse("limit")
+		}
+	}
+
+	if v := q.Get("cursor"); v != "" {
+		cursor, err := decodeEventCursor(v)
+		if err != nil {
+			return filter, httpwrap.NewInvalidParamErrorResponse("cursor")
+		}
+
+		filter.Before = &cursor
+	}
+
+	return filter, nil
+}
+
+// encodeEventCursor encodes a cursor as "<blockNumber>-<logIndex>".
+func encodeEventCursor(c vault.EventCursor) string {
+	return strconv.FormatUint(c.BlockNumber, 10) + "-" + strconv.FormatUint(uint64(c.LogIndex), 10)
+}
+
+func decodeEventCursor(s string) (vault.EventCursor, error) {
+	blockStr, indexStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return vault.EventCursor{}, errInvalidCursor
+	}
+
+	block, err := strconv.ParseUint(blockStr, 10, 63)
+	if err != nil {
+		return vault.EventCursor{}, errInvalidCursor
+	}
+
+	index, err := strconv.ParseUint(indexStr, 10, 31)
+	if err != nil {
+		return vault.EventCursor{}, errInvalidCursor
+	}
+
+	return vault.EventCursor{BlockNumber: block, LogIndex: uint(index)}, nil
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+
+	"remora/internal/vault"
+)
+
+func TestParseEventFilter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+		check   func(t *testing.T, f vault.EventFilter)
+	}{
+		{
+			name:  "defaults",
+			query: "",
+			check: func(t *testing.T, f vault.EventFilter) {
+				t.Helper()
+
+				if f.Limit != defaultEventsLimit || f.Before != nil || len(f.Types) != 0 {
+					t.Errorf("unexpected defaults: %+v", f)
+				}
+			},
+		},
+		{
+			name:  "all params",
+			query: "type=PositionAdded,AgentPaused&fromBlock=10&toBlock=20&limit=5&cursor=15-3",
+			check: func(t *testing.T, f vault.EventFilter) {
+				t.Helper()
+
+				if len(f.Types) != 2 || f.Types[0] != vault.EventPositionAdded || f.Types[1] != vault.EventAgentPaused {
+					t.Errorf("unexpected types: %v", f.Types)
+				}
+
+				if f.FromBlock != 10 || f.ToBlock != 20 || f.Limit != 5 {
+					t.Errorf("unexpected range/limit: %+v", f)
+				}
+
+				if f.Before == nil || f.Before.BlockNumber != 15 || f.Before.LogIndex != 3 {
+					t.Errorf("unexpected cursor: %+v", f.Before)
+				}
+			},
+		},
+		{name: "unknown type", query: "type=Transfer", wantErr: true},
+		{name: "inverted range", query: "fromBlock=20&toBlock=10", wantErr: true},
+		{name: "fromBlock above int64", query: "fromBlock=9223372036854775808", wantErr: true},
+		{name: "toBlock above int64", query: "toBlock=18446744073709551615", wantErr: true},
+		{name: "limit too large", query: "limit=1000", wantErr: true},
+		{name: "bad cursor", query: "cursor=abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			f, errResp := parseEventFilter(q)
+			if (errResp != nil) != tt.wantErr {
+				t.Fatalf("wantErr %v, got %+v", tt.wantErr, errResp)
+			}
+
+			if tt.check != nil {
+				tt.check(t, f)
+			}
+		})
+	}
+}
+
+func TestEventCursorRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	c := vault.EventCursor{BlockNumber: 123456, LogIndex: 7}
+
+	got, err := decodeEventCursor(encodeEventCursor(c))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	if got != c {
+		t.Errorf("expected %+v, got %+v", c, got)
+	}
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/api/middleware"
+	"remora/internal/httpwrap"
+	"remora/internal/liquidity"
+	"remora/internal/user"
+	"remora/internal/vault"
+)
+
+// maxConcurrentVaultReads bounds the on-chain snapshot reads of one "my vaults" request.
+const maxConcurrentVaultReads = 8
+
+// MyVaultsResponse is the API response listing the vaults owned by the caller.
+type MyVaultsResponse struct {
+	Owner  string            `json:"owner"`
+	Vaults []MyVaultResponse `json:"vaults"`
+}
+
+// MyVaultResponse is a vault state summary with its position totals.
+type MyVaultResponse struct {
+	Address          string `json:"address"`
+	BlockNumber      uint64 `json:"blockNumber"`
+	Agent            string `json:"agent"`
+	AgentPaused      bool   `json:"agentPaused"`
+	SwapAllowed      bool   `json:"swapAllowed"`
+	AllowedTickLower int32  `json:"allowedTickLower"`
+	AllowedTickUpper int32  `json:"allowedTickUpper"`
+	PoolID           string `json:"poolId"`
+	PositionsCount   int    `json:"positionsCount"`
+	Liquidity        string `json:"liquidity"`
+	Amount0          string `json:"amount0"`
+	Amount1          string `json:"amount1"`
+}
+
+// getMyVaults returns a handler listing the caller's vaults with their current on-chain state.
+func getMyVaults(users user.Service, owners vault.OwnerIndex, factory VaultFactory, liquiditySvc liquidity.Service) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		ctx := r.Context()
+
+		u, err := users.ByID(ctx, middleware.GetUserID(r))
+		if err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "get user failed",
+				Err:        err,
+			}
+		}
+
+		owner := common.HexToAddress(u.Address)
+
+		configs, err := owners.ListConfigsByOwner(ctx, owner)
+		if err != nil {
+			slog.ErrorContext(ctx, "list vaults by owner failed", slog.String("owner", owner.Hex()), slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "list vaults failed",
+				Err:        err,
+			}
+		}
+
+		summaries := make([]*MyVaultResponse, len(configs))
+		errs := make([]error, len(configs))
+		sem := make(chan struct{}, maxConcurrentVaultReads)
+
+		var wg sync.WaitGroup
+
+		for i, cfg := range configs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				summaries[i], errs[i] = summarizeVault(ctx, factory, liquiditySvc, cfg.Vault, owner)
+			}()
+		}
+
+		wg.Wait()
+
+		resp := &MyVaultsResponse{Owner: owner.Hex(), Vaults: make([]MyVaultResponse, 0, len(configs))}
+
+		for i, s := range summaries {
+			if errs[i] != nil {
+				slog.ErrorContext(ctx, "summarize vault failed", slog.String("address", configs[i].Vault.Hex()), slog.String("error", errs[i].Error()))
+
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusInternalServerError,
+					ErrorMsg:   "read vault failed",
+					Err:        errs[i],
+				}
+			}
+
+			if s != nil {
+				resp.Vaults = append(resp.Vaults, *s)
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body:       resp,
+		}, nil
+	}
+}
+
+// summarizeVault reads a vault snapshot and totals its positions. It returns nil when the vault
+// is no longer owned by owner on chain, i.e. the owner index has not caught up with a transfer yet.
+func summarizeVault(ctx context.Context, factory VaultFactory, liquiditySvc liquidity.Service, addr, owner common.Address) (*MyVaultResponse, error) {
+	v, err := factory(addr)
+	if err != nil {
+		return nil, fmt.Errorf("vault factory: %w", err)
+	}
+
+	snapshot, err := v.GetSnapshot(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get vault snapshot: %w", err)
+	}
+
+	state := &snapshot.State
+	if state.Owner != owner {
+		return nil, nil //nolint:nilnil // not owned anymore, skipped
+	}
+
+	liq, total0, total1 := new(big.Int), new(big.Int), new(big.Int)
+
+	var slot0 *liquidity.Slot0
+
+	if liquiditySvc != nil && len(snapshot.Positions) > 0 {
+		slot0, err = liquiditySvc.GetSlot0(ctx, vaultPoolKeyToLiquidity(&state.PoolKey))
+		if err != nil {
+			return nil, fmt.Errorf("get pool slot0: %w", err)
+		}
+	}
+
+	for _, p := range snapshot.Positions {
+		liq.Add(liq, p.Liquidity)
+
+		if slot0 != nil {
+			a0, a1 := positionAmounts(slot0.SqrtPriceX96, p)
+			total0.Add(total0, a0)
+			total1.Add(total1, a1)
+		}
+	}
+
+	return &MyVaultResponse{
+		Address:          addr.Hex(),
+		BlockNumber:      snapshot.BlockNumber,
+		Agent:            state.Agent.Hex(),
+		AgentPaused:      state.AgentPaused,
+		SwapAllowed:      state.SwapAllowed,
+		AllowedTickLower: state.AllowedTickLower,
+		AllowedTickUpper: state.AllowedTickUpper,
+		PoolID:           hex.EncodeToString(state.PoolID[:]),
+		PositionsCount:   len(snapshot.Positions),
+		Liquidity:        liq.String(),
+		Amount0:          total0.String(),
+		Amount1:          total1.String(),
+	}, nil
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/vault"
+)
+
+// snapshotVault serves a fixed snapshot; other vault.Vault methods are not used.
+type snapshotVault struct {
+	vault.Vault
+
+	snapshot *vault.Snapshot
+}
+
+func (v *snapshotVault) GetSnapshot(context.Context, *big.Int) (*vault.Snapshot, error) {
+	return v.snapshot, nil
+}
+
+func TestSummarizeVault(t *testing.T) {
+	t.Parallel()
+
+	addr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	snapshot := &vault.Snapshot{
+		BlockNumber: 100,
+		State:       vault.State{Owner: owner, AllowedTickLower: -600, AllowedTickUpper: 600},
+		Positions: []vault.Position{
+			{TokenID: big.NewInt(1), TickLower: -60, TickUpper: 60, Liquidity: big.NewInt(1000)},
+			{TokenID: big.NewInt(2), TickLower: -120, TickUpper: 120, Liquidity: big.NewInt(500)},
+		},
+	}
+
+	factory := func(common.Address) (vault.Vault, error) {
+		return &snapshotVault{snapshot: snapshot}, nil
+	}
+
+	got, err := summarizeVault(context.Background(), factory, nil, addr, owner)
+	if err != nil {
+		t.Fatalf("summarizeVault: %v", err)
+	}
+
+	if got.PositionsCount != 2 || got.Liquidity != "1500" || got.BlockNumber != 100 || got.Address != addr.Hex() {
+		t.Errorf("unexpected summary: %+v", got)
+	}
+
+	// Transferred away on chain but not yet re-indexed: skipped.
+	got, err = summarizeVault(context.Background(), factory, nil, addr, common.HexToAddress("0x01"))
+	if err != nil || got != nil {
+		t.Errorf("expected vault owned by someone else to be skipped, got %+v, %v", got, err)
+	}
+}
+
+func TestAddOwnerRoutes_WithoutOwnerIndex(t *testing.T) {
+	t.Parallel()
+
+	factory := func(common.Address) (vault.Vault, error) { return &snapshotVault{}, nil }
+
+	r := chi.NewRouter()
+	AddOwnerRoutes(r, nil, nil, factory, nil)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/me/vaults", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d without an owner index", rec.Code, http.StatusNotFound)
+	}
+}
@@ -0,0 +1,205 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/api/middleware"
+	"remora/internal/httpwrap"
+	"remora/internal/user"
+	"remora/internal/vault"
+)
+
+// OwnerTxRequest is the body of POST /vaults/{address}/tx/{action}. Only the fields of the
+// requested action are read. Big numbers are decimal strings.
+type OwnerTxRequest struct {
+	Agent         *string `json:"agent"`
+	Paused        *bool   `json:"paused"`
+	TickLower     *int32  `json:"tickLower"`
+	TickUpper     *int32  `json:"tickUpper"`
+	MaxPositionsK *string `json:"maxPositionsK"`
+	SwapAllowed   *bool   `json:"swapAllowed"`
+	Deadline      *int64  `json:"deadline"` // unix seconds
+	Currency      *string `json:"currency"`
+	Amount        *string `json:"amount"`
+	To            *string `json:"to"`
+	NewOwner      *string `json:"newOwner"`
+}
+
+// OwnerTxResponse is an unsigned transaction, in the shape eth_sendTransaction expects.
+type OwnerTxResponse struct {
+	Action      string `json:"action"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Data        string `json:"data"`
+	Value       string `json:"value"`
+	BlockNumber uint64 `json:"blockNumber"` // block of the state the parameters were validated against
+}
+
+// postOwnerTx returns a handler that validates an owner action against current vault state
+// and returns it as an unsigned transaction from the caller's wallet.
+func postOwnerTx(users user.Service, factory VaultFactory) httpwrap.HandlerFunc {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		ctx := r.Context()
+
+		addrHex := chi.URLParam(r, "address")
+		if !common.IsHexAddress(addrHex) {
+			return nil, httpwrap.NewInvalidParamErrorResponse("address")
+		}
+
+		action := vault.OwnerAction(chi.URLParam(r, "action"))
+
+		var req OwnerTxRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusBadRequest,
+				ErrorMsg:   "invalid request body: " + err.Error(),
+				Err:        err,
+			}
+		}
+
+		params, errResp := parseOwnerTxParams(&req)
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		u, err := users.ByID(ctx, middleware.GetUserID(r))
+		if err != nil {
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "get user failed",
+				Err:        err,
+			}
+		}
+
+		addr := common.HexToAddress(addrHex)
+
+		v, err := factory(addr)
+		if err != nil {
+			slog.ErrorContext(ctx, "vault factory failed", slog.String("address", addrHex), slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   err.Error(),
+				Err:        err,
+			}
+		}
+
+		snapshot, err := v.GetSnapshot(ctx, nil)
+		if err != nil {
+			slog.ErrorContext(ctx, "get vault snapshot failed", slog.String("address", addrHex), slog.String("error", err.Error()))
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   err.Error(),
+				Err:        err,
+			}
+		}
+
+		tx, err := vault.BuildOwnerTx(addr, common.HexToAddress(u.Address), snapshot, action, params, time.Now())
+		if err != nil {
+			switch {
+			case errors.Is(err, vault.ErrNotOwner):
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusForbidden,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			case errors.Is(err, vault.ErrInvalidOwnerTx):
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusBadRequest,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			}
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "build transaction failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{
+			StatusCode: http.StatusOK,
+			Body: &OwnerTxResponse{
+				Action:      string(action),
+				From:        tx.From.Hex(),
+				To:          tx.To.Hex(),
+				Data:        hexutil.Encode(tx.Data),
+				Value:       hexutil.EncodeBig(tx.Value),
+				BlockNumber: tx.BlockNumber,
+			},
+		}, nil
+	}
+}
+
+// parseOwnerTxParams converts the request's string-encoded fields.
+func parseOwnerTxParams(req *OwnerTxRequest) (vault.OwnerTxParams, *httpwrap.ErrorResponse) {
+	p := vault.OwnerTxParams{
+		Paused:      req.Paused,
+		TickLower:   req.TickLower,
+		TickUpper:   req.TickUpper,
+		SwapAllowed: req.SwapAllowed,
+	}
+
+	addresses := []struct {
+		name string
+		in   *string
+		out  **common.Address
+	}{
+		{"agent", req.Agent, &p.Agent},
+		{"currency", req.Currency, &p.Currency},
+		{"to", req.To, &p.To},
+		{"newOwner", req.NewOwner, &p.NewOwner},
+	}
+
+	for _, a := range addresses {
+		if a.in == nil {
+			continue
+		}
+
+		if !common.IsHexAddress(*a.in) {
+			return p, httpwrap.NewInvalidParamErrorResponse(a.name)
+		}
+
+		addr := common.HexToAddress(*a.in)
+		*a.out = &addr
+	}
+
+	numbers := []struct {
+		name string
+		in   *string
+		out  **big.Int
+	}{
+		{"maxPositionsK", req.MaxPositionsK, &p.MaxPositionsK},
+		{"amount", req.Amount, &p.Amount},
+	}
+
+	for _, n := range numbers {
+		if n.in == nil {
+			continue
+		}
+
+		v, ok := new(big.Int).SetString(*n.in, 10)
+		if !ok {
+			return p, httpwrap.NewInvalidParamErrorResponse(n.name)
+		}
+
+		*n.out = v
+	}
+
+	if req.Deadline != nil {
+		p.Deadline = big.NewInt(*req.Deadline)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,13 @@
+package vault
+
+import (
+	"errors"
+)
+
+var (
+	ErrNotIndexed   = errors.New("vault not indexed")
+	ErrUnknownEvent = errors.New("unknown vault event")
+
+	ErrNotOwner       = errors.New("caller is not the vault owner")
+	ErrInvalidOwnerTx = errors.New("invalid owner transaction")
+)
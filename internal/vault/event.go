@@ -0,0 +1,433 @@
+package vault
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EventType is the name of a V4AgenticVault event.
+type EventType string
+
+// Indexed vault events.
+const (
+	EventAgentPaused             EventType = "AgentPaused"
+	EventAgentUpdated            EventType = "AgentUpdated"
+	EventAllowedTickRangeUpdated EventType = "AllowedTickRangeUpdated"
+	EventMaxPositionsKUpdated    EventType = "MaxPositionsKUpdated"
+	EventOwnershipTransferred    EventType = "OwnershipTransferred"
+	EventPositionAdded           EventType = "PositionAdded"
+	EventPositionRemoved         EventType = "PositionRemoved"
+	EventSwapAllowed             EventType = "SwapAllowed"
+)
+
+// EventTypes lists all indexed event types.
+var EventTypes = []EventType{
+	EventAgentPaused,
+	EventAgentUpdated,
+	EventAllowedTickRangeUpdated,
+	EventMaxPositionsKUpdated,
+	EventOwnershipTransferred,
+	EventPositionAdded,
+	EventPositionRemoved,
+	EventSwapAllowed,
+}
+
+// Event is an indexed vault event. Data holds the JSON encoding of the event's *Data struct.
+type Event struct {
+	Vault       common.Address
+	Type        EventType
+	BlockNumber uint64
+	BlockHash   common.Hash
+	TxHash      common.Hash
+	LogIndex    uint
+	BlockTime   time.Time
+	Data        json.RawMessage
+}
+
+// Event payloads, stored as JSON. Large integers are encoded as decimal strings.
+type (
+	AgentPausedData struct {
+		Paused bool `json:"paused"`
+	}
+	AgentUpdatedData struct {
+		NewAgent common.Address `json:"newAgent"`
+	}
+	AllowedTickRangeUpdatedData struct {
+		TickLower int32 `json:"tickLower"`
+		TickUpper int32 `json:"tickUpper"`
+	}
+	MaxPositionsKUpdatedData struct {
+		K string `json:"k"`
+	}
+	OwnershipTransferredData struct {
+		PreviousOwner common.Address `json:"previousOwner"`
+		NewOwner      common.Address `json:"newOwner"`
+	}
+	PositionAddedData struct {
+		TokenID   string `json:"tokenId"`
+		TickLower int32  `json:"tickLower"`
+		TickUpper int32  `json:"tickUpper"`
+	}
+	PositionRemovedData struct {
+		TokenID string `json:"tokenId"`
+	}
+	SwapAllowedData struct {
+		Allowed bool `json:"allowed"`
+	}
+)
+
+// Config is a vault's configuration as of IndexedBlock, derived from its events.
+type Config struct {
+	Vault            common.Address
+	Owner            common.Address
+	Agent            common.Address
+	AgentPaused      bool
+	SwapAllowed      bool
+	AllowedTickLower int32
+	AllowedTickUpper int32
+	MaxPositionsK    *big.Int
+	IndexedBlock     uint64
+}
+
+// IndexedPosition is a managed position tracked from PositionAdded/PositionRemoved events.
+type IndexedPosition struct {
+	TokenID     *big.Int
+	TickLower   int32
+	TickUpper   int32
+	BlockNumber uint64
+}
+
+// PositionChange is a position added to or removed from a vault.
+type PositionChange struct {
+	Position IndexedPosition
+	Removed  bool
+}
+
+// IndexBatch is the result of indexing a block range for one vault.
+// It is persisted atomically: events, position changes and the config (whose
+// IndexedBlock acts as the vault's indexing cursor).
+type IndexBatch struct {
+	Config          Config
+	Events          []Event
+	PositionChanges []PositionChange
+}
+
+// EventCursor identifies an event's position in a vault's timeline.
+type EventCursor struct {
+	BlockNumber uint64
+	LogIndex    uint
+}
+
+// EventFilter selects events of one vault. Results are ordered newest first.
+type EventFilter struct {
+	Vault common.Address
+	// Types restricts the event types; empty means all.
+	Types []EventType
+	// FromBlock and ToBlock bound the block range (inclusive); zero ToBlock means no upper bound.
+	FromBlock uint64
+	ToBlock   uint64
+	// Before returns only events strictly older than the cursor, for pagination.
+	Before *EventCursor
+	Limit  int
+}
+
+// IsEventType reports whether t is an indexed event type.
+func IsEventType(t EventType) bool {
+	return slices.Contains(EventTypes, t)
+}
+
+// EventLister lists indexed vault events.
+type EventLister interface {
+	ListEvents(ctx context.Context, filter EventFilter) ([]Event, error)
+}
+
+// OwnerIndex looks up indexed vaults by their current owner.
+type OwnerIndex interface {
+	// ListConfigsByOwner returns the indexed configs of vaults owned by owner, ordered by vault address.
+	ListConfigsByOwner(ctx context.Context, owner common.Address) ([]Config, error)
+}
+
+// EventRepository persists indexed vault events and the state derived from them.
+type EventRepository interface {
+	// GetConfig returns the indexed config of a vault, or ErrNotIndexed.
+	GetConfig(ctx context.Context, vault common.Address) (Config, error)
+
+	// ListIndexedBlocks returns the last indexed block of every indexed vault.
+	ListIndexedBlocks(ctx context.Context) (map[common.Address]uint64, error)
+
+	// ListPositions returns the indexed managed positions of a vault.
+	ListPositions(ctx context.Context, vault common.Address) ([]IndexedPosition, error)
+
+	// SaveBatch atomically stores an IndexBatch.
+	SaveBatch(ctx context.Context, batch IndexBatch) error
+
+	// ListEvents returns events matching filter.
+	ListEvents(ctx context.Context, filter EventFilter) ([]Event, error)
+
+	// AdvanceIndexedBlock moves the cursor of already indexed vaults that had no events up to block.
+	AdvanceIndexedBlock(ctx context.Context, vaults []common.Address, block uint64) error
+}
+
+// eventFilterer is only used to unpack logs, so it needs no address or backend.
+var eventFilterer, _ = NewV4AgenticVaultFilterer(common.Address{}, nil) //nolint:gochecknoglobals // stateless ABI unpacker
+
+// EventTopics returns the topic0 hashes of all indexed event types.
+func EventTopics() ([]common.Hash, error) {
+	parsed, err := V4AgenticVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("parse vault abi: %w", err)
+	}
+
+	topics := make([]common.Hash, 0, len(EventTypes))
+
+	for _, t := range EventTypes {
+		ev, ok := parsed.Events[string(t)]
+		if !ok {
+			return nil, fmt.Errorf("event %s not in vault abi", t)
+		}
+
+		topics = append(topics, ev.ID)
+	}
+
+	return topics, nil
+}
+
+// ParseEvent decodes a vault log into an Event. BlockTime is left for the caller to fill.
+// Returns ErrUnknownEvent for logs that are not indexed event types.
+func ParseEvent(lg types.Log) (Event, error) {
+	if len(lg.Topics) == 0 {
+		return Event{}, ErrUnknownEvent
+	}
+
+	parsed, err := V4AgenticVaultMetaData.GetAbi()
+	if err != nil {
+		return Event{}, fmt.Errorf("parse vault abi: %w", err)
+	}
+
+	abiEvent, err := parsed.EventByID(lg.Topics[0])
+	if err != nil {
+		return Event{}, ErrUnknownEvent
+	}
+
+	data, err := parseEventData(EventType(abiEvent.Name), lg)
+	if err != nil {
+		return Event{}, fmt.Errorf("parse %s: %w", abiEvent.Name, err)
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return Event{}, fmt.Errorf("marshal %s: %w", abiEvent.Name, err)
+	}
+
+	return Event{
+		Vault:       lg.Address,
+		Type:        EventType(abiEvent.Name),
+		BlockNumber: lg.BlockNumber,
+		BlockHash:   lg.BlockHash,
+		TxHash:      lg.TxHash,
+		LogIndex:    lg.Index,
+		Data:        raw,
+	}, nil
+}
+
+//nolint:cyclop // one case per event type
+func parseEventData(t EventType, lg types.Log) (any, error) {
+	switch t {
+	case EventAgentPaused:
+		ev, err := eventFilterer.ParseAgentPaused(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return AgentPausedData{Paused: ev.Paused}, nil
+	case EventAgentUpdated:
+		ev, err := eventFilterer.ParseAgentUpdated(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return AgentUpdatedData{NewAgent: ev.NewAgent}, nil
+	case EventAllowedTickRangeUpdated:
+		ev, err := eventFilterer.ParseAllowedTickRangeUpdated(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return AllowedTickRangeUpdatedData{
+			TickLower: int32(ev.TickLower.Int64()), //nolint:gosec // tick is int24, fits in int32
+			TickUpper: int32(ev.TickUpper.Int64()), //nolint:gosec // tick is int24, fits in int32
+		}, nil
+	case EventMaxPositionsKUpdated:
+		ev, err := eventFilterer.ParseMaxPositionsKUpdated(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return MaxPositionsKUpdatedData{K: ev.K.String()}, nil
+	case EventOwnershipTransferred:
+		ev, err := eventFilterer.ParseOwnershipTransferred(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return OwnershipTransferredData{PreviousOwner: ev.PreviousOwner, NewOwner: ev.NewOwner}, nil
+	case EventPositionAdded:
+		ev, err := eventFilterer.ParsePositionAdded(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return PositionAddedData{
+			TokenID:   ev.TokenId.String(),
+			TickLower: int32(ev.TickLower.Int64()), //nolint:gosec // tick is int24, fits in int32
+			TickUpper: int32(ev.TickUpper.Int64()), //nolint:gosec // tick is int24, fits in int32
+		}, nil
+	case EventPositionRemoved:
+		ev, err := eventFilterer.ParsePositionRemoved(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return PositionRemovedData{TokenID: ev.TokenId.String()}, nil
+	case EventSwapAllowed:
+		ev, err := eventFilterer.ParseSwapAllowed(lg)
+		if err != nil {
+			return nil, err
+		}
+
+		return SwapAllowedData{Allowed: ev.Allowed}, nil
+	default:
+		return nil, ErrUnknownEvent
+	}
+}
+
+// Apply updates the config with an event and returns the resulting position change, if any.
+// Events must be applied in (block, log index) order.
+//
+//nolint:cyclop // one case per event type
+func (c *Config) Apply(e Event) (*PositionChange, error) {
+	var change *PositionChange
+
+	switch e.Type {
+	case EventAgentPaused:
+		var d AgentPausedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		c.AgentPaused = d.Paused
+	case EventAgentUpdated:
+		var d AgentUpdatedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		c.Agent = d.NewAgent
+	case EventAllowedTickRangeUpdated:
+		var d AllowedTickRangeUpdatedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		c.AllowedTickLower, c.AllowedTickUpper = d.TickLower, d.TickUpper
+	case EventMaxPositionsKUpdated:
+		var d MaxPositionsKUpdatedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		k, ok := new(big.Int).SetString(d.K, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid k: %s", d.K)
+		}
+
+		c.MaxPositionsK = k
+	case EventOwnershipTransferred:
+		var d OwnershipTransferredData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		c.Owner = d.NewOwner
+	case EventPositionAdded:
+		var d PositionAddedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		tokenID, ok := new(big.Int).SetString(d.TokenID, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token id: %s", d.TokenID)
+		}
+
+		change = &PositionChange{Position: IndexedPosition{
+			TokenID:     tokenID,
+			TickLower:   d.TickLower,
+			TickUpper:   d.TickUpper,
+			BlockNumber: e.BlockNumber,
+		}}
+	case EventPositionRemoved:
+		var d PositionRemovedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		tokenID, ok := new(big.Int).SetString(d.TokenID, 10)
+		if !ok {
+			return nil, fmt.Errorf("invalid token id: %s", d.TokenID)
+		}
+
+		change = &PositionChange{Position: IndexedPosition{TokenID: tokenID, BlockNumber: e.BlockNumber}, Removed: true}
+	case EventSwapAllowed:
+		var d SwapAllowedData
+		if err := json.Unmarshal(e.Data, &d); err != nil {
+			return nil, fmt.Errorf("unmarshal %s: %w", e.Type, err)
+		}
+
+		c.SwapAllowed = d.Allowed
+	default:
+		return nil, ErrUnknownEvent
+	}
+
+	return change, nil
+}
+
+// ReadConfig reads the vault's config and managed positions at the given block.
+// Used to seed the indexer before it starts applying events.
+func (c *Client) ReadConfig(ctx context.Context, block uint64) (Config, []IndexedPosition, error) {
+	snapshot, err := c.GetSnapshot(ctx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return Config{}, nil, fmt.Errorf("get snapshot: %w", err)
+	}
+
+	positions := make([]IndexedPosition, 0, len(snapshot.Positions))
+	for _, p := range snapshot.Positions {
+		positions = append(positions, IndexedPosition{
+			TokenID:     p.TokenID,
+			TickLower:   p.TickLower,
+			TickUpper:   p.TickUpper,
+			BlockNumber: block,
+		})
+	}
+
+	s := snapshot.State
+
+	return Config{
+		Vault:            c.address,
+		Owner:            s.Owner,
+		Agent:            s.Agent,
+		AgentPaused:      s.AgentPaused,
+		SwapAllowed:      s.SwapAllowed,
+		AllowedTickLower: s.AllowedTickLower,
+		AllowedTickUpper: s.AllowedTickUpper,
+		MaxPositionsK:    s.MaxPositionsK,
+		IndexedBlock:     block,
+	}, positions, nil
+}
@@ -1,4 +1,4 @@
-package agent
+package vault
 
 import (
 	"context"
@@ -11,11 +11,11 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
 )
 
-// Minimal ABI for factory vault discovery.
+// Minimal ABI for factory vault discovery: the listing calls and the creation event.
 const factoryABIJSON = `[
+	{"anonymous":false,"inputs":[{"indexed":true,"internalType":"address","name":"vault","type":"address"},{"indexed":true,"internalType":"address","name":"owner","type":"address"}],"name":"VaultCreated","type":"event"},
 	{"inputs":[],"name":"getAllVaults","outputs":[{"internalType":"address[]","name":"","type":"address[]"}],"stateMutability":"view","type":"function"},
 	{"inputs":[],"name":"totalVaults","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
 	{"inputs":[{"internalType":"uint256","name":"","type":"uint256"}],"name":"vaults","outputs":[{"internalType":"address","name":"","type":"address"}],"stateMutability":"view","type":"function"}
@@ -28,18 +28,39 @@ func init() {
 
 	factoryABI, err = abi.JSON(strings.NewReader(factoryABIJSON))
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse factory ABI: %v", err))
+		panic(fmt.Sprintf("parse factory ABI: %v", err))
 	}
 }
 
-// FactoryVaultSource reads vaults from the on-chain factory.
+// VaultCreatedTopic returns the topic0 of the factory's VaultCreated event and the index of the
+// topic holding the created vault's address.
+func VaultCreatedTopic() (common.Hash, int) {
+	ev := factoryABI.Events["VaultCreated"]
+	index := 1
+
+	for _, in := range ev.Inputs {
+		if !in.Indexed {
+			continue
+		}
+
+		if in.Name == "vault" {
+			break
+		}
+
+		index++
+	}
+
+	return ev.ID, index
+}
+
+// FactoryVaultSource lists the vaults created by the on-chain factory.
 type FactoryVaultSource struct {
-	client      *ethclient.Client
+	client      ethereum.ContractCaller
 	factoryAddr common.Address
 }
 
 // NewFactoryVaultSource creates a vault source that queries the factory contract.
-func NewFactoryVaultSource(client *ethclient.Client, factoryAddr common.Address) *FactoryVaultSource {
+func NewFactoryVaultSource(client ethereum.ContractCaller, factoryAddr common.Address) *FactoryVaultSource {
 	return &FactoryVaultSource{
 		client:      client,
 		factoryAddr: factoryAddr,
@@ -175,6 +196,3 @@ func (s *FactoryVaultSource) getVaultByIndex(ctx context.Context, index *big.Int
 
 	return addr, nil
 }
-
-// Ensure FactoryVaultSource implements VaultSource.
-var _ VaultSource = (*FactoryVaultSource)(nil)
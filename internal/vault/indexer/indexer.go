@@ -0,0 +1,273 @@
+// Package indexer backfills and tails V4AgenticVault events into Postgres.
+//
+// Each vault is seeded once by reading its config and positions at a confirmed block;
+// from then on events after that block are stored and applied to the indexed state.
+// Indexing never goes past head minus Confirmations, so reorged logs are never stored.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/logscan"
+	"remora/internal/vault"
+)
+
+// maxAddressesPerQuery caps the vaults filtered by one eth_getLogs call.
+const maxAddressesPerQuery = 100
+
+// defaults apply to zero scan settings. Vault activity drives rebalances, so it is polled often.
+var defaults = logscan.Config{Confirmations: 12, BlockRange: 2000, Interval: 30 * time.Second} //nolint:gochecknoglobals // fixed defaults
+
+// ChainClient is the subset of an Ethereum client needed to index events with their block times.
+type ChainClient interface {
+	logscan.Client
+	logscan.HeaderSource
+}
+
+// VaultLister returns the vaults to index.
+type VaultLister interface {
+	GetVaultAddresses(ctx context.Context) ([]common.Address, error)
+}
+
+// ConfigReader reads a vault's config and positions at a block, used to seed new vaults.
+type ConfigReader interface {
+	ReadConfig(ctx context.Context, addr common.Address, block uint64) (vault.Config, []vault.IndexedPosition, error)
+}
+
+// ErrNoStartBlock is returned by New when Config.StartBlock is not set.
+var ErrNoStartBlock = errors.New("vault indexer start block not set")
+
+// Config configures the vault indexer. StartBlock, the factory deploy block, is where the event
+// history of a newly seeded vault is backfilled from. It is required: seeding scans from it
+// inside Sync, so a zero start block would read the whole chain for every new vault.
+type Config = logscan.Config
+
+// Indexer indexes vault events.
+type Indexer struct {
+	scanner *logscan.Scanner
+	reader  ConfigReader
+	lister  VaultLister
+	repo    vault.EventRepository
+	topics  []common.Hash
+	logger  *slog.Logger
+}
+
+// New creates an indexer.
+func New(client ChainClient, reader ConfigReader, lister VaultLister, repo vault.EventRepository, cfg Config, logger *slog.Logger) (*Indexer, error) {
+	if cfg.StartBlock == 0 {
+		return nil, ErrNoStartBlock
+	}
+
+	topics, err := vault.EventTopics()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Indexer{
+		scanner: logscan.New(client, client, cfg, defaults, logger),
+		reader:  reader,
+		lister:  lister,
+		repo:    repo,
+		topics:  topics,
+		logger:  logger,
+	}, nil
+}
+
+// Run keeps the vault index current until ctx is cancelled.
+func (ix *Indexer) Run(ctx context.Context) {
+	ix.scanner.Run(ctx, "vault indexer", ix.Sync)
+}
+
+// Sync seeds new vaults and indexes all vaults up to the latest confirmed block.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	safe, ok, err := ix.scanner.SafeBlock(ctx)
+	if err != nil || !ok {
+		return err //nolint:wrapcheck // already wrapped by the scanner
+	}
+
+	vaults, err := ix.lister.GetVaultAddresses(ctx)
+	if err != nil {
+		return fmt.Errorf("list vaults: %w", err)
+	}
+
+	indexed, err := ix.repo.ListIndexedBlocks(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Vaults that share a cursor are scanned together; in steady state that is all of them.
+	groups := make(map[uint64][]common.Address)
+
+	for _, v := range vaults {
+		block, ok := indexed[v]
+		if !ok {
+			if err := ix.seed(ctx, v, safe); err != nil {
+				ix.logger.WarnContext(ctx, "seed vault failed", slog.String("vault", v.Hex()), slog.Any("error", err))
+			}
+
+			continue
+		}
+
+		if block < safe {
+			groups[block] = append(groups[block], v)
+		}
+	}
+
+	for block, addrs := range groups {
+		for start := 0; start < len(addrs); start += maxAddressesPerQuery {
+			batch := addrs[start:min(start+maxAddressesPerQuery, len(addrs))]
+
+			if err := ix.index(ctx, batch, block+1, safe); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// seed stores a vault's config and positions as of block, together with its event history up to block.
+// History events are stored only; they are not applied since the read config already reflects them.
+func (ix *Indexer) seed(ctx context.Context, addr common.Address, block uint64) error {
+	cfg, positions, err := ix.reader.ReadConfig(ctx, addr, block)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	var events []vault.Event
+
+	err = ix.scan(ctx, []common.Address{addr}, ix.scanner.Config().StartBlock, block, func(_ uint64, chunk []vault.Event) error {
+		events = append(events, chunk...)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	changes := make([]vault.PositionChange, 0, len(positions))
+	for _, p := range positions {
+		changes = append(changes, vault.PositionChange{Position: p})
+	}
+
+	if err := ix.repo.SaveBatch(ctx, vault.IndexBatch{Config: cfg, Events: events, PositionChanges: changes}); err != nil {
+		return err
+	}
+
+	ix.logger.InfoContext(ctx, "vault seeded",
+		slog.String("vault", addr.Hex()),
+		slog.Uint64("block", block),
+		slog.Int("events", len(events)),
+		slog.Int("positions", len(positions)),
+	)
+
+	return nil
+}
+
+// index scans [from, to] for addrs, applying events to each vault's indexed state chunk by chunk.
+func (ix *Indexer) index(ctx context.Context, addrs []common.Address, from, to uint64) error {
+	return ix.scan(ctx, addrs, from, to, func(chunkTo uint64, events []vault.Event) error {
+		byVault := make(map[common.Address][]vault.Event)
+		for _, e := range events {
+			byVault[e.Vault] = append(byVault[e.Vault], e)
+		}
+
+		quiet := make([]common.Address, 0, len(addrs))
+
+		for _, addr := range addrs {
+			vaultEvents, ok := byVault[addr]
+			if !ok {
+				quiet = append(quiet, addr)
+				continue
+			}
+
+			if err := ix.apply(ctx, addr, vaultEvents, chunkTo); err != nil {
+				return fmt.Errorf("apply events for %s: %w", addr.Hex(), err)
+			}
+		}
+
+		if len(quiet) > 0 {
+			if err := ix.repo.AdvanceIndexedBlock(ctx, quiet, chunkTo); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (ix *Indexer) apply(ctx context.Context, addr common.Address, events []vault.Event, block uint64) error {
+	cfg, err := ix.repo.GetConfig(ctx, addr)
+	if err != nil {
+		return err
+	}
+
+	changes := make([]vault.PositionChange, 0)
+
+	for _, e := range events {
+		change, err := cfg.Apply(e)
+		if err != nil {
+			return err
+		}
+
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+
+	cfg.IndexedBlock = block
+
+	return ix.repo.SaveBatch(ctx, vault.IndexBatch{Config: cfg, Events: events, PositionChanges: changes})
+}
+
+// scan passes the events of addrs in [from, to] to fn chunk by chunk. Unknown events are skipped.
+func (ix *Indexer) scan(ctx context.Context, addrs []common.Address, from, to uint64, fn func(to uint64, events []vault.Event) error) error {
+	q := ethereum.FilterQuery{Addresses: addrs, Topics: [][]common.Hash{ix.topics}}
+
+	return ix.scanner.Scan(ctx, q, from, to, func(chunkTo uint64, logs []logscan.Log) error { //nolint:wrapcheck // scanner errors are wrapped
+		events := make([]vault.Event, 0, len(logs))
+
+		for _, lg := range logs {
+			e, err := vault.ParseEvent(lg.Log)
+			if errors.Is(err, vault.ErrUnknownEvent) {
+				continue
+			}
+
+			if err != nil {
+				return err
+			}
+
+			e.BlockTime = lg.BlockTime
+			events = append(events, e)
+		}
+
+		return fn(chunkTo, events)
+	})
+}
+
+// chainConfigReader reads vault configs through the vault contract binding.
+type chainConfigReader struct {
+	backend bind.ContractBackend
+}
+
+// NewChainConfigReader creates a ConfigReader that calls the vault contracts.
+func NewChainConfigReader(backend bind.ContractBackend) ConfigReader { //nolint:ireturn // returns ConfigReader by design
+	return &chainConfigReader{backend: backend}
+}
+
+func (r *chainConfigReader) ReadConfig(ctx context.Context, addr common.Address, block uint64) (vault.Config, []vault.IndexedPosition, error) {
+	client, err := vault.NewClient(addr, r.backend, nil)
+	if err != nil {
+		return vault.Config{}, nil, fmt.Errorf("new vault client: %w", err)
+	}
+
+	return client.ReadConfig(ctx, block)
+}
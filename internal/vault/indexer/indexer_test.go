@@ -0,0 +1,253 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/vault"
+)
+
+type fakeChain struct {
+	head uint64
+	logs []types.Log
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	addrs := make(map[common.Address]bool, len(q.Addresses))
+	for _, a := range q.Addresses {
+		addrs[a] = true
+	}
+
+	var out []types.Log
+
+	for _, lg := range f.logs {
+		if addrs[lg.Address] && lg.BlockNumber >= q.FromBlock.Uint64() && lg.BlockNumber <= q.ToBlock.Uint64() {
+			out = append(out, lg)
+		}
+	}
+
+	return out, nil
+}
+
+func (f *fakeChain) HeaderByNumber(_ context.Context, n *big.Int) (*types.Header, error) {
+	return &types.Header{Number: n, Time: n.Uint64() * 12}, nil
+}
+
+type fakeReader struct {
+	reads int
+}
+
+func (r *fakeReader) ReadConfig(_ context.Context, addr common.Address, block uint64) (vault.Config, []vault.IndexedPosition, error) {
+	r.reads++
+
+	return vault.Config{Vault: addr, MaxPositionsK: big.NewInt(3), IndexedBlock: block},
+		[]vault.IndexedPosition{{TokenID: big.NewInt(1), TickLower: -60, TickUpper: 60, BlockNumber: block}}, nil
+}
+
+type staticLister []common.Address
+
+func (l staticLister) GetVaultAddresses(context.Context) ([]common.Address, error) { return l, nil }
+
+type memRepo struct {
+	configs   map[common.Address]vault.Config
+	events    map[common.Address][]vault.Event
+	positions map[common.Address]map[string]vault.IndexedPosition
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{
+		configs:   make(map[common.Address]vault.Config),
+		events:    make(map[common.Address][]vault.Event),
+		positions: make(map[common.Address]map[string]vault.IndexedPosition),
+	}
+}
+
+func (m *memRepo) GetConfig(_ context.Context, addr common.Address) (vault.Config, error) {
+	c, ok := m.configs[addr]
+	if !ok {
+		return vault.Config{}, vault.ErrNotIndexed
+	}
+
+	return c, nil
+}
+
+func (m *memRepo) ListIndexedBlocks(context.Context) (map[common.Address]uint64, error) {
+	out := make(map[common.Address]uint64, len(m.configs))
+	for a, c := range m.configs {
+		out[a] = c.IndexedBlock
+	}
+
+	return out, nil
+}
+
+func (m *memRepo) ListPositions(_ context.Context, addr common.Address) ([]vault.IndexedPosition, error) {
+	out := make([]vault.IndexedPosition, 0, len(m.positions[addr]))
+	for _, p := range m.positions[addr] {
+		out = append(out, p)
+	}
+
+	return out, nil
+}
+
+func (m *memRepo) SaveBatch(_ context.Context, b vault.IndexBatch) error {
+	addr := b.Config.Vault
+	m.configs[addr] = b.Config
+	m.events[addr] = append(m.events[addr], b.Events...)
+
+	if m.positions[addr] == nil {
+		m.positions[addr] = make(map[string]vault.IndexedPosition)
+	}
+
+	for _, c := range b.PositionChanges {
+		if c.Removed {
+			delete(m.positions[addr], c.Position.TokenID.String())
+		} else {
+			m.positions[addr][c.Position.TokenID.String()] = c.Position
+		}
+	}
+
+	return nil
+}
+
+func (m *memRepo) ListEvents(_ context.Context, f vault.EventFilter) ([]vault.Event, error) {
+	return m.events[f.Vault], nil
+}
+
+func (m *memRepo) AdvanceIndexedBlock(_ context.Context, vaults []common.Address, block uint64) error {
+	for _, v := range vaults {
+		c := m.configs[v]
+		c.IndexedBlock = block
+		m.configs[v] = c
+	}
+
+	return nil
+}
+
+// eventLog ABI-encodes a vault event log.
+func eventLog(t *testing.T, addr common.Address, block uint64, index uint, name string, topics []common.Hash, args ...any) types.Log {
+	t.Helper()
+
+	parsed, err := vault.V4AgenticVaultMetaData.GetAbi()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := parsed.Events[name]
+
+	data, err := ev.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatalf("pack %s: %v", name, err)
+	}
+
+	return types.Log{
+		Address:     addr,
+		BlockNumber: block,
+		Index:       index,
+		Topics:      append([]common.Hash{ev.ID}, topics...),
+		Data:        data,
+	}
+}
+
+func newTestIndexer(t *testing.T, chain *fakeChain, reader *fakeReader, lister VaultLister, repo *memRepo) *Indexer {
+	t.Helper()
+
+	ix, err := New(chain, reader, lister, repo, Config{StartBlock: 1, Confirmations: 10, BlockRange: 50}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return ix
+}
+
+func TestIndexer_SeedThenTail(t *testing.T) {
+	t.Parallel()
+
+	v := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+
+	chain := &fakeChain{
+		head: 110,
+		logs: []types.Log{
+			// history before the seed block: stored, not applied
+			eventLog(t, v, 20, 0, "SwapAllowed", nil, true),
+		},
+	}
+	reader := &fakeReader{}
+	repo := newMemRepo()
+	ix := newTestIndexer(t, chain, reader, staticLister{v}, repo)
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatalf("seed sync: %v", err)
+	}
+
+	if reader.reads != 1 || repo.configs[v].IndexedBlock != 100 {
+		t.Fatalf("expected vault seeded at block 100, got reads=%d config=%+v", reader.reads, repo.configs[v])
+	}
+
+	if repo.configs[v].SwapAllowed {
+		t.Error("history events must not be applied over the seeded config")
+	}
+
+	if len(repo.events[v]) != 1 {
+		t.Errorf("expected 1 history event, got %d", len(repo.events[v]))
+	}
+
+	chain.head = 250
+	chain.logs = append(chain.logs,
+		eventLog(t, v, 120, 0, "AgentPaused", nil, true),
+		eventLog(t, v, 130, 1, "PositionAdded", []common.Hash{common.BigToHash(big.NewInt(2))}, big.NewInt(-120), big.NewInt(120)),
+		eventLog(t, v, 140, 0, "PositionRemoved", []common.Hash{common.BigToHash(big.NewInt(1))}),
+		eventLog(t, v, 150, 2, "OwnershipTransferred", []common.Hash{common.BytesToHash(common.Address{}.Bytes()), common.BytesToHash(owner.Bytes())}),
+		eventLog(t, v, 160, 0, "AllowedTickRangeUpdated", nil, big.NewInt(-600), big.NewInt(600)),
+		eventLog(t, v, 245, 0, "SwapAllowed", nil, true), // not confirmed yet
+	)
+
+	if err := ix.Sync(context.Background()); err != nil {
+		t.Fatalf("tail sync: %v", err)
+	}
+
+	cfg := repo.configs[v]
+	if cfg.IndexedBlock != 240 {
+		t.Errorf("expected cursor at 240, got %d", cfg.IndexedBlock)
+	}
+
+	if !cfg.AgentPaused || cfg.Owner != owner || cfg.AllowedTickLower != -600 || cfg.AllowedTickUpper != 600 {
+		t.Errorf("events not applied: %+v", cfg)
+	}
+
+	if cfg.SwapAllowed {
+		t.Error("unconfirmed event must not be applied")
+	}
+
+	positions := repo.positions[v]
+	if len(positions) != 1 {
+		t.Fatalf("expected 1 position, got %d", len(positions))
+	}
+
+	if p, ok := positions["2"]; !ok || p.TickLower != -120 || p.TickUpper != 120 {
+		t.Errorf("unexpected positions: %+v", positions)
+	}
+
+	if reader.reads != 1 {
+		t.Errorf("indexed vault must not be re-read, got %d reads", reader.reads)
+	}
+}
+
+func TestNew_RequiresStartBlock(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(&fakeChain{}, &fakeReader{}, nil, newMemRepo(), Config{}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if !errors.Is(err, ErrNoStartBlock) {
+		t.Errorf("New() error = %v, want ErrNoStartBlock", err)
+	}
+}
@@ -0,0 +1,196 @@
+package vault
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/allocation"
+)
+
+// OwnerAction is a vault function restricted to the owner.
+type OwnerAction string
+
+const (
+	ActionSetAgent            OwnerAction = "setAgent"
+	ActionSetAgentPaused      OwnerAction = "setAgentPaused"
+	ActionSetAllowedTickRange OwnerAction = "setAllowedTickRange"
+	ActionSetMaxPositionsK    OwnerAction = "setMaxPositionsK"
+	ActionSetSwapAllowed      OwnerAction = "setSwapAllowed"
+	ActionPauseAndExitAll     OwnerAction = "pauseAndExitAll"
+	ActionWithdraw            OwnerAction = "withdraw"
+	ActionTransferOwnership   OwnerAction = "transferOwnership"
+)
+
+// OwnerTxParams are the arguments of an owner action; each action reads only its own fields.
+type OwnerTxParams struct {
+	Agent         *common.Address // setAgent
+	Paused        *bool           // setAgentPaused
+	TickLower     *int32          // setAllowedTickRange
+	TickUpper     *int32          // setAllowedTickRange
+	MaxPositionsK *big.Int        // setMaxPositionsK
+	SwapAllowed   *bool           // setSwapAllowed
+	Deadline      *big.Int        // pauseAndExitAll, unix seconds
+	Currency      *common.Address // withdraw, zero address for native ETH
+	Amount        *big.Int        // withdraw
+	To            *common.Address // withdraw
+	NewOwner      *common.Address // transferOwnership
+}
+
+// OwnerTx is an unsigned transaction for the owner's wallet to sign and send.
+type OwnerTx struct {
+	From  common.Address
+	To    common.Address
+	Data  []byte
+	Value *big.Int
+	// BlockNumber is the block of the state the transaction was validated against.
+	BlockNumber uint64
+}
+
+// BuildOwnerTx validates an owner action against snapshot and returns its calldata.
+// from must be the current owner; ErrNotOwner otherwise. Invalid params wrap ErrInvalidOwnerTx.
+func BuildOwnerTx(vault, from common.Address, snapshot *Snapshot, action OwnerAction, p OwnerTxParams, now time.Time) (*OwnerTx, error) {
+	if from != snapshot.State.Owner {
+		return nil, ErrNotOwner
+	}
+
+	args, err := ownerTxArgs(&snapshot.State, snapshot.Positions, action, p, now)
+	if err != nil {
+		return nil, err
+	}
+
+	vaultABI, err := V4AgenticVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("parse vault abi: %w", err)
+	}
+
+	data, err := vaultABI.Pack(string(action), args...)
+	if err != nil {
+		return nil, fmt.Errorf("pack %s: %w", action, err)
+	}
+
+	return &OwnerTx{
+		From:        from,
+		To:          vault,
+		Data:        data,
+		Value:       new(big.Int),
+		BlockNumber: snapshot.BlockNumber,
+	}, nil
+}
+
+//nolint:cyclop // one case per owner action
+func ownerTxArgs(state *State, positions []Position, action OwnerAction, p OwnerTxParams, now time.Time) ([]any, error) {
+	switch action {
+	case ActionSetAgent:
+		if p.Agent == nil {
+			return nil, invalidParam("agent", "required")
+		}
+
+		return []any{*p.Agent}, nil
+	case ActionSetAgentPaused:
+		if p.Paused == nil {
+			return nil, invalidParam("paused", "required")
+		}
+
+		return []any{*p.Paused}, nil
+	case ActionSetAllowedTickRange:
+		if err := validateTickRange(state, positions, p.TickLower, p.TickUpper); err != nil {
+			return nil, err
+		}
+
+		return []any{big.NewInt(int64(*p.TickLower)), big.NewInt(int64(*p.TickUpper))}, nil
+	case ActionSetMaxPositionsK:
+		switch {
+		case p.MaxPositionsK == nil:
+			return nil, invalidParam("maxPositionsK", "required")
+		case p.MaxPositionsK.Sign() <= 0:
+			return nil, invalidParam("maxPositionsK", "must be positive")
+		case p.MaxPositionsK.Cmp(big.NewInt(int64(len(positions)))) < 0:
+			return nil, invalidParam("maxPositionsK", fmt.Sprintf("below the %d open positions", len(positions)))
+		}
+
+		return []any{p.MaxPositionsK}, nil
+	case ActionSetSwapAllowed:
+		if p.SwapAllowed == nil {
+			return nil, invalidParam("swapAllowed", "required")
+		}
+
+		return []any{*p.SwapAllowed}, nil
+	case ActionPauseAndExitAll:
+		if p.Deadline == nil {
+			return nil, invalidParam("deadline", "required")
+		}
+
+		if p.Deadline.Cmp(big.NewInt(now.Unix())) <= 0 {
+			return nil, invalidParam("deadline", "must be in the future")
+		}
+
+		return []any{p.Deadline}, nil
+	case ActionWithdraw:
+		switch {
+		case p.Currency == nil:
+			return nil, invalidParam("currency", "required")
+		case p.Amount == nil || p.Amount.Sign() <= 0:
+			return nil, invalidParam("amount", "must be positive")
+		case p.To == nil || *p.To == (common.Address{}):
+			return nil, invalidParam("to", "must be a non-zero address")
+		}
+
+		return []any{*p.Currency, p.Amount, *p.To}, nil
+	case ActionTransferOwnership:
+		switch {
+		case p.NewOwner == nil || *p.NewOwner == (common.Address{}):
+			return nil, invalidParam("newOwner", "must be a non-zero address")
+		case *p.NewOwner == state.Owner:
+			return nil, invalidParam("newOwner", "already the owner")
+		}
+
+		return []any{*p.NewOwner}, nil
+	default:
+		return nil, fmt.Errorf("%w: unknown action %q", ErrInvalidOwnerTx, action)
+	}
+}
+
+// validateTickRange checks the range is ordered, within tick bounds, aligned to the pool's
+// tickSpacing and contains every open position, so the agent is not left managing positions out of range.
+func validateTickRange(state *State, positions []Position, lower, upper *int32) error {
+	if lower == nil || upper == nil {
+		return invalidParam("tickLower/tickUpper", "required")
+	}
+
+	if *lower >= *upper {
+		return invalidParam("tickLower", "must be below tickUpper")
+	}
+
+	if *lower < allocation.MinTick || *upper > allocation.MaxTick {
+		return invalidParam("tickLower/tickUpper", fmt.Sprintf("must be within [%d, %d]", allocation.MinTick, allocation.MaxTick))
+	}
+
+	if state.PoolKey.TickSpacing == nil || state.PoolKey.TickSpacing.Sign() <= 0 {
+		return fmt.Errorf("%w: pool tick spacing unknown", ErrInvalidOwnerTx)
+	}
+
+	spacing := int32(state.PoolKey.TickSpacing.Int64()) //nolint:gosec // tickSpacing is int24, fits in int32
+
+	if *lower%spacing != 0 {
+		return invalidParam("tickLower", fmt.Sprintf("not aligned to tickSpacing %d", spacing))
+	}
+
+	if *upper%spacing != 0 {
+		return invalidParam("tickUpper", fmt.Sprintf("not aligned to tickSpacing %d", spacing))
+	}
+
+	for _, pos := range positions {
+		if pos.TickLower < *lower || pos.TickUpper > *upper {
+			return invalidParam("tickLower/tickUpper", fmt.Sprintf("position %s [%d, %d] falls outside the range", pos.TokenID, pos.TickLower, pos.TickUpper))
+		}
+	}
+
+	return nil
+}
+
+func invalidParam(param, reason string) error {
+	return fmt.Errorf("%w: %s %s", ErrInvalidOwnerTx, param, reason)
+}
@@ -0,0 +1,142 @@
+package vault
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func int32p(v int32) *int32 { return &v }
+
+func TestBuildOwnerTx(t *testing.T) {
+	t.Parallel()
+
+	vaultAddr := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	owner := common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	other := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	now := time.Unix(1_700_000_000, 0)
+
+	snapshot := &Snapshot{
+		BlockNumber: 42,
+		State: State{
+			Owner:   owner,
+			PoolKey: PoolKey{TickSpacing: big.NewInt(60)},
+		},
+		Positions: []Position{
+			{TokenID: big.NewInt(1), TickLower: -120, TickUpper: 60},
+			{TokenID: big.NewInt(2), TickLower: 0, TickUpper: 180},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		from    common.Address
+		action  OwnerAction
+		params  OwnerTxParams
+		wantErr error
+	}{
+		{
+			name:   "tick range aligned and containing positions",
+			from:   owner,
+			action: ActionSetAllowedTickRange,
+			params: OwnerTxParams{TickLower: int32p(-600), TickUpper: int32p(600)},
+		},
+		{
+			name:    "tick range not aligned",
+			from:    owner,
+			action:  ActionSetAllowedTickRange,
+			params:  OwnerTxParams{TickLower: int32p(-610), TickUpper: int32p(600)},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:    "tick range excludes a position",
+			from:    owner,
+			action:  ActionSetAllowedTickRange,
+			params:  OwnerTxParams{TickLower: int32p(-60), TickUpper: int32p(600)},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:    "tick range inverted",
+			from:    owner,
+			action:  ActionSetAllowedTickRange,
+			params:  OwnerTxParams{TickLower: int32p(600), TickUpper: int32p(-600)},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:    "max positions below open positions",
+			from:    owner,
+			action:  ActionSetMaxPositionsK,
+			params:  OwnerTxParams{MaxPositionsK: big.NewInt(1)},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:    "deadline in the past",
+			from:    owner,
+			action:  ActionPauseAndExitAll,
+			params:  OwnerTxParams{Deadline: big.NewInt(now.Unix() - 1)},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:   "withdraw",
+			from:   owner,
+			action: ActionWithdraw,
+			params: OwnerTxParams{Currency: &common.Address{}, Amount: big.NewInt(1), To: &owner},
+		},
+		{
+			name:    "transfer to current owner",
+			from:    owner,
+			action:  ActionTransferOwnership,
+			params:  OwnerTxParams{NewOwner: &owner},
+			wantErr: ErrInvalidOwnerTx,
+		},
+		{
+			name:    "caller is not the owner",
+			from:    other,
+			action:  ActionSetAgent,
+			params:  OwnerTxParams{Agent: &other},
+			wantErr: ErrNotOwner,
+		},
+		{
+			name:    "unknown action",
+			from:    owner,
+			action:  "renounceOwnership",
+			wantErr: ErrInvalidOwnerTx,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx, err := BuildOwnerTx(vaultAddr, tt.from, snapshot, tt.action, tt.params, now)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("BuildOwnerTx() error = %v, want %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("BuildOwnerTx() failed: %v", err)
+			}
+
+			if tx.From != owner || tx.To != vaultAddr || tx.BlockNumber != 42 || tx.Value.Sign() != 0 {
+				t.Errorf("unexpected tx envelope: %+v", tx)
+			}
+
+			parsed, err := V4AgenticVaultMetaData.GetAbi()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(tx.Data[:4], parsed.Methods[string(tt.action)].ID) {
+				t.Errorf("calldata selector %x does not match %s", tx.Data[:4], tt.action)
+			}
+		})
+	}
+}
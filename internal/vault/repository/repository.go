@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"remora/internal/db"
+	"remora/internal/vault"
+)
+
+// Repository stores indexed vault events, configs and positions in Postgres.
+type Repository struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+func New(pool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pool, q: db.New(pool)}
+}
+
+func (r *Repository) GetConfig(ctx context.Context, addr common.Address) (vault.Config, error) {
+	c, err := r.q.GetVaultConfig(ctx, addr.Hex())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return vault.Config{}, vault.ErrNotIndexed
+		}
+
+		return vault.Config{}, fmt.Errorf("get vault config: %w", err)
+	}
+
+	return toDomainConfig(c), nil
+}
+
+func (r *Repository) ListConfigsByOwner(ctx context.Context, owner common.Address) ([]vault.Config, error) {
+	rows, err := r.q.ListVaultConfigsByOwner(ctx, owner.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("list vault configs by owner: %w", err)
+	}
+
+	configs := make([]vault.Config, len(rows))
+	for i, row := range rows {
+		configs[i] = toDomainConfig(row)
+	}
+
+	return configs, nil
+}
+
+func (r *Repository) ListIndexedBlocks(ctx context.Context) (map[common.Address]uint64, error) {
+	rows, err := r.q.ListVaultIndexedBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list vault indexed blocks: %w", err)
+	}
+
+	blocks := make(map[common.Address]uint64, len(rows))
+	for _, row := range rows {
+		blocks[common.HexToAddress(row.VaultAddress)] = uint64(row.IndexedBlock) //nolint:gosec // block numbers are non-negative
+	}
+
+	return blocks, nil
+}
+
+func (r *Repository) ListPositions(ctx context.Context, addr common.Address) ([]vault.IndexedPosition, error) {
+	rows, err := r.q.ListVaultPositions(ctx, addr.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("list vault positions: %w", err)
+	}
+
+	positions := make([]vault.IndexedPosition, 0, len(rows))
+	for _, row := range rows {
+		positions = append(positions, vault.IndexedPosition{
+			TokenID:     row.TokenID.BigInt(),
+			TickLower:   int32(row.TickLower),    //nolint:gosec // tick is int24, fits in int32
+			TickUpper:   int32(row.TickUpper),    //nolint:gosec // tick is int24, fits in int32
+			BlockNumber: uint64(row.BlockNumber), //nolint:gosec // block numbers are non-negative
+		})
+	}
+
+	return positions, nil
+}
+
+func (r *Repository) ListEvents(ctx context.Context, filter vault.EventFilter) ([]vault.Event, error) {
+	types := make([]string, 0, len(filter.Types))
+	for _, t := range filter.Types {
+		types = append(types, string(t))
+	}
+
+	toBlock := int64(math.MaxInt64)
+	if filter.ToBlock > 0 {
+		toBlock = int64(filter.ToBlock) //nolint:gosec // block numbers fit in int64
+	}
+
+	beforeBlock, beforeLogIndex := int64(math.MaxInt64), math.MaxInt32
+	if filter.Before != nil {
+		beforeBlock = int64(filter.Before.BlockNumber) //nolint:gosec // block numbers fit in int64
+		beforeLogIndex = int(filter.Before.LogIndex)   //nolint:gosec // log index fits in int
+	}
+
+	rows, err := r.q.ListVaultEvents(ctx, db.ListVaultEventsParams{
+		VaultAddress:   filter.Vault.Hex(),
+		EventTypes:     types,
+		FromBlock:      int64(filter.FromBlock), //nolint:gosec // block numbers fit in int64
+		ToBlock:        toBlock,
+		BeforeBlock:    beforeBlock,
+		BeforeLogIndex: beforeLogIndex,
+		RowLimit:       int32(filter.Limit), //nolint:gosec // limit is validated by the caller
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list vault events: %w", err)
+	}
+
+	events := make([]vault.Event, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, toDomainEvent(row))
+	}
+
+	return events, nil
+}
+
+func (r *Repository) SaveBatch(ctx context.Context, batch vault.IndexBatch) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	q := r.q.WithTx(tx)
+	now := time.Now()
+	addr := batch.Config.Vault.Hex()
+
+	for _, e := range batch.Events {
+		if err := q.InsertVaultEvent(ctx, db.InsertVaultEventParams{
+			VaultAddress: e.Vault.Hex(),
+			BlockNumber:  int64(e.BlockNumber), //nolint:gosec // block numbers fit in int64
+			LogIndex:     int(e.LogIndex),      //nolint:gosec // log index fits in int
+			BlockHash:    e.BlockHash.Hex(),
+			TxHash:       e.TxHash.Hex(),
+			EventType:    string(e.Type),
+			Data:         e.Data,
+			BlockTime:    e.BlockTime,
+			CreatedAt:    now,
+		}); err != nil {
+			return fmt.Errorf("insert vault event: %w", err)
+		}
+	}
+
+	for _, c := range batch.PositionChanges {
+		tokenID := decimal.NewFromBigInt(c.Position.TokenID, 0)
+
+		if c.Removed {
+			if err := q.DeleteVaultPosition(ctx, db.DeleteVaultPositionParams{
+				VaultAddress: addr,
+				TokenID:      tokenID,
+			}); err != nil {
+				return fmt.Errorf("delete vault position: %w", err)
+			}
+
+			continue
+		}
+
+		if err := q.UpsertVaultPosition(ctx, db.UpsertVaultPositionParams{
+			VaultAddress: addr,
+			TokenID:      tokenID,
+			TickLower:    int(c.Position.TickLower),
+			TickUpper:    int(c.Position.TickUpper),
+			BlockNumber:  int64(c.Position.BlockNumber), //nolint:gosec // block numbers fit in int64
+		}); err != nil {
+			return fmt.Errorf("upsert vault position: %w", err)
+		}
+	}
+
+	cfg := batch.Config
+
+	maxPositionsK := decimal.Zero
+	if cfg.MaxPositionsK != nil {
+		maxPositionsK = decimal.NewFromBigInt(cfg.MaxPositionsK, 0)
+	}
+
+	if err := q.UpsertVaultConfig(ctx, db.UpsertVaultConfigParams{
+		VaultAddress:     addr,
+		Owner:            cfg.Owner.Hex(),
+		Agent:            cfg.Agent.Hex(),
+		AgentPaused:      cfg.AgentPaused,
+		SwapAllowed:      cfg.SwapAllowed,
+		AllowedTickLower: int(cfg.AllowedTickLower),
+		AllowedTickUpper: int(cfg.AllowedTickUpper),
+		MaxPositionsK:    maxPositionsK,
+		IndexedBlock:     int64(cfg.IndexedBlock), //nolint:gosec // block numbers fit in int64
+		UpdatedAt:        now,
+	}); err != nil {
+		return fmt.Errorf("upsert vault config: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) AdvanceIndexedBlock(ctx context.Context, vaults []common.Address, block uint64) error {
+	addrs := make([]string, 0, len(vaults))
+	for _, v := range vaults {
+		addrs = append(addrs, v.Hex())
+	}
+
+	if err := r.q.AdvanceVaultIndexedBlock(ctx, db.AdvanceVaultIndexedBlockParams{
+		IndexedBlock:   int64(block), //nolint:gosec // block numbers fit in int64
+		UpdatedAt:      time.Now(),
+		VaultAddresses: addrs,
+	}); err != nil {
+		return fmt.Errorf("advance vault indexed block: %w", err)
+	}
+
+	return nil
+}
+
+func toDomainConfig(c db.VaultConfig) vault.Config {
+	return vault.Config{
+		Vault:            common.HexToAddress(c.VaultAddress),
+		Owner:            common.HexToAddress(c.Owner),
+		Agent:            common.HexToAddress(c.Agent),
+		AgentPaused:      c.AgentPaused,
+		SwapAllowed:      c.SwapAllowed,
+		AllowedTickLower: int32(c.AllowedTickLower), //nolint:gosec // tick is int24, fits in int32
+		AllowedTickUpper: int32(c.AllowedTickUpper), //nolint:gosec // tick is int24, fits in int32
+		MaxPositionsK:    c.MaxPositionsK.BigInt(),
+		IndexedBlock:     uint64(c.IndexedBlock), //nolint:gosec // block numbers are non-negative
+	}
+}
+
+func toDomainEvent(e db.VaultEvent) vault.Event {
+	return vault.Event{
+		Vault:       common.HexToAddress(e.VaultAddress),
+		Type:        vault.EventType(e.EventType),
+		BlockNumber: uint64(e.BlockNumber), //nolint:gosec // block numbers are non-negative
+		BlockHash:   common.HexToHash(e.BlockHash),
+		TxHash:      common.HexToHash(e.TxHash),
+		LogIndex:    uint(e.LogIndex), //nolint:gosec // log index is non-negative
+		BlockTime:   e.BlockTime,
+		Data:        e.Data,
+	}
+}
+
+// Ensure Repository implements vault.EventRepository and vault.OwnerIndex.
+var (
+	_ vault.EventRepository = (*Repository)(nil)
+	_ vault.OwnerIndex      = (*Repository)(nil)
+)
@@ -0,0 +1,254 @@
+package vault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/multicall"
+)
+
+// speculativePositionIDs is how many positionIds(i) calls are sent with the state batch,
+// before positionsLength is known. Vaults with more positions need one extra round trip.
+const speculativePositionIDs = 8
+
+// Snapshot is a vault's state and positions read at a single block.
+type Snapshot struct {
+	BlockNumber uint64
+	State       State
+	Positions   []Position
+}
+
+// state batch layout: getBlockNumber, then the vault getters below, then speculative positionIds.
+var stateMethods = []string{ //nolint:gochecknoglobals // fixed call layout
+	"owner",
+	"agent",
+	"agentPaused",
+	"swapAllowed",
+	"allowedTickLower",
+	"allowedTickUpper",
+	"maxPositionsK",
+	"getPoolKey",
+	"poolId",
+	"posm",
+	"positionsLength",
+}
+
+// GetSnapshot reads state and positions through Multicall3, all pinned to the same block.
+// blockNumber nil reads the latest block; the block actually used is returned in Snapshot.BlockNumber.
+//
+// Position ticks and liquidity are keyed by token ID, which Multicall3 cannot feed from one call
+// into the next, so this is not a single round trip. It costs two eth_calls: state with the first
+// speculativePositionIDs position IDs, then position ticks and liquidity. Vaults with more
+// positions need a third for the remaining IDs. Pinning every call to the first call's block
+// keeps the snapshot consistent.
+func (c *Client) GetSnapshot(ctx context.Context, blockNumber *big.Int) (*Snapshot, error) {
+	vaultABI, err := V4AgenticVaultMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("parse vault abi: %w", err)
+	}
+
+	state, block, tokenIDs, err := c.readState(ctx, vaultABI, blockNumber, speculativePositionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	pinned := new(big.Int).SetUint64(block)
+
+	if n := state.PositionsLength.Int64(); int64(len(tokenIDs)) < n {
+		rest, err := c.readPositionIDs(ctx, vaultABI, pinned, int64(len(tokenIDs)), n)
+		if err != nil {
+			return nil, err
+		}
+
+		tokenIDs = append(tokenIDs, rest...)
+	}
+
+	positions, err := c.readPositions(ctx, vaultABI, pinned, state.Posm, tokenIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Snapshot{BlockNumber: block, State: *state, Positions: positions}, nil
+}
+
+// readState reads the vault getters and up to prefetch position ids in one multicall.
+func (c *Client) readState(ctx context.Context, vaultABI *abi.ABI, blockNumber *big.Int, prefetch int) (*State, uint64, []*big.Int, error) {
+	calls := make([]multicall.Call, 0, 1+len(stateMethods)+prefetch)
+	calls = append(calls, multicall.BlockNumberCall())
+
+	for _, m := range stateMethods {
+		call, err := multicall.NewCall(vaultABI, c.address, m)
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		calls = append(calls, call)
+	}
+
+	for i := range prefetch {
+		call, err := multicall.NewCall(vaultABI, c.address, "positionIds", big.NewInt(int64(i)))
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		// Out-of-range indexes revert; they are expected to fail.
+		call.AllowFailure = true
+		calls = append(calls, call)
+	}
+
+	results, err := multicall.Aggregate3(ctx, c.caller, calls, blockNumber)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	block, err := multicall.DecodeBlockNumber(results[0])
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var (
+		s         State
+		tickLower *big.Int
+		tickUpper *big.Int
+		poolKey   any
+	)
+
+	r := results[1:]
+	outs := [][]any{
+		{&s.Owner},
+		{&s.Agent},
+		{&s.AgentPaused},
+		{&s.SwapAllowed},
+		{&tickLower},
+		{&tickUpper},
+		{&s.MaxPositionsK},
+		{&poolKey},
+		{&s.PoolID},
+		{&s.Posm},
+		{&s.PositionsLength},
+	}
+
+	for i, m := range stateMethods {
+		if err := multicall.Decode(vaultABI, m, r[i], outs[i]...); err != nil {
+			return nil, 0, nil, err
+		}
+	}
+
+	s.PoolKey = *abi.ConvertType(poolKey, new(PoolKey)).(*PoolKey) //nolint:forcetypeassert // ConvertType returns the given type
+	s.AllowedTickLower = int32(tickLower.Int64())                  //nolint:gosec // tick is int24, fits in int32
+	s.AllowedTickUpper = int32(tickUpper.Int64())                  //nolint:gosec // tick is int24, fits in int32
+
+	n := s.PositionsLength.Int64()
+	tokenIDs := make([]*big.Int, 0, min(int64(prefetch), n))
+
+	for i, res := range r[len(stateMethods):] {
+		if int64(i) >= n {
+			break
+		}
+
+		var id *big.Int
+		if err := multicall.Decode(vaultABI, "positionIds", res, &id); err != nil {
+			return nil, 0, nil, err
+		}
+
+		tokenIDs = append(tokenIDs, id)
+	}
+
+	return &s, block.Uint64(), tokenIDs, nil
+}
+
+// readPositionIDs reads positionIds(i) for i in [from, to).
+func (c *Client) readPositionIDs(ctx context.Context, vaultABI *abi.ABI, blockNumber *big.Int, from, to int64) ([]*big.Int, error) {
+	calls := make([]multicall.Call, 0, to-from)
+
+	for i := from; i < to; i++ {
+		call, err := multicall.NewCall(vaultABI, c.address, "positionIds", big.NewInt(i))
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, call)
+	}
+
+	results, err := multicall.Aggregate3(ctx, c.caller, calls, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]*big.Int, len(results))
+	for i, res := range results {
+		if err := multicall.Decode(vaultABI, "positionIds", res, &ids[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
+
+// readPositions reads tick range and POSM liquidity of each position in one multicall.
+func (c *Client) readPositions(ctx context.Context, vaultABI *abi.ABI, blockNumber *big.Int, posm common.Address, tokenIDs []*big.Int) ([]Position, error) {
+	if len(tokenIDs) == 0 {
+		return []Position{}, nil
+	}
+
+	const callsPerPosition = 3
+
+	calls := make([]multicall.Call, 0, callsPerPosition*len(tokenIDs))
+
+	for _, id := range tokenIDs {
+		lower, err := multicall.NewCall(vaultABI, c.address, "positionTickLower", id)
+		if err != nil {
+			return nil, err
+		}
+
+		upper, err := multicall.NewCall(vaultABI, c.address, "positionTickUpper", id)
+		if err != nil {
+			return nil, err
+		}
+
+		liq, err := multicall.NewCall(&posmABI, posm, "getPositionLiquidity", id)
+		if err != nil {
+			return nil, err
+		}
+
+		calls = append(calls, lower, upper, liq)
+	}
+
+	results, err := multicall.Aggregate3(ctx, c.caller, calls, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]Position, len(tokenIDs))
+
+	for i, id := range tokenIDs {
+		r := results[i*callsPerPosition : (i+1)*callsPerPosition]
+
+		var lower, upper, liq *big.Int
+
+		if err := multicall.Decode(vaultABI, "positionTickLower", r[0], &lower); err != nil {
+			return nil, err
+		}
+
+		if err := multicall.Decode(vaultABI, "positionTickUpper", r[1], &upper); err != nil {
+			return nil, err
+		}
+
+		if err := multicall.Decode(&posmABI, "getPositionLiquidity", r[2], &liq); err != nil {
+			return nil, err
+		}
+
+		positions[i] = Position{
+			TokenID:   id,
+			TickLower: int32(lower.Int64()), //nolint:gosec // Uniswap tick is int24, fits in int32
+			TickUpper: int32(upper.Int64()), //nolint:gosec // Uniswap tick is int24, fits in int32
+			Liquidity: liq,
+		}
+	}
+
+	return positions, nil
+}
@@ -21,6 +21,7 @@ type Position struct {
 
 // State represents the current state of a vault.
 type State struct {
+	Owner            common.Address
 	Agent            common.Address
 	AgentPaused      bool
 	SwapAllowed      bool
@@ -44,6 +45,9 @@ type Vault interface {
 	// GetPositions returns all managed positions.
 	GetPositions(ctx context.Context) ([]Position, error)
 
+	// GetSnapshot returns state and positions read at one block (nil = latest).
+	GetSnapshot(ctx context.Context, blockNumber *big.Int) (*Snapshot, error)
+
 	// Agent operations
 	MintPosition(ctx context.Context, tickLower, tickUpper int32, liquidity *big.Int, amount0Max, amount1Max *big.Int, deadline *big.Int) (*types.Transaction, error)
 	IncreaseLiquidity(ctx context.Context, tokenID, liquidity *big.Int, amount0Max, amount1Max *big.Int, deadline *big.Int) (*types.Transaction, error)
@@ -57,7 +61,7 @@ type Vault interface {
 type Client struct {
 	address  common.Address
 	contract *V4AgenticVault
-	caller   bind.ContractCaller // for POSM getPositionLiquidity calls
+	caller   bind.ContractCaller // for Multicall3 reads
 	auth     *bind.TransactOpts
 }
 
@@ -81,130 +85,29 @@ func (c *Client) Address() common.Address {
 	return c.address
 }
 
-// GetState returns the current vault state.
+// GetState returns the current vault state, read in a single multicall.
 func (c *Client) GetState(ctx context.Context) (*State, error) {
-	opts := &bind.CallOpts{Context: ctx}
-
-	agent, err := c.contract.Agent(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	agentPaused, err := c.contract.AgentPaused(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	swapAllowed, err := c.contract.SwapAllowed(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	allowedTickLower, err := c.contract.AllowedTickLower(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	allowedTickUpper, err := c.contract.AllowedTickUpper(opts)
+	vaultABI, err := V4AgenticVaultMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
 
-	maxPositionsK, err := c.contract.MaxPositionsK(opts)
+	state, _, _, err := c.readState(ctx, vaultABI, nil, 0)
 	if err != nil {
 		return nil, err
 	}
 
-	poolKey, err := c.contract.GetPoolKey(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	poolID, err := c.contract.PoolId(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	posm, err := c.contract.Posm(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	positionsLength, err := c.contract.PositionsLength(opts)
-	if err != nil {
-		return nil, err
-	}
-
-	return &State{
-		Agent:            agent,
-		AgentPaused:      agentPaused,
-		SwapAllowed:      swapAllowed,
-		AllowedTickLower: int32(allowedTickLower.Int64()), //nolint:gosec // tick is int24, fits in int32
-		AllowedTickUpper: int32(allowedTickUpper.Int64()), //nolint:gosec // tick is int24, fits in int32
-		MaxPositionsK:    maxPositionsK,
-		PoolKey:          poolKey,
-		PoolID:           poolID,
-		Posm:             posm,
-		PositionsLength:  positionsLength,
-	}, nil
+	return state, nil
 }
 
-// GetPositions returns all managed positions.
+// GetPositions returns all managed positions, read at a single block. See GetSnapshot for the cost.
 func (c *Client) GetPositions(ctx context.Context) ([]Position, error) {
-	opts := &bind.CallOpts{Context: ctx}
-
-	length, err := c.contract.PositionsLength(opts)
+	snapshot, err := c.GetSnapshot(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
 
-	n := length.Int64()
-	positions := make([]Position, 0, n)
-
-	var posmAddr common.Address
-
-	if c.caller != nil {
-		state, err := c.GetState(ctx)
-		if err != nil {
-			return nil, err
-		}
-
-		posmAddr = state.Posm
-	}
-
-	for i := range n {
-		tokenID, err := c.contract.PositionIds(opts, big.NewInt(i))
-		if err != nil {
-			return nil, err
-		}
-
-		tickLower, err := c.contract.PositionTickLower(opts, tokenID)
-		if err != nil {
-			return nil, err
-		}
-
-		tickUpper, err := c.contract.PositionTickUpper(opts, tokenID)
-		if err != nil {
-			return nil, err
-		}
-
-		liquidity := big.NewInt(0)
-		if c.caller != nil {
-			liquidity, err = getPositionLiquidity(ctx, c.caller, posmAddr, tokenID)
-			if err != nil {
-				return nil, err
-			}
-		}
-
-		positions = append(positions, Position{
-			TokenID:   tokenID,
-			TickLower: int32(tickLower.Int64()), //nolint:gosec // Uniswap tick is int24, fits in int32
-			TickUpper: int32(tickUpper.Int64()), //nolint:gosec // Uniswap tick is int24, fits in int32
-			Liquidity: liquidity,
-		})
-	}
-
-	return positions, nil
+	return snapshot.Positions, nil
 }
 
 // MintPosition mints a new LP position.
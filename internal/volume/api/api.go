@@ -0,0 +1,224 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"remora/internal/httpwrap"
+	"remora/internal/liquidity/poolid"
+	"remora/internal/volume"
+)
+
+const (
+	defaultWindow       = 24 * time.Hour
+	defaultInterval     = time.Hour
+	defaultBinSizeTicks = 60
+)
+
+// AddRoutes registers swap volume routes on the provided router.
+func AddRoutes(r chi.Router, svc volume.Service) {
+	r.Get("/pools/{poolId}/volume", httpwrap.Handler(getVolume(svc)))
+}
+
+// StatsResponse is aggregated swap activity. Amounts are raw token units.
+type StatsResponse struct {
+	Swaps   int64  `json:"swaps"`
+	Volume0 string `json:"volume0"` // Both directions
+	Volume1 string `json:"volume1"`
+	Fees0   string `json:"fees0"` // Charged on token0 paid in
+	Fees1   string `json:"fees1"`
+}
+
+// BinResponse is the activity of swaps that ended in [tickLower, tickUpper).
+type BinResponse struct {
+	TickLower int32 `json:"tickLower"`
+	TickUpper int32 `json:"tickUpper"`
+	StatsResponse
+}
+
+// BucketResponse is the activity of swaps in [start, start+interval).
+type BucketResponse struct {
+	Start int64 `json:"start"` // Unix seconds
+	StatsResponse
+}
+
+// FeeAPRResponse is the estimated fee APR of the queried range.
+type FeeAPRResponse struct {
+	TickLower int32   `json:"tickLower"`
+	TickUpper int32   `json:"tickUpper"`
+	Swaps     int64   `json:"swaps"` // Swaps that ended in range
+	APR       float64 `json:"apr"`   // Annualized fraction, e.g. 0.12 for 12%
+}
+
+// VolumeResponse is the swap activity of a pool over a window.
+type VolumeResponse struct {
+	PoolID       string           `json:"poolId"`
+	From         int64            `json:"from"` // Unix seconds, inclusive
+	To           int64            `json:"to"`   // Unix seconds, exclusive
+	IndexedBlock uint64           `json:"indexedBlock"`
+	Total        StatsResponse    `json:"total"`
+	Bins         []BinResponse    `json:"bins"`
+	Buckets      []BucketResponse `json:"buckets"`
+	FeeAPR       *FeeAPRResponse  `json:"feeApr,omitempty"` // Set when tickLower and tickUpper are given
+}
+
+// getVolume returns a handler that reports a pool's swap volume and fees. Query parameters:
+// window (default 24h) ending at to (unix seconds, default now), interval (default 1h),
+// binSizeTicks (default 60), and optionally tickLower and tickUpper for a fee APR estimate.
+func getVolume(svc volume.Service) func(*http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+	return func(r *http.Request) (*httpwrap.Response, *httpwrap.ErrorResponse) {
+		id, err := poolid.ParsePoolID(chi.URLParam(r, "poolId"))
+		if err != nil {
+			return nil, httpwrap.NewInvalidParamErrorResponse("poolId")
+		}
+
+		q, errResp := parseQuery(r.URL.Query(), time.Now())
+		if errResp != nil {
+			return nil, errResp
+		}
+
+		q.PoolID = id
+
+		report, err := svc.Report(r.Context(), q)
+		if err != nil {
+			switch {
+			case errors.Is(err, volume.ErrNotTracked):
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusNotFound,
+					ErrorMsg:   "pool not tracked",
+					Err:        err,
+				}
+			case errors.Is(err, volume.ErrInvalidQuery):
+				return nil, &httpwrap.ErrorResponse{
+					StatusCode: http.StatusBadRequest,
+					ErrorMsg:   err.Error(),
+					Err:        err,
+				}
+			}
+
+			slog.ErrorContext(r.Context(), "get volume failed", slog.String("pool_id", id.Hex()), slog.String("error", err.Error())) //nolint:sloglint // handler error logging, logger not injected in API layer
+
+			return nil, &httpwrap.ErrorResponse{
+				StatusCode: http.StatusInternalServerError,
+				ErrorMsg:   "get volume failed",
+				Err:        err,
+			}
+		}
+
+		return &httpwrap.Response{StatusCode: http.StatusOK, Body: toVolumeResponse(report)}, nil
+	}
+}
+
+func parseQuery(values url.Values, now time.Time) (volume.Query, *httpwrap.ErrorResponse) {
+	q := volume.Query{
+		To:           now.UTC().Truncate(time.Second),
+		BinSizeTicks: defaultBinSizeTicks,
+		Interval:     defaultInterval,
+	}
+
+	if raw := values.Get("to"); raw != "" {
+		to, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || to <= 0 {
+			return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("to")
+		}
+
+		q.To = time.Unix(to, 0).UTC()
+	}
+
+	window := defaultWindow
+
+	if raw := values.Get("window"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("window")
+		}
+
+		window = d
+	}
+
+	q.From = q.To.Add(-window)
+
+	if raw := values.Get("interval"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("interval")
+		}
+
+		q.Interval = d
+	}
+
+	if raw := values.Get("binSizeTicks"); raw != "" {
+		size, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("binSizeTicks")
+		}
+
+		q.BinSizeTicks = int32(size) //nolint:gosec // parsed as 32 bits
+	}
+
+	rawLower, rawUpper := values.Get("tickLower"), values.Get("tickUpper")
+	if rawLower == "" && rawUpper == "" {
+		return q, nil
+	}
+
+	lower, err := strconv.ParseInt(rawLower, 10, 32)
+	if err != nil {
+		return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("tickLower")
+	}
+
+	upper, err := strconv.ParseInt(rawUpper, 10, 32)
+	if err != nil {
+		return volume.Query{}, httpwrap.NewInvalidParamErrorResponse("tickUpper")
+	}
+
+	q.Range = &volume.TickRange{TickLower: int32(lower), TickUpper: int32(upper)} //nolint:gosec // parsed as 32 bits
+
+	return q, nil
+}
+
+func toVolumeResponse(report *volume.Report) *VolumeResponse {
+	resp := &VolumeResponse{
+		PoolID:       report.PoolID.Hex(),
+		From:         report.From.Unix(),
+		To:           report.To.Unix(),
+		IndexedBlock: report.IndexedBlock,
+		Total:        toStatsResponse(report.Total),
+		Bins:         make([]BinResponse, len(report.Bins)),
+		Buckets:      make([]BucketResponse, len(report.Buckets)),
+	}
+
+	for i, b := range report.Bins {
+		resp.Bins[i] = BinResponse{TickLower: b.TickLower, TickUpper: b.TickUpper, StatsResponse: toStatsResponse(b.Stats)}
+	}
+
+	for i, b := range report.Buckets {
+		resp.Buckets[i] = BucketResponse{Start: b.Start.Unix(), StatsResponse: toStatsResponse(b.Stats)}
+	}
+
+	if report.FeeAPR != nil {
+		resp.FeeAPR = &FeeAPRResponse{
+			TickLower: report.FeeAPR.TickLower,
+			TickUpper: report.FeeAPR.TickUpper,
+			Swaps:     report.FeeAPR.Swaps,
+			APR:       report.FeeAPR.APR,
+		}
+	}
+
+	return resp
+}
+
+func toStatsResponse(s volume.Stats) StatsResponse {
+	return StatsResponse{
+		Swaps:   s.Swaps,
+		Volume0: s.Volume0.String(),
+		Volume1: s.Volume1.String(),
+		Fees0:   s.Fees0.String(),
+		Fees1:   s.Fees1.String(),
+	}
+}
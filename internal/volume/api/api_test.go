@@ -0,0 +1,48 @@
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		query     string
+		wantErr   bool
+		wantFrom  int64
+		wantTo    int64
+		wantRange bool
+	}{
+		{query: "", wantFrom: 1_700_000_000 - 86400, wantTo: 1_700_000_000},
+		{query: "to=1600000000&window=1h&interval=5m&binSizeTicks=10", wantFrom: 1_600_000_000 - 3600, wantTo: 1_600_000_000},
+		{query: "tickLower=-600&tickUpper=600", wantFrom: 1_700_000_000 - 86400, wantTo: 1_700_000_000, wantRange: true},
+		{query: "tickLower=-600", wantErr: true},
+		{query: "window=1d", wantErr: true},
+		{query: "to=yesterday", wantErr: true},
+		{query: "binSizeTicks=x", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		values, _ := url.ParseQuery(tt.query)
+
+		q, errResp := parseQuery(values, now)
+		if (errResp != nil) != tt.wantErr {
+			t.Errorf("%q: error = %v, wantErr %v", tt.query, errResp, tt.wantErr)
+
+			continue
+		}
+
+		if tt.wantErr {
+			continue
+		}
+
+		if q.From.Unix() != tt.wantFrom || q.To.Unix() != tt.wantTo || (q.Range != nil) != tt.wantRange {
+			t.Errorf("%q: query = %+v", tt.query, q)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package volume
+
+import (
+	"math/big"
+	"time"
+
+	"remora/internal/allocation"
+)
+
+const year = 365 * 24 * time.Hour
+
+// referenceLiquidity is the liquidity a range is valued at; large enough that the integer
+// token amounts keep full float precision.
+var referenceLiquidity = new(big.Int).Exp(big.NewInt(10), big.NewInt(18), nil)
+
+// EstimateAPR annualizes the fees one unit of liquidity over r earned in window against the
+// value of that unit at sqrtPriceX96, both in raw token1 units. It returns 0 when the range
+// holds no value.
+func EstimateAPR(fees RangeFees, r TickRange, sqrtPriceX96 *big.Int, window time.Duration) float64 {
+	if window <= 0 {
+		return 0
+	}
+
+	sqrtA := allocation.TickToSqrtPriceX96(int(r.TickLower))
+	sqrtB := allocation.TickToSqrtPriceX96(int(r.TickUpper))
+	amount0 := allocation.GetAmount0ForLiquidity(sqrtPriceX96, sqrtA, sqrtB, referenceLiquidity)
+	amount1 := allocation.GetAmount1ForLiquidity(sqrtPriceX96, sqrtA, sqrtB, referenceLiquidity)
+	price := allocation.SqrtPriceX96ToPrice(sqrtPriceX96)
+
+	value := (toFloat(amount0)*price + toFloat(amount1)) / toFloat(referenceLiquidity)
+	if value <= 0 {
+		return 0
+	}
+
+	earned := fees.Fees0PerLiquidity*price + fees.Fees1PerLiquidity
+
+	return earned / value * float64(year) / float64(window)
+}
+
+func toFloat(x *big.Int) float64 {
+	f, _ := new(big.Float).SetInt(x).Float64()
+
+	return f
+}
@@ -0,0 +1,20 @@
+package volume
+
+import "errors"
+
+var (
+	// ErrNotTracked is returned for a pool whose swaps are not recorded.
+	ErrNotTracked = errors.New("pool not tracked")
+
+	// ErrNotIndexed is returned before a pool's first batch of swaps is stored.
+	ErrNotIndexed = errors.New("pool swaps not indexed")
+
+	// ErrNoSwaps is returned when a pool has no recorded swap to price a range with.
+	ErrNoSwaps = errors.New("no swaps")
+
+	// ErrInvalidQuery is returned for a malformed window, bin size, interval or range.
+	ErrInvalidQuery = errors.New("invalid query")
+
+	// ErrInvalidEvent is returned when a log is not a well-formed Swap event.
+	ErrInvalidEvent = errors.New("invalid swap event")
+)
@@ -0,0 +1,78 @@
+package volume
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const swapABI = `[{"anonymous":false,"type":"event","name":"Swap","inputs":[
+	{"indexed":true,"name":"id","type":"bytes32"},
+	{"indexed":true,"name":"sender","type":"address"},
+	{"indexed":false,"name":"amount0","type":"int128"},
+	{"indexed":false,"name":"amount1","type":"int128"},
+	{"indexed":false,"name":"sqrtPriceX96","type":"uint160"},
+	{"indexed":false,"name":"liquidity","type":"uint128"},
+	{"indexed":false,"name":"tick","type":"int24"},
+	{"indexed":false,"name":"fee","type":"uint24"}]}]`
+
+const swapTopics = 3
+
+var swapEvent = func() abi.Event {
+	parsed, err := abi.JSON(strings.NewReader(swapABI))
+	if err != nil {
+		panic(fmt.Sprintf("parse swap abi: %v", err))
+	}
+
+	return parsed.Events["Swap"]
+}()
+
+// SwapTopic is the topic of the PoolManager Swap event.
+func SwapTopic() common.Hash {
+	return swapEvent.ID
+}
+
+// ParseSwap decodes a PoolManager Swap log. BlockTime is left for the caller to set.
+func ParseSwap(lg types.Log) (Swap, error) {
+	if len(lg.Topics) != swapTopics || lg.Topics[0] != swapEvent.ID {
+		return Swap{}, fmt.Errorf("%w: unexpected topics", ErrInvalidEvent)
+	}
+
+	var data struct {
+		Amount0      *big.Int
+		Amount1      *big.Int
+		SqrtPriceX96 *big.Int
+		Liquidity    *big.Int
+		Tick         *big.Int
+		Fee          *big.Int
+	}
+
+	args := swapEvent.Inputs.NonIndexed()
+
+	values, err := args.Unpack(lg.Data)
+	if err != nil {
+		return Swap{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	if err := args.Copy(&data, values); err != nil {
+		return Swap{}, fmt.Errorf("%w: %w", ErrInvalidEvent, err)
+	}
+
+	return Swap{
+		PoolID:       lg.Topics[1],
+		BlockNumber:  lg.BlockNumber,
+		LogIndex:     lg.Index,
+		TxHash:       lg.TxHash,
+		Sender:       common.BytesToAddress(lg.Topics[2].Bytes()),
+		Amount0:      data.Amount0,
+		Amount1:      data.Amount1,
+		SqrtPriceX96: data.SqrtPriceX96,
+		Liquidity:    data.Liquidity,
+		Tick:         int32(data.Tick.Int64()),  //nolint:gosec // int24
+		Fee:          uint32(data.Fee.Uint64()), //nolint:gosec // uint24
+	}, nil
+}
@@ -0,0 +1,139 @@
+// Package indexer records the swaps of tracked pools from PoolManager Swap events.
+//
+// Each pool keeps its own cursor, so a pool added to the tracked set is backfilled from
+// StartBlock without rescanning the others. Logs are scanned up to head minus Confirmations,
+// so reorged swaps are never stored.
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/logscan"
+	"remora/internal/volume"
+)
+
+// defaults apply to zero scan settings. Busy pools emit many swaps, so chunks are kept small.
+var defaults = logscan.Config{Confirmations: 12, BlockRange: 2000, Interval: time.Minute} //nolint:gochecknoglobals // fixed defaults
+
+// ChainClient is the subset of an Ethereum client needed to index swaps with their block times.
+type ChainClient interface {
+	logscan.Client
+	logscan.HeaderSource
+}
+
+// Config configures the swap indexer. StartBlock applies to pools without a cursor.
+type Config struct {
+	logscan.Config
+
+	// PoolManager is the address of the Uniswap v4 PoolManager.
+	PoolManager common.Address
+	// Pools are the IDs of the pools whose swaps are recorded.
+	Pools []common.Hash
+}
+
+// Indexer indexes PoolManager Swap events of the tracked pools.
+type Indexer struct {
+	scanner     *logscan.Scanner
+	repo        volume.Repository
+	poolManager common.Address
+	pools       []common.Hash
+	logger      *slog.Logger
+}
+
+// New creates an indexer.
+func New(client ChainClient, repo volume.Repository, cfg Config, logger *slog.Logger) *Indexer {
+	return &Indexer{
+		scanner:     logscan.New(client, client, cfg.Config, defaults, logger),
+		repo:        repo,
+		poolManager: cfg.PoolManager,
+		pools:       cfg.Pools,
+		logger:      logger,
+	}
+}
+
+// Run records new swaps until ctx is cancelled.
+func (ix *Indexer) Run(ctx context.Context) {
+	ix.scanner.Run(ctx, "swap indexer", ix.Sync)
+}
+
+// Sync indexes the swaps of every tracked pool up to the latest confirmed block. A pool that
+// fails does not hold back the others.
+func (ix *Indexer) Sync(ctx context.Context) error {
+	safe, ok, err := ix.scanner.SafeBlock(ctx)
+	if err != nil || !ok {
+		return err //nolint:wrapcheck // already wrapped by the scanner
+	}
+
+	var errs []error
+
+	for _, poolID := range ix.pools {
+		q := ethereum.FilterQuery{
+			Addresses: []common.Address{ix.poolManager},
+			Topics:    [][]common.Hash{{volume.SwapTopic()}, {poolID}},
+		}
+
+		if _, err := logscan.Sync(ctx, ix.scanner, q, safe, poolCursor{ix: ix, poolID: poolID}, ix.decode); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err() //nolint:wrapcheck // cancellation
+			}
+
+			errs = append(errs, fmt.Errorf("pool %s: %w", poolID.Hex(), err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (ix *Indexer) decode(lg logscan.Log) (volume.Swap, error) {
+	s, err := volume.ParseSwap(lg.Log)
+	if err != nil {
+		ix.logger.Warn("skipping swap log", slog.String("tx", lg.TxHash.Hex()), slog.Any("error", err))
+
+		return volume.Swap{}, logscan.ErrSkip
+	}
+
+	s.BlockTime = lg.BlockTime
+
+	return s, nil
+}
+
+// poolCursor is the swap cursor of one pool.
+type poolCursor struct {
+	ix     *Indexer
+	poolID common.Hash
+}
+
+func (c poolCursor) IndexedBlock(ctx context.Context) (uint64, bool, error) {
+	block, err := c.ix.repo.IndexedBlock(ctx, c.poolID)
+	if errors.Is(err, volume.ErrNotIndexed) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err //nolint:wrapcheck // wrapped by logscan.Sync
+	}
+
+	return block, true, nil
+}
+
+func (c poolCursor) Save(ctx context.Context, swaps []volume.Swap, block uint64) error {
+	if err := c.ix.repo.SaveBatch(ctx, c.poolID, swaps, block); err != nil {
+		return fmt.Errorf("save swaps: %w", err)
+	}
+
+	if len(swaps) > 0 {
+		c.ix.logger.InfoContext(ctx, "swaps indexed",
+			slog.String("pool_id", c.poolID.Hex()),
+			slog.Int("swaps", len(swaps)),
+			slog.Uint64("to_block", block))
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"remora/internal/logscan"
+	"remora/internal/volume"
+)
+
+var (
+	poolManager = common.HexToAddress("0x000000000004444c5dc75cB358380D2e3dE08A90")
+	ethUSDC     = common.Hash{1}
+	usdcUSDT    = common.Hash{2}
+)
+
+func swapLog(t *testing.T, poolID common.Hash, block uint64, index uint) types.Log {
+	t.Helper()
+
+	newType := func(name string) abi.Type {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return typ
+	}
+
+	args := abi.Arguments{
+		{Type: newType("int128")}, {Type: newType("int128")}, {Type: newType("uint160")},
+		{Type: newType("uint128")}, {Type: newType("int24")}, {Type: newType("uint24")},
+	}
+
+	data, err := args.Pack(big.NewInt(-1000), big.NewInt(990), big.NewInt(1), big.NewInt(1), big.NewInt(0), big.NewInt(500))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return types.Log{
+		Address:     poolManager,
+		Topics:      []common.Hash{volume.SwapTopic(), poolID, {}},
+		Data:        data,
+		BlockNumber: block,
+		Index:       index,
+	}
+}
+
+// fakeChain serves logs by block and pool topic, dates block n at second n, and rejects
+// ranges wider than maxRange.
+type fakeChain struct {
+	head     uint64
+	logs     []types.Log
+	maxRange uint64
+	headers  atomic.Int32
+}
+
+func (f *fakeChain) BlockNumber(context.Context) (uint64, error) { return f.head, nil }
+
+func (f *fakeChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	from, to := q.FromBlock.Uint64(), q.ToBlock.Uint64()
+	if f.maxRange > 0 && to-from+1 > f.maxRange {
+		return nil, errors.New("block range too large")
+	}
+
+	var out []types.Log
+
+	for _, lg := range f.logs {
+		if lg.BlockNumber >= from && lg.BlockNumber <= to && lg.Topics[1] == q.Topics[1][0] {
+			out = append(out, lg)
+		}
+	}
+
+	return out, nil
+}
+
+func (f *fakeChain) HeaderByNumber(_ context.Context, number *big.Int) (*types.Header, error) {
+	f.headers.Add(1)
+
+	return &types.Header{Number: number, Time: number.Uint64()}, nil
+}
+
+type memRepo struct {
+	swaps   map[common.Hash][]volume.Swap
+	indexed map[common.Hash]uint64
+}
+
+func (m *memRepo) IndexedBlock(_ context.Context, poolID common.Hash) (uint64, error) {
+	block, ok := m.indexed[poolID]
+	if !ok {
+		return 0, volume.ErrNotIndexed
+	}
+
+	return block, nil
+}
+
+func (m *memRepo) SaveBatch(_ context.Context, poolID common.Hash, swaps []volume.Swap, indexedBlock uint64) error {
+	m.swaps[poolID] = append(m.swaps[poolID], swaps...)
+	m.indexed[poolID] = indexedBlock
+
+	return nil
+}
+
+func (m *memRepo) LatestSwap(context.Context, common.Hash, time.Time) (*volume.Swap, error) {
+	return nil, volume.ErrNoSwaps
+}
+
+func (m *memRepo) VolumeByTick(context.Context, volume.Query) ([]volume.TickBin, error) {
+	return nil, nil
+}
+
+func (m *memRepo) VolumeByTime(context.Context, volume.Query) ([]volume.Bucket, error) {
+	return nil, nil
+}
+
+func (m *memRepo) RangeFees(context.Context, volume.Query) (*volume.RangeFees, error) {
+	return &volume.RangeFees{}, nil
+}
+
+func TestIndexer_Sync(t *testing.T) {
+	chain := &fakeChain{head: 130, maxRange: 50, logs: []types.Log{
+		swapLog(t, ethUSDC, 105, 0),
+		swapLog(t, ethUSDC, 105, 3),
+		swapLog(t, usdcUSDT, 110, 1),
+	}}
+	repo := &memRepo{swaps: make(map[common.Hash][]volume.Swap), indexed: make(map[common.Hash]uint64)}
+
+	ix := New(chain, repo, Config{
+		Config:      logscan.Config{StartBlock: 100, Confirmations: 10, BlockRange: 200},
+		PoolManager: poolManager,
+		Pools:       []common.Hash{ethUSDC},
+	}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := ix.Sync(t.Context()); err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+
+	if repo.indexed[ethUSDC] != 120 || len(repo.swaps[ethUSDC]) != 2 {
+		t.Fatalf("ETH/USDC indexed = %d with %d swaps, want 120 and 2", repo.indexed[ethUSDC], len(repo.swaps[ethUSDC]))
+	}
+
+	if s := repo.swaps[ethUSDC][1]; s.LogIndex != 3 || !s.BlockTime.Equal(time.Unix(105, 0)) || s.Fee != 500 {
+		t.Errorf("swap = %+v", s)
+	}
+
+	if chain.headers.Load() != 1 {
+		t.Errorf("header reads = %d, want 1 for one block", chain.headers.Load())
+	}
+
+	if _, ok := repo.indexed[usdcUSDT]; ok {
+		t.Error("untracked pool indexed")
+	}
+
+	// A newly tracked pool is backfilled from StartBlock while the other resumes at its cursor.
+	ix.pools = append(ix.pools, usdcUSDT)
+	chain.head = 160
+	chain.logs = append(chain.logs, swapLog(t, ethUSDC, 140, 0))
+
+	if err := ix.Sync(t.Context()); err != nil {
+		t.Fatalf("second Sync() error = %v", err)
+	}
+
+	if repo.indexed[ethUSDC] != 150 || len(repo.swaps[ethUSDC]) != 3 {
+		t.Errorf("ETH/USDC indexed = %d with %d swaps, want 150 and 3", repo.indexed[ethUSDC], len(repo.swaps[ethUSDC]))
+	}
+
+	if repo.indexed[usdcUSDT] != 150 || len(repo.swaps[usdcUSDT]) != 1 {
+		t.Errorf("USDC/USDT indexed = %d with %d swaps, want 150 and 1", repo.indexed[usdcUSDT], len(repo.swaps[usdcUSDT]))
+	}
+}
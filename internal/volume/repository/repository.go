@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/shopspring/decimal"
+
+	"remora/internal/db"
+	"remora/internal/volume"
+)
+
+// cursorPrefix prefixes the indexer_cursor row of each pool's swap indexer.
+const cursorPrefix = "pool_swap:"
+
+// Repository stores swaps in Postgres and aggregates them in SQL.
+type Repository struct {
+	pool *pgxpool.Pool
+	q    *db.Queries
+}
+
+var _ volume.Repository = (*Repository)(nil)
+
+func New(pgPool *pgxpool.Pool) *Repository {
+	return &Repository{pool: pgPool, q: db.New(pgPool)}
+}
+
+func (r *Repository) IndexedBlock(ctx context.Context, poolID common.Hash) (uint64, error) {
+	block, err := r.q.GetIndexerCursor(ctx, cursorPrefix+poolID.Hex())
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, volume.ErrNotIndexed
+		}
+
+		return 0, fmt.Errorf("get swap indexer cursor: %w", err)
+	}
+
+	return uint64(block), nil //nolint:gosec // block numbers are non-negative
+}
+
+func (r *Repository) SaveBatch(ctx context.Context, poolID common.Hash, swaps []volume.Swap, indexedBlock uint64) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck // no-op after commit
+
+	q := r.q.WithTx(tx)
+	now := time.Now().UTC()
+
+	for _, s := range swaps {
+		if err := q.InsertPoolSwap(ctx, db.InsertPoolSwapParams{
+			PoolID:       s.PoolID.Hex(),
+			BlockNumber:  int64(s.BlockNumber), //nolint:gosec // block numbers fit in int64
+			LogIndex:     int(s.LogIndex),      //nolint:gosec // log indexes fit in int
+			TxHash:       s.TxHash.Hex(),
+			BlockTime:    s.BlockTime,
+			Sender:       s.Sender.Hex(),
+			Amount0:      decimal.NewFromBigInt(s.Amount0, 0),
+			Amount1:      decimal.NewFromBigInt(s.Amount1, 0),
+			SqrtPriceX96: decimal.NewFromBigInt(s.SqrtPriceX96, 0),
+			Liquidity:    decimal.NewFromBigInt(s.Liquidity, 0),
+			Tick:         int(s.Tick),
+			Fee:          int(s.Fee),
+			CreatedAt:    now,
+		}); err != nil {
+			return fmt.Errorf("insert swap: %w", err)
+		}
+	}
+
+	if err := q.UpsertIndexerCursor(ctx, db.UpsertIndexerCursorParams{
+		Name:        cursorPrefix + poolID.Hex(),
+		BlockNumber: int64(indexedBlock), //nolint:gosec // block numbers fit in int64
+		UpdatedAt:   now,
+	}); err != nil {
+		return fmt.Errorf("save swap indexer cursor: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit tx: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Repository) LatestSwap(ctx context.Context, poolID common.Hash, before time.Time) (*volume.Swap, error) {
+	row, err := r.q.GetLatestPoolSwap(ctx, db.GetLatestPoolSwapParams{PoolID: poolID.Hex(), Until: before})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, volume.ErrNoSwaps
+		}
+
+		return nil, fmt.Errorf("get latest swap: %w", err)
+	}
+
+	return &volume.Swap{
+		PoolID:       common.HexToHash(row.PoolID),
+		BlockNumber:  uint64(row.BlockNumber), //nolint:gosec // block numbers are non-negative
+		LogIndex:     uint(row.LogIndex),      //nolint:gosec // log indexes are non-negative
+		TxHash:       common.HexToHash(row.TxHash),
+		BlockTime:    row.BlockTime,
+		Sender:       common.HexToAddress(row.Sender),
+		Amount0:      row.Amount0.BigInt(),
+		Amount1:      row.Amount1.BigInt(),
+		SqrtPriceX96: row.SqrtPriceX96.BigInt(),
+		Liquidity:    row.Liquidity.BigInt(),
+		Tick:         int32(row.Tick), //nolint:gosec // int24
+		Fee:          uint32(row.Fee), //nolint:gosec // uint24
+	}, nil
+}
+
+func (r *Repository) VolumeByTick(ctx context.Context, q volume.Query) ([]volume.TickBin, error) {
+	rows, err := r.q.ListPoolSwapVolumeByTick(ctx, db.ListPoolSwapVolumeByTickParams{
+		BinSize: int(q.BinSizeTicks),
+		PoolID:  q.PoolID.Hex(),
+		Since:   q.From,
+		Until:   q.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list volume by tick: %w", err)
+	}
+
+	bins := make([]volume.TickBin, len(rows))
+	for i, row := range rows {
+		bins[i] = volume.TickBin{
+			TickLower: int32(row.TickLower),                  //nolint:gosec // int24
+			TickUpper: int32(row.TickLower) + q.BinSizeTicks, //nolint:gosec // int24
+			Stats:     toStats(row.Swaps, row.Volume0, row.Volume1, row.Fees0, row.Fees1),
+		}
+	}
+
+	return bins, nil
+}
+
+func (r *Repository) VolumeByTime(ctx context.Context, q volume.Query) ([]volume.Bucket, error) {
+	rows, err := r.q.ListPoolSwapVolumeByTime(ctx, db.ListPoolSwapVolumeByTimeParams{
+		BucketSeconds: int(q.Interval / time.Second),
+		PoolID:        q.PoolID.Hex(),
+		Since:         q.From,
+		Until:         q.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list volume by time: %w", err)
+	}
+
+	buckets := make([]volume.Bucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = volume.Bucket{
+			Start: row.BucketStart.UTC(),
+			Stats: toStats(row.Swaps, row.Volume0, row.Volume1, row.Fees0, row.Fees1),
+		}
+	}
+
+	return buckets, nil
+}
+
+func (r *Repository) RangeFees(ctx context.Context, q volume.Query) (*volume.RangeFees, error) {
+	row, err := r.q.GetPoolSwapRangeFees(ctx, db.GetPoolSwapRangeFeesParams{
+		PoolID:    q.PoolID.Hex(),
+		Since:     q.From,
+		Until:     q.To,
+		TickLower: int(q.Range.TickLower),
+		TickUpper: int(q.Range.TickUpper),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get range fees: %w", err)
+	}
+
+	return &volume.RangeFees{
+		Swaps:             row.Swaps,
+		Fees0PerLiquidity: row.Fees0PerLiquidity.InexactFloat64(),
+		Fees1PerLiquidity: row.Fees1PerLiquidity.InexactFloat64(),
+	}, nil
+}
+
+// toStats floors fee sums to whole token units.
+func toStats(swaps int64, volume0, volume1, fees0, fees1 decimal.Decimal) volume.Stats {
+	return volume.Stats{
+		Swaps:   swaps,
+		Volume0: volume0.BigInt(),
+		Volume1: volume1.BigInt(),
+		Fees0:   fees0.Floor().BigInt(),
+		Fees1:   fees1.Floor().BigInt(),
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/allocation"
+	"remora/internal/volume"
+)
+
+const (
+	// maxWindow bounds the span of one report.
+	maxWindow = 90 * 24 * time.Hour
+	// maxBuckets bounds the number of time buckets in one report.
+	maxBuckets = 2000
+)
+
+// Service reports swap activity of the tracked pools.
+type Service struct {
+	repo    volume.Repository
+	tracked map[common.Hash]struct{}
+}
+
+var _ volume.Service = (*Service)(nil)
+
+// New creates a volume service for the pools whose swaps are indexed.
+func New(repo volume.Repository, pools []common.Hash) *Service {
+	tracked := make(map[common.Hash]struct{}, len(pools))
+	for _, id := range pools {
+		tracked[id] = struct{}{}
+	}
+
+	return &Service{repo: repo, tracked: tracked}
+}
+
+func (s *Service) Report(ctx context.Context, q volume.Query) (*volume.Report, error) {
+	if err := validate(q); err != nil {
+		return nil, err
+	}
+
+	if _, ok := s.tracked[q.PoolID]; !ok {
+		return nil, fmt.Errorf("%w: %s", volume.ErrNotTracked, q.PoolID.Hex())
+	}
+
+	indexed, err := s.repo.IndexedBlock(ctx, q.PoolID)
+	if err != nil && !errors.Is(err, volume.ErrNotIndexed) {
+		return nil, fmt.Errorf("get indexed block: %w", err)
+	}
+
+	bins, err := s.repo.VolumeByTick(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("volume by tick: %w", err)
+	}
+
+	buckets, err := s.repo.VolumeByTime(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("volume by time: %w", err)
+	}
+
+	report := &volume.Report{
+		PoolID:       q.PoolID,
+		From:         q.From,
+		To:           q.To,
+		IndexedBlock: indexed,
+		Total:        total(bins),
+		Bins:         bins,
+		Buckets:      buckets,
+	}
+
+	if q.Range != nil {
+		report.FeeAPR, err = s.feeAPR(ctx, q)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// feeAPR estimates the APR of q.Range at the price of the last swap before the window ends.
+// A pool that has never swapped earns nothing.
+func (s *Service) feeAPR(ctx context.Context, q volume.Query) (*volume.FeeAPR, error) {
+	fees, err := s.repo.RangeFees(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("range fees: %w", err)
+	}
+
+	apr := &volume.FeeAPR{TickRange: *q.Range, Swaps: fees.Swaps}
+
+	latest, err := s.repo.LatestSwap(ctx, q.PoolID, q.To)
+	switch {
+	case errors.Is(err, volume.ErrNoSwaps):
+		return apr, nil
+	case err != nil:
+		return nil, fmt.Errorf("latest swap: %w", err)
+	}
+
+	apr.APR = volume.EstimateAPR(*fees, *q.Range, latest.SqrtPriceX96, q.To.Sub(q.From))
+
+	return apr, nil
+}
+
+func validate(q volume.Query) error {
+	window := q.To.Sub(q.From)
+
+	switch {
+	case window <= 0:
+		return fmt.Errorf("%w: from must be before to", volume.ErrInvalidQuery)
+	case window > maxWindow:
+		return fmt.Errorf("%w: window exceeds %s", volume.ErrInvalidQuery, maxWindow)
+	case q.BinSizeTicks <= 0:
+		return fmt.Errorf("%w: bin size must be positive", volume.ErrInvalidQuery)
+	case q.Interval < time.Second:
+		return fmt.Errorf("%w: interval must be at least 1s", volume.ErrInvalidQuery)
+	case window/q.Interval > maxBuckets:
+		return fmt.Errorf("%w: more than %d buckets", volume.ErrInvalidQuery, maxBuckets)
+	case q.Range != nil && q.Range.TickLower >= q.Range.TickUpper:
+		return fmt.Errorf("%w: tickLower must be below tickUpper", volume.ErrInvalidQuery)
+	case q.Range != nil && (q.Range.TickLower < allocation.MinTick || q.Range.TickUpper > allocation.MaxTick):
+		return fmt.Errorf("%w: range outside [%d, %d]", volume.ErrInvalidQuery, allocation.MinTick, allocation.MaxTick)
+	}
+
+	return nil
+}
+
+func total(bins []volume.TickBin) volume.Stats {
+	t := volume.Stats{
+		Volume0: new(big.Int),
+		Volume1: new(big.Int),
+		Fees0:   new(big.Int),
+		Fees1:   new(big.Int),
+	}
+
+	for _, b := range bins {
+		t.Swaps += b.Swaps
+		t.Volume0.Add(t.Volume0, b.Volume0)
+		t.Volume1.Add(t.Volume1, b.Volume1)
+		t.Fees0.Add(t.Fees0, b.Fees0)
+		t.Fees1.Add(t.Fees1, b.Fees1)
+	}
+
+	return t
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"remora/internal/volume"
+)
+
+var poolID = common.Hash{1}
+
+type fakeRepo struct {
+	bins   []volume.TickBin
+	fees   volume.RangeFees
+	latest *volume.Swap
+}
+
+func (f *fakeRepo) IndexedBlock(context.Context, common.Hash) (uint64, error) { return 42, nil }
+
+func (f *fakeRepo) SaveBatch(context.Context, common.Hash, []volume.Swap, uint64) error { return nil }
+
+func (f *fakeRepo) LatestSwap(context.Context, common.Hash, time.Time) (*volume.Swap, error) {
+	if f.latest == nil {
+		return nil, volume.ErrNoSwaps
+	}
+
+	return f.latest, nil
+}
+
+func (f *fakeRepo) VolumeByTick(context.Context, volume.Query) ([]volume.TickBin, error) {
+	return f.bins, nil
+}
+
+func (f *fakeRepo) VolumeByTime(context.Context, volume.Query) ([]volume.Bucket, error) {
+	return nil, nil
+}
+
+func (f *fakeRepo) RangeFees(context.Context, volume.Query) (*volume.RangeFees, error) {
+	return &f.fees, nil
+}
+
+func stats(swaps, v0, v1, f0, f1 int64) volume.Stats {
+	return volume.Stats{Swaps: swaps, Volume0: big.NewInt(v0), Volume1: big.NewInt(v1), Fees0: big.NewInt(f0), Fees1: big.NewInt(f1)}
+}
+
+func testQuery() volume.Query {
+	to := time.Unix(1_700_000_000, 0)
+
+	return volume.Query{
+		PoolID:       poolID,
+		From:         to.Add(-24 * time.Hour),
+		To:           to,
+		BinSizeTicks: 60,
+		Interval:     time.Hour,
+		Range:        &volume.TickRange{TickLower: -60, TickUpper: 60},
+	}
+}
+
+func TestService_Report(t *testing.T) {
+	t.Parallel()
+
+	repo := &fakeRepo{
+		bins: []volume.TickBin{
+			{TickLower: -60, TickUpper: 0, Stats: stats(2, 100, 200, 1, 0)},
+			{TickLower: 0, TickUpper: 60, Stats: stats(1, 50, 40, 0, 2)},
+		},
+		fees:   volume.RangeFees{Swaps: 3, Fees1PerLiquidity: 1e-6},
+		latest: &volume.Swap{SqrtPriceX96: new(big.Int).Lsh(big.NewInt(1), 96)},
+	}
+	svc := New(repo, []common.Hash{poolID})
+
+	report, err := svc.Report(t.Context(), testQuery())
+	if err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	if report.IndexedBlock != 42 || report.Total.Swaps != 3 || report.Total.Volume0.Int64() != 150 || report.Total.Fees1.Int64() != 2 {
+		t.Errorf("report = %+v, total = %+v", report, report.Total)
+	}
+
+	if report.FeeAPR == nil || report.FeeAPR.Swaps != 3 || report.FeeAPR.APR <= 0 {
+		t.Errorf("FeeAPR = %+v, want a positive estimate over 3 swaps", report.FeeAPR)
+	}
+
+	// Without any swap to price the range, the estimate is zero.
+	repo.latest = nil
+
+	report, err = svc.Report(t.Context(), testQuery())
+	if err != nil {
+		t.Fatalf("Report() without swaps error = %v", err)
+	}
+
+	if report.FeeAPR == nil || report.FeeAPR.APR != 0 {
+		t.Errorf("FeeAPR = %+v, want zero", report.FeeAPR)
+	}
+}
+
+func TestService_ReportRejects(t *testing.T) {
+	t.Parallel()
+
+	svc := New(&fakeRepo{}, []common.Hash{poolID})
+
+	tests := []struct {
+		name    string
+		modify  func(q *volume.Query)
+		wantErr error
+	}{
+		{name: "untracked pool", modify: func(q *volume.Query) { q.PoolID = common.Hash{2} }, wantErr: volume.ErrNotTracked},
+		{name: "empty window", modify: func(q *volume.Query) { q.From = q.To }, wantErr: volume.ErrInvalidQuery},
+		{name: "long window", modify: func(q *volume.Query) { q.From = q.To.Add(-100 * 24 * time.Hour) }, wantErr: volume.ErrInvalidQuery},
+		{name: "zero bin size", modify: func(q *volume.Query) { q.BinSizeTicks = 0 }, wantErr: volume.ErrInvalidQuery},
+		{name: "too many buckets", modify: func(q *volume.Query) { q.Interval = time.Second }, wantErr: volume.ErrInvalidQuery},
+		{name: "inverted range", modify: func(q *volume.Query) { q.Range = &volume.TickRange{TickLower: 60, TickUpper: -60} }, wantErr: volume.ErrInvalidQuery},
+		{name: "range out of bounds", modify: func(q *volume.Query) { q.Range = &volume.TickRange{TickLower: -900000, TickUpper: 0} }, wantErr: volume.ErrInvalidQuery},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			q := testQuery()
+			tt.modify(&q)
+
+			if _, err := svc.Report(t.Context(), q); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Report() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package volume records swaps of tracked Uniswap v4 pools from PoolManager Swap events and
+// aggregates their volume and fees by tick bin and time bucket, so fee income can be compared
+// against where liquidity is placed.
+package volume
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Swap is a PoolManager Swap event. Amounts are the swapper's balance deltas, so the negative
+// side is the amount paid into the pool. SqrtPriceX96, Liquidity and Tick are the pool state
+// after the swap.
+type Swap struct {
+	PoolID       common.Hash
+	BlockNumber  uint64
+	LogIndex     uint
+	TxHash       common.Hash
+	BlockTime    time.Time
+	Sender       common.Address
+	Amount0      *big.Int
+	Amount1      *big.Int
+	SqrtPriceX96 *big.Int
+	Liquidity    *big.Int
+	Tick         int32
+	Fee          uint32 // Total swap fee in hundredths of a bip
+}
+
+// Stats aggregates swaps. Volumes count both directions; fees are charged on the amount paid
+// in, in raw token units.
+type Stats struct {
+	Swaps   int64
+	Volume0 *big.Int
+	Volume1 *big.Int
+	Fees0   *big.Int
+	Fees1   *big.Int
+}
+
+// TickBin is the activity of swaps that ended in [TickLower, TickUpper).
+type TickBin struct {
+	TickLower int32
+	TickUpper int32
+	Stats
+}
+
+// Bucket is the activity of swaps in [Start, Start+Interval).
+type Bucket struct {
+	Start time.Time
+	Stats
+}
+
+// Query selects the swaps of a pool in [From, To). Range is the candidate position to estimate
+// the fee APR of; nil skips the estimate.
+type Query struct {
+	PoolID       common.Hash
+	From         time.Time
+	To           time.Time
+	BinSizeTicks int32
+	Interval     time.Duration
+	Range        *TickRange
+}
+
+// TickRange is a position range [TickLower, TickUpper).
+type TickRange struct {
+	TickLower int32
+	TickUpper int32
+}
+
+// RangeFees is the fee income of one unit of liquidity over a tick range, in raw token units.
+type RangeFees struct {
+	Swaps             int64
+	Fees0PerLiquidity float64
+	Fees1PerLiquidity float64
+}
+
+// FeeAPR is the estimated fee APR of a position over a tick range, from the fees one unit of
+// liquidity over the range earned in the window and its value at the latest swap price.
+type FeeAPR struct {
+	TickRange
+	Swaps int64
+	APR   float64 // Annualized fraction, e.g. 0.12 for 12%
+}
+
+// Report is the swap activity of a pool over a window.
+type Report struct {
+	PoolID       common.Hash
+	From         time.Time
+	To           time.Time
+	IndexedBlock uint64
+	Total        Stats
+	Bins         []TickBin
+	Buckets      []Bucket
+	FeeAPR       *FeeAPR // nil unless a range was queried
+}
+
+// Service reports swap activity of tracked pools.
+type Service interface {
+	// Report returns ErrNotTracked for pools whose swaps are not recorded.
+	Report(ctx context.Context, q Query) (*Report, error)
+}
+
+// Repository stores swaps and each pool's indexing progress.
+type Repository interface {
+	// IndexedBlock returns the last block indexed for a pool, or ErrNotIndexed before the
+	// first batch.
+	IndexedBlock(ctx context.Context, poolID common.Hash) (uint64, error)
+
+	// SaveBatch stores swaps and advances the pool's indexed block in one transaction.
+	SaveBatch(ctx context.Context, poolID common.Hash, swaps []Swap, indexedBlock uint64) error
+
+	// LatestSwap returns the last swap before t, or ErrNoSwaps.
+	LatestSwap(ctx context.Context, poolID common.Hash, before time.Time) (*Swap, error)
+
+	VolumeByTick(ctx context.Context, q Query) ([]TickBin, error)
+	VolumeByTime(ctx context.Context, q Query) ([]Bucket, error)
+	RangeFees(ctx context.Context, q Query) (*RangeFees, error)
+}
@@ -0,0 +1,100 @@
+package volume
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func swapLog(t *testing.T, poolID common.Hash, amount0, amount1 int64, tick int32) types.Log {
+	t.Helper()
+
+	data, err := swapEvent.Inputs.NonIndexed().Pack(
+		big.NewInt(amount0),
+		big.NewInt(amount1),
+		new(big.Int).Lsh(big.NewInt(1), 96),
+		big.NewInt(1_000_000),
+		big.NewInt(int64(tick)),
+		big.NewInt(3000),
+	)
+	if err != nil {
+		t.Fatalf("pack swap data: %v", err)
+	}
+
+	return types.Log{
+		Topics: []common.Hash{
+			SwapTopic(),
+			poolID,
+			common.BytesToHash(common.HexToAddress("0x66a9893cC07D91D95644AEDD05D03f95e1dBA8Af").Bytes()),
+		},
+		Data:        data,
+		BlockNumber: 9,
+		Index:       4,
+	}
+}
+
+func TestParseSwap(t *testing.T) {
+	t.Parallel()
+
+	poolID := common.Hash{7}
+
+	s, err := ParseSwap(swapLog(t, poolID, -1000, 997, -12))
+	if err != nil {
+		t.Fatalf("ParseSwap() error = %v", err)
+	}
+
+	if s.PoolID != poolID || s.BlockNumber != 9 || s.LogIndex != 4 || s.Tick != -12 || s.Fee != 3000 {
+		t.Errorf("swap = %+v", s)
+	}
+
+	if s.Amount0.Int64() != -1000 || s.Amount1.Int64() != 997 || s.Liquidity.Int64() != 1_000_000 {
+		t.Errorf("amounts = %s, %s, liquidity = %s", s.Amount0, s.Amount1, s.Liquidity)
+	}
+
+	if s.Sender != common.HexToAddress("0x66a9893cC07D91D95644AEDD05D03f95e1dBA8Af") {
+		t.Errorf("Sender = %s", s.Sender.Hex())
+	}
+
+	lg := swapLog(t, poolID, 1, -1, 0)
+	lg.Topics = lg.Topics[:2]
+
+	if _, err := ParseSwap(lg); !errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("ParseSwap() short topics error = %v, want %v", err, ErrInvalidEvent)
+	}
+}
+
+func TestEstimateAPR(t *testing.T) {
+	t.Parallel()
+
+	// At price 1 a unit of liquidity over [-60, 60) holds 1 - 1.0001^-30 of each token.
+	q96 := new(big.Int).Lsh(big.NewInt(1), 96)
+	r := TickRange{TickLower: -60, TickUpper: 60}
+	value := 2 * (1 - math.Pow(1.0001, -30))
+
+	tests := []struct {
+		name   string
+		fees   RangeFees
+		window time.Duration
+		want   float64
+	}{
+		{name: "token1 fees over a year", fees: RangeFees{Fees1PerLiquidity: value * 0.1}, window: year, want: 0.1},
+		{name: "split fees over half a year", fees: RangeFees{Fees0PerLiquidity: value * 0.05, Fees1PerLiquidity: value * 0.05}, window: year / 2, want: 0.2},
+		{name: "no window", fees: RangeFees{Fees1PerLiquidity: 1}, window: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := EstimateAPR(tt.fees, r, q96, tt.window)
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("EstimateAPR() = %g, want %g", got, tt.want)
+			}
+		})
+	}
+}